@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/piatoss3612/my-study-bot/internal/pubsub/rabbitmq"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+)
+
+const deadLetterCmd = "dlq"
+
+// usage: logger dlq <list|replay> [-limit n]
+func runDeadLetterCmd(args []string) {
+	if len(args) == 0 {
+		printDeadLetterUsage()
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet(deadLetterCmd, flag.ExitOnError)
+	limit := fs.Int("limit", 0, "maximum number of dead letters, 0 means all")
+	_ = fs.Parse(args[1:])
+
+	cfg := mustLoadConfig(os.Getenv("LOGGER_CONFIG_FILE"))
+
+	rabbit, err := utils.DialRabbitMQ(cfg.RabbitMQ.Addr)
+	if err != nil {
+		sugar.Fatal(err)
+	}
+	defer func() { _ = rabbit.Close() }()
+
+	dl := rabbitmq.NewDeadLetters(rabbit, cfg.RabbitMQ.Queue)
+
+	switch args[0] {
+	case "list":
+		letters, err := dl.List(*limit)
+		if err != nil {
+			sugar.Fatal(err)
+		}
+
+		for i, l := range letters {
+			fmt.Printf("[%d] topic=%s reason=%s retries=%d timestamp=%s\n%s\n",
+				i+1, l.Topic, l.Reason, l.RetryCount, l.Timestamp.Format(time.RFC3339), string(l.Body))
+		}
+
+		fmt.Printf("%d dead letters\n", len(letters))
+	case "replay":
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+		defer cancel()
+
+		cnt, err := dl.Replay(ctx, *limit)
+		if err != nil {
+			sugar.Errorw("Failed to replay dead letters", "error", err, "replayed", cnt)
+		}
+
+		fmt.Printf("%d dead letters replayed\n", cnt)
+	default:
+		printDeadLetterUsage()
+		os.Exit(1)
+	}
+}
+
+func printDeadLetterUsage() {
+	fmt.Println("usage: logger dlq <list|replay> [-limit n]")
+}
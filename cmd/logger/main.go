@@ -4,21 +4,19 @@ import (
 	"context"
 	"log"
 	"os"
-	"strconv"
 	"time"
+	_ "time/tzdata" // time zones of studies do not depend on zoneinfo of the host
 
 	_ "github.com/joho/godotenv/autoload"
+	"github.com/piatoss3612/my-study-bot/internal/cache/redis"
 	"github.com/piatoss3612/my-study-bot/internal/config"
+	"github.com/piatoss3612/my-study-bot/internal/logger/dedup"
 	"github.com/piatoss3612/my-study-bot/internal/logger/service"
 	"github.com/piatoss3612/my-study-bot/internal/pubsub"
 	"github.com/piatoss3612/my-study-bot/internal/pubsub/rabbitmq"
-	"github.com/piatoss3612/my-study-bot/internal/study"
 	"github.com/piatoss3612/my-study-bot/internal/study/event"
 	"github.com/piatoss3612/my-study-bot/internal/utils"
 	"go.uber.org/zap"
-	"golang.org/x/oauth2/google"
-	"google.golang.org/api/option"
-	"google.golang.org/api/sheets/v4"
 )
 
 var sugar *zap.SugaredLogger
@@ -39,6 +37,12 @@ func main() {
 
 	mustSetTimezone(os.Getenv("TIME_ZONE"))
 
+	// run subcommand for dead-lettered events
+	if len(os.Args) > 1 && os.Args[1] == deadLetterCmd {
+		runDeadLetterCmd(os.Args[2:])
+		return
+	}
+
 	run()
 }
 
@@ -48,41 +52,40 @@ func run() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	sub, close := mustInitSubscriber(ctx, cfg.RabbitMQ.Addr, cfg.RabbitMQ.Exchange, cfg.RabbitMQ.Kind, cfg.RabbitMQ.Queue)
+	var subOpts []rabbitmq.SubscriberOptsFunc
+
+	if cfg.RabbitMQ.MaxRetries > 0 {
+		subOpts = append(subOpts, rabbitmq.WithMaxRetries(cfg.RabbitMQ.MaxRetries))
+	}
+
+	if cfg.RabbitMQ.RetryDelay > 0 {
+		subOpts = append(subOpts, rabbitmq.WithRetryDelay(cfg.RabbitMQ.RetryDelay))
+	}
+
+	sub, close := mustInitSubscriber(ctx, cfg.RabbitMQ.Addr, cfg.RabbitMQ.Exchange, cfg.RabbitMQ.Kind, cfg.RabbitMQ.Queue, subOpts...)
 	defer func() {
 		_ = close()
 		sugar.Info("RabbitMQ connection is closed!")
 	}()
 
-	sheetsSvc := mustInitSheetsService(ctx)
+	store := mustInitDedupStore(ctx, cfg.Redis.Addr)
 
-	sugar.Info("Sheets service is ready!")
+	sugar.Info("Dedup store is ready!")
 
-	sh := mustInitStudyEventHandler(ctx, sheetsSvc)
+	sh := mustInitStudyEventHandler(ctx, cfg.Sinks, store)
 
-	mapper := pubsub.NewMapper()
+	sugar.Info("Event sinks are ready!")
 
-	type mapping struct {
-		topic string
-		h     pubsub.Handler
-	}
-
-	mappings := []mapping{
-		{topic: study.EventTopicStudyRoundCreated.String(), h: sh},
-		{topic: study.EventTopicStudyRoundFinished.String(), h: sh},
-		{topic: study.EventTopicStudyRoundProgress.String(), h: sh},
-	}
-
-	topics := make([]string, 0, len(mappings))
+	mapper := pubsub.NewMapper()
+	topics := event.Topics()
 
-	for _, m := range mappings {
-		mapper.Register(m.topic, m.h)
-		topics = append(topics, m.topic)
+	for _, topic := range topics {
+		mapper.Register(topic, sh)
 	}
 
 	sugar.Info("Event handlers are ready!")
 
-	svc := service.New(sub, mapper, sugar)
+	svc := service.New(sub, mapper, sugar, service.WithDedupStore(store))
 	defer func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -107,14 +110,14 @@ func mustLoadConfig(path string) *config.LoggerConfig {
 	return cfg
 }
 
-func mustInitSubscriber(ctx context.Context, addr, exchange, kind, queue string) (pubsub.Subscriber, func() error) {
+func mustInitSubscriber(ctx context.Context, addr, exchange, kind, queue string, opts ...rabbitmq.SubscriberOptsFunc) (pubsub.Subscriber, func() error) {
 	rabbit := <-utils.RedialRabbitMQ(ctx, addr)
 
 	if rabbit == nil {
 		sugar.Fatal("Failed to connect to RabbitMQ")
 	}
 
-	sub, err := rabbitmq.NewSubscriber(rabbit, exchange, kind, queue)
+	sub, err := rabbitmq.NewSubscriber(rabbit, exchange, kind, queue, opts...)
 	if err != nil {
 		log.Println(err)
 		sugar.Fatal(err)
@@ -123,42 +126,32 @@ func mustInitSubscriber(ctx context.Context, addr, exchange, kind, queue string)
 	return sub, func() error { return rabbit.Close() }
 }
 
-func mustInitSheetsService(ctx context.Context) *sheets.Service {
-	b, err := os.ReadFile(os.Getenv("SHEETS_CREDENTIALS"))
-	if err != nil {
-		sugar.Fatalf("Unable to read client secret file: %v", err)
-	}
-
-	config, err := google.JWTConfigFromJSON(b, "https://www.googleapis.com/auth/spreadsheets")
-	if err != nil {
-		sugar.Fatalf("Unable to parse client secret file to config: %v", err)
+// use redis to keep handled events if the address is given, otherwise keep them in memory
+func mustInitDedupStore(ctx context.Context, addr string) dedup.Store {
+	if addr == "" {
+		return dedup.NewMemoryStore()
 	}
 
-	client := config.Client(ctx)
-
-	srv, err := sheets.NewService(ctx, option.WithHTTPClient(client))
+	c, err := utils.ConnectRedisCache(ctx, addr, 1*time.Minute)
 	if err != nil {
-		sugar.Fatalf("Unable to retrieve Sheets client: %v", err)
+		sugar.Fatal(err)
 	}
 
-	return srv
+	return dedup.NewCacheStore(redis.NewCache(c))
 }
 
-func mustInitStudyEventHandler(ctx context.Context, s *sheets.Service) pubsub.Handler {
-	progressSheetID, _ := strconv.ParseInt(os.Getenv("PROGRESS_SHEET_ID"), 10, 64)
-
-	var opts []event.HandlerOptsFunc
-
-	if progressSheetID != 0 {
-		opts = append(opts, event.WithProgressSheetID(progressSheetID))
+func mustInitStudyEventHandler(ctx context.Context, cfgs []config.SinkConfig, store dedup.Store) pubsub.Handler {
+	// keep recording to google sheets by default
+	if len(cfgs) == 0 {
+		cfgs = []config.SinkConfig{{Type: "sheets"}}
 	}
 
-	h, err := event.New(ctx, s, os.Getenv("SPREADSHEET_ID"), opts...)
+	sinks, err := event.NewSinks(ctx, cfgs)
 	if err != nil {
 		sugar.Fatal(err)
 	}
 
-	return h
+	return event.New(sinks, event.WithDedupStore(store))
 }
 
 func mustSetTimezone(tz string) {
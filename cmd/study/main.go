@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"time"
+	_ "time/tzdata" // time zones of studies do not depend on zoneinfo of the host
 
 	"context"
 
@@ -18,13 +19,21 @@ import (
 	"github.com/piatoss3612/my-study-bot/internal/bot/command/profile"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command/reflection"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command/registration"
+	"github.com/piatoss3612/my-study-bot/internal/bot/command/reminder"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command/submit"
+	"github.com/piatoss3612/my-study-bot/internal/bot/guild"
 	"github.com/piatoss3612/my-study-bot/internal/cache"
+	mcache "github.com/piatoss3612/my-study-bot/internal/cache/memory"
 	"github.com/piatoss3612/my-study-bot/internal/cache/redis"
 	"github.com/piatoss3612/my-study-bot/internal/config"
+	loggersvc "github.com/piatoss3612/my-study-bot/internal/logger/service"
 	"github.com/piatoss3612/my-study-bot/internal/pubsub"
+	mpubsub "github.com/piatoss3612/my-study-bot/internal/pubsub/memory"
 	"github.com/piatoss3612/my-study-bot/internal/pubsub/rabbitmq"
+	"github.com/piatoss3612/my-study-bot/internal/study/event"
+	"github.com/piatoss3612/my-study-bot/internal/study/outbox"
 	"github.com/piatoss3612/my-study-bot/internal/study/repository"
+	"github.com/piatoss3612/my-study-bot/internal/study/repository/memory"
 	"github.com/piatoss3612/my-study-bot/internal/study/repository/mongo"
 	"github.com/piatoss3612/my-study-bot/internal/study/service"
 	"github.com/piatoss3612/my-study-bot/internal/utils"
@@ -58,33 +67,31 @@ func run() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	tx, txClose := mustInitTx(ctx, cfg.MongoDB.URI, cfg.MongoDB.DBName)
-	defer func() {
-		_ = txClose()
-		sugar.Info("Disconnected from MongoDB!")
-	}()
+	tx, cache, pub, closeDrivers := mustInitDrivers(ctx, cfg)
+	defer closeDrivers()
 
-	sugar.Info("Connected to MongoDB!")
-
-	cache := mustInitStudyCache(ctx, cfg.Redis.Addr, 1*time.Minute)
+	svc := service.New(tx)
+	sugar.Info("Study service is ready!")
 
-	sugar.Info("Study cache is ready!")
+	cmdReg := registerCommands(svc, cache)
+	handler := command.NewHandler(cmdReg.HandleFuncs())
 
-	pub, pubClose := mustInitPublisher(ctx, cfg.RabbitMQ.Addr, cfg.RabbitMQ.Exchange, cfg.RabbitMQ.Kind)
-	defer func() {
-		_ = pubClose()
-		sugar.Info("Disconnected from RabbitMQ!")
-	}()
+	sess := mustOpenDiscordSession(cfg.Discord.BotToken)
 
-	sugar.Info("Study/Event publisher is ready!")
+	b := bot.New(sess, sugar)
 
-	svc := service.New(tx)
-	sugar.Info("Study service is ready!")
+	// commands are registered to each guild on GuildCreate, so set them before connecting
+	b.RegisterHandler(handler)
+	b.RegisterGuildHook(guild.NewGuildHook(svc, cache))
+	b.RegisterVoiceHook(guild.NewVoiceHook(svc))
 
-	cmdReg := registerCommands(svc, pub, cache)
-	handler := command.NewHandler(cmdReg.HandleFuncs())
+	if err := b.RegisterCommands(cmdReg.Commands()); err != nil {
+		sugar.Fatal(err)
+	}
 
-	b := bot.New(mustOpenDiscordSession(cfg.Discord.BotToken), sugar)
+	if err := b.RegisterMetrics(outbox.Metrics()...); err != nil {
+		sugar.Fatal(err)
+	}
 
 	stop, err := b.Run()
 	if err != nil {
@@ -97,17 +104,23 @@ func run() {
 
 	sugar.Info("Connected to Discord!")
 
-	b.RegisterHandler(handler)
-
-	if err := b.RegisterCommands(cmdReg.Commands()); err != nil {
-		sugar.Fatal(err)
-	}
 	defer func() {
 		_ = b.RemoveCommands()
 		sugar.Info("Removed commands!")
 	}()
 
-	sugar.Info("Registered commands!")
+	bgCtx, bgCancel := context.WithCancel(context.Background())
+	defer bgCancel()
+
+	go outbox.NewRelay(tx, pub, sugar).Run(bgCtx)
+
+	sugar.Info("Outbox relay is running!")
+
+	go admin.NewStageScheduler(svc, sugar).Run(bgCtx, sess)
+	go admin.NewSeriesScheduler(svc, sugar).Run(bgCtx, sess)
+	go admin.NewReminderScheduler(svc, sugar).Run(bgCtx, sess)
+
+	sugar.Info("Stage, series and reminder schedulers are running!")
 
 	<-stop
 }
@@ -121,6 +134,70 @@ func mustLoadConfig(path string) *config.StudyConfig {
 	return cfg
 }
 
+// init repository, cache and publisher with the driver
+func mustInitDrivers(ctx context.Context, cfg *config.StudyConfig) (repository.Tx, cache.Cache, pubsub.Publisher, func()) {
+	if cfg.Driver == "memory" {
+		broker := mpubsub.NewBroker()
+
+		stopLogger := mustRunEmbeddedLogger(ctx, broker, cfg.Sinks)
+
+		sugar.Info("Memory driver is ready!")
+
+		return memory.NewMemoryTx(), mcache.NewCache(), mpubsub.NewPublisher(broker), stopLogger
+	}
+
+	tx, txClose := mustInitTx(ctx, cfg.MongoDB.URI, cfg.MongoDB.DBName)
+
+	sugar.Info("Connected to MongoDB!")
+
+	cache := mustInitStudyCache(ctx, cfg.Redis.Addr, 1*time.Minute)
+
+	sugar.Info("Study cache is ready!")
+
+	pub, pubClose := mustInitPublisher(ctx, cfg.RabbitMQ.Addr, cfg.RabbitMQ.Exchange, cfg.RabbitMQ.Kind)
+
+	sugar.Info("Study/Event publisher is ready!")
+
+	return tx, cache, pub, func() {
+		_ = pubClose()
+		sugar.Info("Disconnected from RabbitMQ!")
+
+		_ = txClose()
+		sugar.Info("Disconnected from MongoDB!")
+	}
+}
+
+// run logger listening to the broker in the same process, events are written to local files by default
+func mustRunEmbeddedLogger(ctx context.Context, broker *mpubsub.Broker, cfgs []config.SinkConfig) func() {
+	if len(cfgs) == 0 {
+		cfgs = []config.SinkConfig{{Type: "jsonl", Dir: "logs"}}
+	}
+
+	sinks, err := event.NewSinks(ctx, cfgs)
+	if err != nil {
+		sugar.Fatal(err)
+	}
+
+	h := event.New(sinks)
+	mapper := pubsub.NewMapper()
+	topics := event.Topics()
+
+	for _, topic := range topics {
+		mapper.Register(topic, h)
+	}
+
+	stop := make(chan bool)
+
+	go loggersvc.New(mpubsub.NewSubscriber(broker), mapper, sugar).Listen(stop, topics)
+
+	sugar.Info("Embedded logger is running!")
+
+	return func() {
+		close(stop)
+		sugar.Info("Embedded logger is stopped!")
+	}
+}
+
 func mustInitTx(ctx context.Context, uri, dbname string) (repository.Tx, func() error) {
 	mongoClient, err := utils.ConnectMongoDB(ctx, uri)
 	if err != nil {
@@ -163,17 +240,18 @@ func mustOpenDiscordSession(token string) *discordgo.Session {
 	return sess
 }
 
-func registerCommands(svc service.Service, pub pubsub.Publisher, cache cache.Cache) command.Registerer {
+func registerCommands(svc service.Service, cache cache.Cache) command.Registerer {
 	reg := command.NewRegisterer()
 
-	admin.NewAdminCommand(svc, pub, sugar).Register(reg)
+	admin.NewAdminCommand(svc, sugar).Register(reg)
 	help.NewHelpCommand().Register(reg)
-	profile.NewProfileCommand(sugar).Register(reg)
+	profile.NewProfileCommand(svc, sugar).Register(reg)
 	info.NewInfoCommand(svc, cache).Register(reg)
-	registration.NewRegistrationCommand(svc).Register(reg)
+	registration.NewRegistrationCommand(svc, sugar).Register(reg)
 	submit.NewSubmitCommand(svc).Register(reg)
-	feedback.NewFeedbackCommand(svc).Register(reg)
+	feedback.NewFeedbackCommand(svc, cache).Register(reg)
 	reflection.NewReflectionCommand(svc).Register(reg)
+	reminder.NewReminderCommand(svc).Register(reg)
 
 	return reg
 }
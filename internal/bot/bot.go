@@ -6,11 +6,15 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -30,7 +34,7 @@ var totalErrors = prometheus.NewCounterVec(
 		Name: "command_errors_total",
 		Help: "Total number of errors.",
 	},
-	[]string{"command"},
+	[]string{"command", "code"},
 )
 
 var duration = prometheus.NewHistogramVec(
@@ -47,43 +51,69 @@ type Bot interface {
 	Run() (<-chan bool, error)
 	RegisterCommands(cmds []*discordgo.ApplicationCommand) error
 	RegisterHandler(h command.Handler)
+	RegisterGuildHook(h GuildHook)
+	RegisterVoiceHook(h VoiceHook)
+	RegisterMetrics(cs ...prometheus.Collector) error
 	RemoveCommands() error
 	Close() error
 }
 
+// GuildHook is called when the bot joins or leaves a guild
+type GuildHook interface {
+	OnGuildJoin(ctx context.Context, g *discordgo.Guild) error
+	OnGuildLeave(ctx context.Context, guildID string) error
+}
+
+// VoiceHook is called when a member joins, leaves or moves between voice channels
+type VoiceHook interface {
+	OnVoiceStateUpdate(ctx context.Context, v *discordgo.VoiceStateUpdate) error
+}
+
 type bot struct {
 	sess               *discordgo.Session
-	registeredCommands []*discordgo.ApplicationCommand
+	commands           []*discordgo.ApplicationCommand
+	registeredCommands map[string][]*discordgo.ApplicationCommand
 	handler            command.Handler
+	guildHook          GuildHook
+	voiceHook          VoiceHook
+
+	mtx *sync.Mutex
 
-	srv *http.Server
+	srv     *http.Server
+	metrics *prometheus.Registry
 
 	sugar *zap.SugaredLogger
 }
 
 func New(sess *discordgo.Session, sugar *zap.SugaredLogger) Bot {
 	b := &bot{
-		sess:  sess,
-		sugar: sugar,
+		sess:               sess,
+		registeredCommands: map[string][]*discordgo.ApplicationCommand{},
+		mtx:                &sync.Mutex{},
+		sugar:              sugar,
 	}
 	return b.setup()
 }
 
 func (b *bot) setup() Bot {
 	b.sess.Identify.Intents = discordgo.IntentGuildMembers | discordgo.IntentGuildMessages |
-		discordgo.IntentGuilds | discordgo.IntentDirectMessages
+		discordgo.IntentGuilds | discordgo.IntentDirectMessages | discordgo.IntentGuildVoiceStates
 
 	b.sess.AddHandler(b.ready)
+	b.sess.AddHandler(b.guildCreate)
+	b.sess.AddHandler(b.guildUpdate)
+	b.sess.AddHandler(b.guildDelete)
+	b.sess.AddHandler(b.voiceStateUpdate)
 	b.sess.AddHandler(b.handleApplicationCommand)
 
-	metrics := prometheus.NewRegistry()
-	metrics.MustRegister(collectors.NewGoCollector())
-	metrics.MustRegister(totalRequests)
-	metrics.MustRegister(totalErrors)
-	metrics.MustRegister(duration)
+	b.metrics = prometheus.NewRegistry()
+	b.metrics.MustRegister(collectors.NewGoCollector())
+	b.metrics.MustRegister(totalRequests)
+	b.metrics.MustRegister(totalErrors)
+	b.metrics.MustRegister(duration)
 
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.HandlerFor(metrics, promhttp.HandlerOpts{}))
+	mux.Handle("/metrics", promhttp.HandlerFor(b.metrics, promhttp.HandlerOpts{}))
 	mux.HandleFunc("/healthcheck", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
@@ -128,19 +158,27 @@ func (b *bot) Run() (<-chan bool, error) {
 	return stop, nil
 }
 
+// register commands to every guild the bot has joined
 func (b *bot) RegisterCommands(cmds []*discordgo.ApplicationCommand) error {
-	registeredCmds := make([]*discordgo.ApplicationCommand, 0, len(cmds))
+	b.mtx.Lock()
+	b.commands = cmds
+	b.mtx.Unlock()
 
-	for _, cmd := range cmds {
-		registered, err := b.sess.ApplicationCommandCreate(b.sess.State.User.ID, "", cmd)
-		if err != nil {
-			return err
+	// guilds joined before the commands are set
+	if b.sess.State == nil || b.sess.State.User == nil {
+		return nil
+	}
+
+	for _, g := range b.sess.State.Guilds {
+		if g.Unavailable {
+			continue
 		}
 
-		registeredCmds = append(registeredCmds, registered)
+		if err := b.registerGuildCommands(g.ID); err != nil {
+			return err
+		}
 	}
 
-	b.registeredCommands = registeredCmds
 	return nil
 }
 
@@ -148,11 +186,35 @@ func (b *bot) RegisterHandler(h command.Handler) {
 	b.handler = h
 }
 
+func (b *bot) RegisterGuildHook(h GuildHook) {
+	b.guildHook = h
+}
+
+func (b *bot) RegisterVoiceHook(h VoiceHook) {
+	b.voiceHook = h
+}
+
+// register additional collectors to the metric server
+func (b *bot) RegisterMetrics(cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		if err := b.metrics.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remove commands from every guild
 func (b *bot) RemoveCommands() error {
-	appID := b.sess.State.User.ID
+	b.mtx.Lock()
+	guildIDs := make([]string, 0, len(b.registeredCommands))
+	for guildID := range b.registeredCommands {
+		guildIDs = append(guildIDs, guildID)
+	}
+	b.mtx.Unlock()
 
-	for _, cmd := range b.registeredCommands {
-		if err := b.sess.ApplicationCommandDelete(appID, "", cmd.ID); err != nil {
+	for _, guildID := range guildIDs {
+		if err := b.removeGuildCommands(guildID); err != nil {
 			return err
 		}
 	}
@@ -160,6 +222,42 @@ func (b *bot) RemoveCommands() error {
 	return nil
 }
 
+// register commands scoped to the guild
+func (b *bot) registerGuildCommands(guildID string) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if len(b.commands) == 0 {
+		return nil
+	}
+
+	registered, err := b.sess.ApplicationCommandBulkOverwrite(b.sess.State.User.ID, guildID, b.commands)
+	if err != nil {
+		return err
+	}
+
+	b.registeredCommands[guildID] = registered
+	return nil
+}
+
+// remove commands scoped to the guild
+func (b *bot) removeGuildCommands(guildID string) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if _, ok := b.registeredCommands[guildID]; !ok {
+		return nil
+	}
+
+	_, err := b.sess.ApplicationCommandBulkOverwrite(b.sess.State.User.ID, guildID, []*discordgo.ApplicationCommand{})
+	if err != nil {
+		return err
+	}
+
+	delete(b.registeredCommands, guildID)
+	return nil
+}
+
 func (b *bot) Close() error {
 	err := b.sess.Close()
 	if err != nil {
@@ -178,7 +276,83 @@ func (b *bot) Close() error {
 }
 
 func (b *bot) ready(s *discordgo.Session, _ *discordgo.Ready) {
-	_ = s.UpdateGameStatus(0, "초기화")
+	_ = s.UpdateGameStatus(0, command.Status)
+}
+
+func (b *bot) guildCreate(s *discordgo.Session, g *discordgo.GuildCreate) {
+	if g.Unavailable {
+		return
+	}
+
+	if err := b.registerGuildCommands(g.ID); err != nil {
+		b.sugar.Errorw("failed to register guild commands", "guild", g.ID, "error", err)
+	}
+
+	b.callGuildJoinHook(g.Guild)
+}
+
+func (b *bot) guildUpdate(s *discordgo.Session, g *discordgo.GuildUpdate) {
+	b.callGuildJoinHook(g.Guild)
+}
+
+func (b *bot) guildDelete(s *discordgo.Session, g *discordgo.GuildDelete) {
+	// guild is temporarily unavailable due to an outage
+	if g.Unavailable {
+		return
+	}
+
+	// commands of the guild are deleted by discord when the bot leaves
+	b.mtx.Lock()
+	delete(b.registeredCommands, g.ID)
+	b.mtx.Unlock()
+
+	if b.guildHook == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := b.guildHook.OnGuildLeave(ctx, g.ID); err != nil {
+		b.sugar.Errorw("failed to handle guild leave", "guild", g.ID, "error", err)
+		return
+	}
+
+	b.sugar.Infow("left guild", "guild", g.ID)
+}
+
+func (b *bot) callGuildJoinHook(g *discordgo.Guild) {
+	if b.guildHook == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := b.guildHook.OnGuildJoin(ctx, g); err != nil {
+		b.sugar.Errorw("failed to handle guild join", "guild", g.ID, "error", err)
+		return
+	}
+
+	b.sugar.Infow("guild settings synced", "guild", g.ID, "name", g.Name)
+}
+
+func (b *bot) voiceStateUpdate(s *discordgo.Session, v *discordgo.VoiceStateUpdate) {
+	if b.voiceHook == nil || v.GuildID == "" {
+		return
+	}
+
+	// voice state of the bot itself is not tracked
+	if s.State != nil && s.State.User != nil && v.UserID == s.State.User.ID {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := b.voiceHook.OnVoiceStateUpdate(ctx, v); err != nil {
+		b.sugar.Errorw("failed to handle voice state update", "guild", v.GuildID, "user", v.UserID, "error", err)
+	}
 }
 
 func (b *bot) handleApplicationCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -188,9 +362,9 @@ func (b *bot) handleApplicationCommand(s *discordgo.Session, i *discordgo.Intera
 	case discordgo.InteractionApplicationCommand:
 		name = i.ApplicationCommandData().Name
 	case discordgo.InteractionMessageComponent:
-		name = i.MessageComponentData().CustomID
+		name, _ = command.ParseCustomID(i.MessageComponentData().CustomID)
 	case discordgo.InteractionModalSubmit:
-		name = i.ModalSubmitData().CustomID
+		name, _ = command.ParseCustomID(i.ModalSubmitData().CustomID)
 	default:
 		return
 	}
@@ -202,22 +376,46 @@ func (b *bot) handleApplicationCommand(s *discordgo.Session, i *discordgo.Intera
 	totalRequests.WithLabelValues(name).Inc()
 
 	if err != nil {
-		totalErrors.WithLabelValues(name).Inc()
+		code := study.CodeOf(err)
+		totalErrors.WithLabelValues(name, string(code)).Inc()
 		b.errorResponse(s, i, err)
-		b.sugar.Errorw("command error", "command", name, "error", err.Error(), "duration", timer.ObserveDuration().String())
+
+		// interaction id is shown to users as the correlation id of hidden errors
+		kvs := []any{"command", name, "code", code, "correlation_id", i.ID, "error", err.Error(), "duration", timer.ObserveDuration().String()}
+
+		switch study.SeverityOf(err) {
+		case study.SeverityInfo:
+			b.sugar.Infow("command error", kvs...)
+		case study.SeverityWarning:
+			b.sugar.Warnw("command error", kvs...)
+		default:
+			b.sugar.Errorw("command error", kvs...)
+		}
 		return
 	}
 	b.sugar.Infow("command handled", "command", name, "duration", timer.ObserveDuration().String())
 }
 
 func (b *bot) errorResponse(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	l := i18n.FromInteraction(i)
+
+	msg, safe := l.Error(err)
+
 	embed := &discordgo.MessageEmbed{
-		Title:       "오류",
-		Description: err.Error(),
+		Title:       l.T("오류"),
+		Description: msg,
 		Color:       0xff0000,
 		Timestamp:   time.Now().Format(time.RFC3339),
 	}
 
+	// internal errors are hidden, users can report them with the correlation id
+	if !safe {
+		embed.Description = strings.TrimSpace(msg + "\n" + l.T("알 수 없는 오류가 발생했습니다. 문제가 계속되면 매니저에게 오류 ID를 알려주세요."))
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: l.T("오류 ID: %s", i.ID),
+		}
+	}
+
 	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
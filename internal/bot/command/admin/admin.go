@@ -2,14 +2,12 @@ package admin
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command"
-	"github.com/piatoss3612/my-study-bot/internal/pubsub"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
 	"github.com/piatoss3612/my-study-bot/internal/study"
 	"github.com/piatoss3612/my-study-bot/internal/study/service"
 	"github.com/piatoss3612/my-study-bot/internal/utils"
@@ -18,23 +16,33 @@ import (
 
 type adminCommand struct {
 	svc service.Service
-	pub pubsub.Publisher
 
 	sugar *zap.SugaredLogger
 }
 
-func NewAdminCommand(svc service.Service, pub pubsub.Publisher, sugar *zap.SugaredLogger) command.Command {
+func NewAdminCommand(svc service.Service, sugar *zap.SugaredLogger) command.Command {
 	return &adminCommand{
 		svc:   svc,
-		pub:   pub,
 		sugar: sugar,
 	}
 }
 
 func (ac *adminCommand) Register(reg command.Registerer) {
 	reg.RegisterCommand(adminCmd, ac.adminHandler)
+	reg.RegisterCommand(seriesCmd, ac.seriesHandler)
+	reg.RegisterCommand(waitlistCmd, ac.waitlistHandler)
+	reg.RegisterCommand(memberCmd, ac.memberHandler)
+	reg.RegisterCommand(lineupCmd, ac.lineupHandler)
+	reg.RegisterCommand(voiceCmd, ac.voiceHandler)
+	reg.RegisterCommand(reviewCmd, ac.reviewHandler)
+	reg.RegisterCommand(reminderCmd, ac.reminderHandler)
+	reg.RegisterCommand(dashboardCmd, ac.dashboardHandler)
 	reg.RegisterHandler(noticeModalCustomID, ac.sendNotice)
-	reg.RegisterHandler(stageMoveConfirmButton.CustomID, ac.moveRoundStageConfirm)
+	reg.RegisterHandler(stageMoveConfirmButtonCustomID, ac.moveRoundStageConfirm)
+	reg.RegisterHandler(cancelRoundModalCustomID, ac.cancelRoundConfirm)
+	reg.RegisterHandler(nextSpeakerButtonCustomID, ac.moveToNextSpeaker)
+	reg.RegisterHandler(reviewAttendanceCustomID, ac.reviewAttendance)
+	reg.RegisterHandler(nudgeButtonCustomID, ac.nudgeMembers)
 }
 
 // handle admin command
@@ -52,6 +60,11 @@ func (ac *adminCommand) adminHandler(s *discordgo.Session, i *discordgo.Interact
 	var txt string
 	var u *discordgo.User
 	var ch *discordgo.Channel
+	var stage study.Stage
+	var role *discordgo.Role
+	var pipeline string
+	var locale study.Locale
+	var feedbackMode study.FeedbackMode
 
 	for _, o := range options[1:] {
 		switch o.Name {
@@ -61,6 +74,16 @@ func (ac *adminCommand) adminHandler(s *discordgo.Session, i *discordgo.Interact
 			u = o.UserValue(s)
 		case "채널":
 			ch = o.ChannelValue(s)
+		case "역할":
+			role = o.RoleValue(s, i.GuildID)
+		case "단계":
+			stage = study.Stage(o.IntValue())
+		case "진행-방식":
+			pipeline = o.StringValue()
+		case "언어":
+			locale = study.Locale(o.StringValue())
+		case "피드백-방식":
+			feedbackMode = study.FeedbackMode(o.StringValue())
 		}
 	}
 
@@ -74,9 +97,17 @@ func (ac *adminCommand) adminHandler(s *discordgo.Session, i *discordgo.Interact
 	case "refresh-status":
 		err = ac.refreshBotStatus(s, i)
 	case "create-study-round":
-		err = ac.createRound(s, i, txt)
+		err = ac.createRound(s, i, txt, pipeline)
 	case "move-round-stage":
 		err = ac.moveRoundStage(s, i)
+	case "rollback-round-stage":
+		err = ac.rollbackRoundStage(s, i)
+	case "pause-round":
+		err = ac.pauseRound(s, i)
+	case "resume-round":
+		err = ac.resumeRound(s, i)
+	case "cancel-round":
+		err = ac.cancelRound(s, i)
 	case "confirm-attendance":
 		err = ac.checkAttendance(s, i, u)
 	case "register-recorded-content":
@@ -87,9 +118,31 @@ func (ac *adminCommand) adminHandler(s *discordgo.Session, i *discordgo.Interact
 		err = ac.setReflectionChannel(s, i, ch)
 	case "set-spreadsheet":
 		err = ac.setSpreadsheet(s, i, txt)
+	case "set-time-zone":
+		err = ac.setTimeZone(s, i, txt)
+	case "set-locale":
+		err = ac.setLocale(s, i, locale)
+	case "set-feedback-mode":
+		err = ac.setFeedbackMode(s, i, feedbackMode)
+	case "set-stage-deadline":
+		err = ac.setStageDeadline(s, i, stage, txt)
+	case "add-manager":
+		err = ac.addManager(s, i, u)
+	case "remove-manager":
+		err = ac.removeManager(s, i, u)
+	case "transfer-ownership":
+		err = ac.transferOwnership(s, i, u)
+	case "set-manager-role":
+		err = ac.setManagerRole(s, i, role)
+	case "audit-log":
+		err = ac.showAuditLogs(s, i)
 	default:
 		err = study.ErrInvalidCommand
 	}
+
+	// record the action of the manager
+	ac.recordAuditLog(i.GuildID, manager.ID, cmd, auditDetails(options[1:]), err)
+
 	return err
 }
 
@@ -100,19 +153,20 @@ func (ac *adminCommand) createStudy(s *discordgo.Session, i *discordgo.Interacti
 		return study.ErrManagerNotFound
 	}
 
-	guild, err := s.Guild(i.GuildID)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// get guild settings
+	guild, err := ac.svc.GetGuild(ctx, i.GuildID)
 	if err != nil {
 		return err
 	}
 
 	// check manager is owner of guild
-	if guild.OwnerID != manager.ID {
+	if !guild.IsOwner(manager.ID) {
 		return study.ErrNotManager
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
 	// create study
 	gs, err := ac.svc.NewStudy(ctx, &service.NewStudyParams{
 		GuildID:   i.GuildID,
@@ -122,14 +176,16 @@ func (ac *adminCommand) createStudy(s *discordgo.Session, i *discordgo.Interacti
 		return err
 	}
 
+	l := i18n.FromInteraction(i)
+
 	// send response
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Title: "스터디 생성",
+			Title: l.T("스터디 생성"),
 			Flags: discordgo.MessageFlagsEphemeral,
 			Embeds: []*discordgo.MessageEmbed{
-				adminEmbed(s.State.User, "스터디가 생성되었습니다.", fmt.Sprintf("스터디 ID: %s", gs.ID)),
+				adminEmbed(s.State.User, l.T("스터디가 생성되었습니다."), l.T("스터디 ID: %s", gs.ID)),
 			},
 		},
 	})
@@ -152,20 +208,22 @@ func (ac *adminCommand) writeNotice(s *discordgo.Session, i *discordgo.Interacti
 	}
 
 	// check manager
-	if !gs.IsManager(manager.ID) {
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
 		return study.ErrNotManager
 	}
 
+	l := i18n.FromInteraction(i)
+
 	// show notice modal
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
 			CustomID: noticeModalCustomID,
-			Title:    "공지 입력",
+			Title:    l.T("공지 입력"),
 			Flags:    discordgo.MessageFlagsEphemeral,
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{noticeTextInput},
+					Components: []discordgo.MessageComponent{noticeTextInput(l)},
 				},
 			},
 		},
@@ -173,12 +231,17 @@ func (ac *adminCommand) writeNotice(s *discordgo.Session, i *discordgo.Interacti
 }
 
 // send notice to notice channel of guild
-func (ac *adminCommand) sendNotice(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (ac *adminCommand) sendNotice(s *discordgo.Session, i *discordgo.InteractionCreate) (err error) {
 	manager := utils.GetGuildUserFromInteraction(i)
 	if manager == nil {
 		return study.ErrManagerNotFound
 	}
 
+	// record the action of the manager
+	defer func() {
+		ac.recordAuditLog(i.GuildID, manager.ID, noticeModalCustomID, "", err)
+	}()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -189,7 +252,7 @@ func (ac *adminCommand) sendNotice(s *discordgo.Session, i *discordgo.Interactio
 	}
 
 	// check manager
-	if !gs.IsManager(manager.ID) {
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
 		return study.ErrNotManager
 	}
 
@@ -215,11 +278,11 @@ func (ac *adminCommand) sendNotice(s *discordgo.Session, i *discordgo.Interactio
 
 	// check content
 	if content == "" {
-		return errors.Join(study.ErrRequiredArgs, errors.New("공지로 전송할 내용을 입력해주세요"))
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("공지로 전송할 내용을 입력해주세요"))
 	}
 
 	bot := s.State.User
-	embed := adminEmbed(bot, "공지", content)
+	embed := adminEmbed(bot, i18n.New(gs.Clock().Locale()).T("공지"), content)
 
 	// send notice DM to all members with confirm button
 	go ac.sendDMsToAllMember(s, embed, i.GuildID)
@@ -236,7 +299,7 @@ func (ac *adminCommand) sendNotice(s *discordgo.Session, i *discordgo.Interactio
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "공지를 전송했습니다.",
+			Content: i18n.FromInteraction(i).T("공지를 전송했습니다."),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
@@ -259,12 +322,12 @@ func (ac *adminCommand) refreshBotStatus(s *discordgo.Session, i *discordgo.Inte
 	}
 
 	// check manager
-	if !gs.IsManager(manager.ID) {
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
 		return study.ErrNotManager
 	}
 
-	// update game status
-	err = s.UpdateGameStatus(0, gs.CurrentStage.String())
+	// presence is shared by every guild, so it is not set from the stage of the study
+	err = s.UpdateGameStatus(0, command.Status)
 	if err != nil {
 		return err
 	}
@@ -273,14 +336,14 @@ func (ac *adminCommand) refreshBotStatus(s *discordgo.Session, i *discordgo.Inte
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "발표 진스의 상태가 갱신되었습니다.",
+			Content: i18n.FromInteraction(i).T("발표 진스의 상태가 갱신되었습니다."),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
 }
 
 // create round of study
-func (ac *adminCommand) createRound(s *discordgo.Session, i *discordgo.InteractionCreate, title string) error {
+func (ac *adminCommand) createRound(s *discordgo.Session, i *discordgo.InteractionCreate, title, pipeline string) error {
 	manager := utils.GetGuildUserFromInteraction(i)
 	if manager == nil {
 		return study.ErrManagerNotFound
@@ -288,53 +351,34 @@ func (ac *adminCommand) createRound(s *discordgo.Session, i *discordgo.Interacti
 
 	// check if title is empty
 	if title == "" {
-		return errors.Join(study.ErrRequiredArgs, errors.New("스터디 제목은 필수입니다"))
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("스터디 제목은 필수입니다"))
 	}
 
-	// get all members in the guild
-	members, err := s.GuildMembers(i.GuildID, "", 1000)
+	memberIDs, err := guildMemberIDs(s, i.GuildID)
 	if err != nil {
 		return err
 	}
 
-	// get all id of non-bot members
-	var memberIDs []string
-
-	for _, m := range members {
-		if m.User == nil || m.User.Bot {
-			continue
-		}
-
-		memberIDs = append(memberIDs, m.User.ID)
-	}
-
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// create a round
 	gs, err := ac.svc.NewRound(ctx, &service.NewRoundParams{
-		GuildID:   i.GuildID,
-		ManagerID: manager.ID,
-		Title:     title,
-		MemberIDs: memberIDs,
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		Title:          title,
+		MemberIDs:      memberIDs,
+		Pipeline:       pipeline,
 	})
 	if err != nil {
 		return err
 	}
 
-	embed := adminEmbed(s.State.User, "스터디 라운드 생성", fmt.Sprintf("**<%s>**가 생성되었습니다.", title))
-	description := fmt.Sprintf("스터디 라운드가 생성되었습니다.\n제목: %s\n참여자: %d명", title, len(memberIDs))
-
-	go func() {
-		evt, err := study.NewEvent(study.EventTopicStudyRoundCreated, description)
-		if err != nil {
-			ac.sugar.Errorw("failed to create an event", "error", err, "topic", study.EventTopicStudyRoundCreated, "description", description)
-			return
-		}
+	// members are notified in the locale of the study
+	sl := i18n.New(gs.Clock().Locale())
 
-		// publish an event
-		go ac.publishEvent(evt)
-	}()
+	embed := adminEmbed(s.State.User, sl.T("스터디 라운드 생성"), sl.T("**<%s>**가 생성되었습니다.", title))
 
 	// send a DM to all members
 	go ac.sendDMsToAllMember(s, embed, i.GuildID)
@@ -347,22 +391,36 @@ func (ac *adminCommand) createRound(s *discordgo.Session, i *discordgo.Interacti
 		}
 	}
 
-	// update game status
-	err = s.UpdateGameStatus(0, gs.CurrentStage.String())
-	if err != nil {
-		return err
-	}
-
 	// send a response message
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "스터디가 생성되었습니다.",
+			Content: i18n.FromInteraction(i).T("스터디 라운드가 생성되었습니다."),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
 }
 
+// get all id of non-bot members in the guild
+func guildMemberIDs(s *discordgo.Session, guildID string) ([]string, error) {
+	members, err := s.GuildMembers(guildID, "", 1000)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberIDs []string
+
+	for _, m := range members {
+		if m.User == nil || m.User.Bot {
+			continue
+		}
+
+		memberIDs = append(memberIDs, m.User.ID)
+	}
+
+	return memberIDs, nil
+}
+
 // move round stage
 func (ac *adminCommand) moveRoundStage(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	manager := utils.GetGuildUserFromInteraction(i)
@@ -380,7 +438,7 @@ func (ac *adminCommand) moveRoundStage(s *discordgo.Session, i *discordgo.Intera
 	}
 
 	// check manager
-	if !gs.IsManager(manager.ID) {
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
 		return study.ErrNotManager
 	}
 
@@ -388,9 +446,21 @@ func (ac *adminCommand) moveRoundStage(s *discordgo.Session, i *discordgo.Intera
 		return study.ErrRoundNotFound
 	}
 
-	next := gs.CurrentStage.Next()
-	embed := adminEmbed(s.State.User, "스터디 라운드 진행 단계 변경",
-		fmt.Sprintf("스터디 라운드 진행 단계가 **<%s>**로 변경됩니다. 진행하시겠습니까?", next.String()), 16777215)
+	// get round to follow its pipeline
+	gr, err := ac.svc.GetRound(ctx, gs.OngoingRoundID)
+	if err != nil {
+		return err
+	}
+
+	if gr.IsPaused() {
+		return study.ErrRoundPaused
+	}
+
+	l := i18n.FromInteraction(i)
+
+	next := gr.GetPipeline().Next(gs.CurrentStage)
+	embed := adminEmbed(s.State.User, l.T("스터디 라운드 진행 단계 변경"),
+		l.T("스터디 라운드 진행 단계가 **<%s>**로 변경됩니다. 진행하시겠습니까?", l.Stage(next)), 16777215)
 
 	// send a response with confirm button
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -401,7 +471,7 @@ func (ac *adminCommand) moveRoundStage(s *discordgo.Session, i *discordgo.Intera
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
-						stageMoveConfirmButton,
+						stageMoveConfirmButton(l),
 					},
 				},
 			},
@@ -410,83 +480,65 @@ func (ac *adminCommand) moveRoundStage(s *discordgo.Session, i *discordgo.Intera
 }
 
 // confirm to move round stage
-func (ac *adminCommand) moveRoundStageConfirm(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (ac *adminCommand) moveRoundStageConfirm(s *discordgo.Session, i *discordgo.InteractionCreate) (err error) {
 	manager := utils.GetGuildUserFromInteraction(i)
 	if manager == nil {
 		return study.ErrManagerNotFound
 	}
 
+	// record the action of the manager
+	defer func() {
+		ac.recordAuditLog(i.GuildID, manager.ID, stageMoveConfirmButtonCustomID, "", err)
+	}()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// move stage
-	gs, gr, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
-		GuildID:   i.GuildID,
-		ManagerID: manager.ID,
-	}, service.MoveStage, service.ValidateToCheckManager, service.ValidateToCheckOngoingRound)
+	params := &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+	}
+
+	gs, gr, err := ac.svc.UpdateRound(ctx, params, service.MoveStage, service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToCheckNotPaused)
 	if err != nil {
 		return err
 	}
 
-	var embed *discordgo.MessageEmbed
-
-	// check if the round is closed
-	if gr.Stage.IsFinished() {
-		embed = adminEmbed(s.State.User, "라운드 종료", "라운드가 종료되었습니다. 다음 라운드를 준비하세요.")
-
-		go func(topic study.EventTopic, desc string, r study.Round) {
-			b, err := json.Marshal(r)
-			if err != nil {
-				ac.sugar.Errorw("failed to marshal a round", "error", err, "round", r)
-				return
-			}
-
-			evt, err := study.NewEvent(topic, desc, b)
-			if err != nil {
-				ac.sugar.Errorw("failed to create an event", "error", err, "topic", topic, "description", desc)
-				return
-			}
+	// notify members that the stage has been moved
+	if err := ac.notifyStageMoved(s, gs, gr); err != nil {
+		return err
+	}
 
-			// publish an event
-			go ac.publishEvent(evt)
-		}(study.EventTopicStudyRoundFinished, "", *gr)
-	} else {
-		embed = adminEmbed(s.State.User, gr.Stage.String(), fmt.Sprintf("**<%s>**이(가) 시작되었습니다.", gr.Stage.String()))
+	// reviewers assigned when reviews open are notified
+	if params.Assigned {
+		go ac.notifyReviewAssignments(s, gs, gr)
 	}
 
-	go func(topic study.EventTopic, desc string) {
-		evt, err := study.NewEvent(topic, desc)
-		if err != nil {
-			ac.sugar.Errorw("failed to create an event", "error", err, "topic", topic, "description", desc)
-			return
-		}
+	// members already in the voice channel are recorded when the presentation starts
+	if err := ac.recordVoicePresence(ctx, s, gs); err != nil {
+		return err
+	}
 
-		// publish an event
-		go ac.publishEvent(evt)
-	}(study.EventTopicStudyRoundProgress, fmt.Sprintf("%s: %s", gr.Title, gr.Stage.String()))
+	l := i18n.FromInteraction(i)
 
-	// send a DM to all members
-	go ac.sendDMsToAllMember(s, embed, i.GuildID)
+	// manager reviews attendance marked by voice presence when the presentation ends
+	if needsAttendanceReview(gs, gr) {
+		data := attendanceReviewData(s.State.User, gs, gr, l)
+		data.Content = l.T("스터디 라운드가 이동되었습니다.")
 
-	// send a notice message
-	if gs.NoticeChannelID != "" {
-		_, err = s.ChannelMessageSendEmbed(gs.NoticeChannelID, embed)
-		if err != nil {
-			return err
-		}
-	}
-
-	// update game status
-	err = s.UpdateGameStatus(0, gs.CurrentStage.String())
-	if err != nil {
-		return err
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: data,
+		})
 	}
 
 	// send a response message
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "스터디 라운드가 이동되었습니다.",
+			Content: l.T("스터디 라운드가 이동되었습니다."),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
@@ -507,17 +559,20 @@ func (ac *adminCommand) checkAttendance(s *discordgo.Session, i *discordgo.Inter
 	defer cancel()
 
 	// check attendance
-	_, _, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
-		GuildID:   i.GuildID,
-		ManagerID: manager.ID,
-		MemberID:  u.ID,
+	gs, _, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		MemberID:       u.ID,
 	}, service.CheckSpeakerAttendance,
 		service.ValidateToCheckManager, service.ValidateToCheckAttendance)
 	if err != nil {
 		return err
 	}
 
-	embed := adminEmbed(s.State.User, "발표 출석 확인", fmt.Sprintf("**<@%s>**님의 발표 출석이 확인되었습니다.", u.Username))
+	sl := i18n.New(gs.Clock().Locale())
+
+	embed := adminEmbed(s.State.User, sl.T("발표 출석 확인"), sl.T("**<@%s>**님의 발표 출석이 확인되었습니다.", u.Username))
 
 	// send a DM to the user
 	go ac.sendDMToMember(s, u, embed)
@@ -526,7 +581,7 @@ func (ac *adminCommand) checkAttendance(s *discordgo.Session, i *discordgo.Inter
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("**<@%s>**님의 발표 출석이 확인되었습니다.", u.Username),
+			Content: i18n.FromInteraction(i).T("**<@%s>**님의 발표 출석이 확인되었습니다.", u.Username),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
@@ -543,30 +598,21 @@ func (ac *adminCommand) registerRecordedContent(s *discordgo.Session, i *discord
 	defer cancel()
 
 	// submit round content
-	gs, gr, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
-		GuildID:    i.GuildID,
-		ManagerID:  manager.ID,
-		ContentURL: contentURL,
+	gs, _, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		ContentURL:     contentURL,
 	}, service.SubmitRoundContent,
 		service.ValidateToCheckManager, service.ValidateToSubmitRoundContent)
 	if err != nil {
 		return err
 	}
 
-	embed := adminEmbed(s.State.User, "발표 영상 등록", "발표 영상이 등록되었습니다.")
-	embed.URL = contentURL
-
-	go func() {
-		description := fmt.Sprintf("%s: 발표 영상 등록", gr.Title)
-		evt, err := study.NewEvent(study.EventTopicStudyRoundProgress, description)
-		if err != nil {
-			ac.sugar.Errorw("failed to create an event", "error", err, "topic", study.EventTopicStudyRoundProgress, "description", description)
-			return
-		}
+	sl := i18n.New(gs.Clock().Locale())
 
-		// publish an event
-		go ac.publishEvent(evt)
-	}()
+	embed := adminEmbed(s.State.User, sl.T("발표 영상 등록"), sl.T("발표 영상이 등록되었습니다."))
+	embed.URL = contentURL
 
 	// send a DM to all members
 	go ac.sendDMsToAllMember(s, embed, i.GuildID)
@@ -583,7 +629,7 @@ func (ac *adminCommand) registerRecordedContent(s *discordgo.Session, i *discord
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "발표 영상이 등록되었습니다.",
+			Content: i18n.FromInteraction(i).T("발표 영상이 등록되었습니다."),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
@@ -606,9 +652,10 @@ func (ac *adminCommand) setNoticeChannel(s *discordgo.Session, i *discordgo.Inte
 
 	// set notice channel
 	_, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
-		GuildID:   i.GuildID,
-		ManagerID: manager.ID,
-		ChannelID: ch.ID,
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		ChannelID:      ch.ID,
 	}, service.UpdateNoticeChannelID, service.ValidateToCheckManager)
 	if err != nil {
 		return err
@@ -618,7 +665,7 @@ func (ac *adminCommand) setNoticeChannel(s *discordgo.Session, i *discordgo.Inte
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("공지 채널이 %s로 설정되었습니다.", ch.Mention()),
+			Content: i18n.FromInteraction(i).T("공지 채널이 %s로 설정되었습니다.", ch.Mention()),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
@@ -641,9 +688,10 @@ func (ac *adminCommand) setReflectionChannel(s *discordgo.Session, i *discordgo.
 
 	// set notice channel
 	_, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
-		GuildID:   i.GuildID,
-		ManagerID: manager.ID,
-		ChannelID: ch.ID,
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		ChannelID:      ch.ID,
 	}, service.UpdateReflectionChannelID, service.ValidateToCheckManager)
 	if err != nil {
 		return err
@@ -653,7 +701,7 @@ func (ac *adminCommand) setReflectionChannel(s *discordgo.Session, i *discordgo.
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("회고 채널이 %s로 설정되었습니다.", ch.Mention()),
+			Content: i18n.FromInteraction(i).T("회고 채널이 %s로 설정되었습니다.", ch.Mention()),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
@@ -671,9 +719,10 @@ func (ac *adminCommand) setSpreadsheet(s *discordgo.Session, i *discordgo.Intera
 
 	// set spreadsheet
 	_, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
-		GuildID:    i.GuildID,
-		ManagerID:  manager.ID,
-		ContentURL: url,
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		ContentURL:     url,
 	}, service.SetSpreadsheetURL, service.ValidateToCheckManager)
 	if err != nil {
 		return err
@@ -683,8 +732,196 @@ func (ac *adminCommand) setSpreadsheet(s *discordgo.Session, i *discordgo.Intera
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("스터디 시트가 %s로 설정되었습니다.", url),
+			Content: i18n.FromInteraction(i).T("스터디 시트가 %s로 설정되었습니다.", url),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// set time zone of the study, times are shown and typed in the time zone
+func (ac *adminCommand) setTimeZone(s *discordgo.Session, i *discordgo.InteractionCreate, timeZone string) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// set time zone
+	gs, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		TimeZone:       timeZone,
+	}, service.SetTimeZone, service.ValidateToCheckManager, service.ValidateToSetTimeZone)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+	c := study.NewClock(gs.TimeZone, l.Locale())
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: l.T("스터디 시간대가 %s로 설정되었습니다. 현재 시간: %s", c.Location(), c.FormatDateTime(c.Now())),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// set locale of the study
+func (ac *adminCommand) setLocale(s *discordgo.Session, i *discordgo.InteractionCreate, locale study.Locale) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	if locale == "" {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("언어를 선택해주세요"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// set locale
+	gs, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		Locale:         locale,
+	}, service.SetLocale, service.ValidateToCheckManager, service.ValidateToSetLocale)
+	if err != nil {
+		return err
+	}
+
+	c := gs.Clock()
+
+	// send a response message in the new locale of the study
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.New(c.Locale()).T("스터디 언어가 %s로 설정되었습니다. 현재 시간: %s", c.Locale(), c.FormatDateTime(c.Now())),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
 }
+
+// set who can see feedbacks written from now on
+func (ac *adminCommand) setFeedbackMode(s *discordgo.Session, i *discordgo.InteractionCreate, mode study.FeedbackMode) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	if mode == "" {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("피드백 방식을 선택해주세요"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// set feedback mode
+	_, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		FeedbackMode:   mode,
+	}, service.SetFeedbackMode, service.ValidateToCheckManager, service.ValidateToSetFeedbackMode)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: l.T("피드백 방식이 %s(으)로 설정되었습니다. 이미 작성된 피드백은 작성 당시의 방식을 따릅니다.", l.FeedbackMode(mode)),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// set deadline of the stage
+func (ac *adminCommand) setStageDeadline(s *discordgo.Session, i *discordgo.InteractionCreate, stage study.Stage, txt string) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	if stage.IsNone() {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("마감 시간을 설정할 진행 단계를 선택해주세요"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// get study to parse the deadline in its time zone
+	gs, err := ac.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// deadline is typed in the time zone of the study
+	c := study.NewClock(gs.TimeZone, l.Locale())
+
+	// empty text removes the deadline
+	var deadline time.Time
+
+	if txt != "" {
+		t, err := c.ParseDateTime(txt)
+		if err != nil {
+			return errors.Join(study.ErrInvalidDeadline, study.Errorf("마감 시간은 %s 형식으로 입력해주세요", study.DateTimeInputLayout))
+		}
+		deadline = t
+	}
+
+	// set deadline
+	_, _, err = ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		Stage:          stage,
+		Deadline:       deadline,
+	}, service.SetStageDeadline,
+		service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToSetStageDeadline)
+	if err != nil {
+		return err
+	}
+
+	content := l.T("**<%s>** 단계의 마감 시간이 %s로 설정되었습니다.", l.Stage(stage), c.FormatDateTime(deadline))
+	if deadline.IsZero() {
+		content = l.T("**<%s>** 단계의 마감 시간이 삭제되었습니다.", l.Stage(stage))
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// send notice about the moved stage, events are published through outbox
+func (ac *adminCommand) notifyStageMoved(s *discordgo.Session, gs *study.Study, gr *study.Round) error {
+	l := i18n.New(gs.Clock().Locale())
+
+	var embed *discordgo.MessageEmbed
+
+	// check if the round is closed
+	if gr.Stage.IsFinished() {
+		embed = adminEmbed(s.State.User, l.T("라운드 종료"), l.T("라운드가 종료되었습니다. 다음 라운드를 준비하세요."))
+	} else {
+		embed = adminEmbed(s.State.User, l.Stage(gr.Stage), l.T("**<%s>**이(가) 시작되었습니다.", l.Stage(gr.Stage)))
+	}
+
+	return ac.notifyRound(s, gs, embed)
+}
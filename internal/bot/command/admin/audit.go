@@ -0,0 +1,108 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+)
+
+// record an action taken by a manager, failure is only logged
+func (ac *adminCommand) recordAuditLog(guildID, actorID, action, details string, actionErr error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := ac.svc.RecordAuditLog(ctx, &service.AuditLogParams{
+		GuildID: guildID,
+		ActorID: actorID,
+		Action:  action,
+		Details: details,
+		Err:     actionErr,
+	})
+	if err != nil {
+		ac.sugar.Errorw("failed to record audit log", "error", err, "guild", guildID, "actor", actorID, "action", action)
+	}
+}
+
+// show recent audit logs of study
+func (ac *adminCommand) showAuditLogs(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// get study
+	gs, err := ac.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	// check manager
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
+		return study.ErrNotManager
+	}
+
+	logs, err := ac.svc.GetAuditLogs(ctx, i.GuildID, auditLogLimit)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:  discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{auditLogEmbed(s.State.User, logs, study.NewClock(gs.TimeZone, l.Locale()), l)},
+		},
+	})
+}
+
+// format command options as details of audit log
+func auditDetails(options []*discordgo.ApplicationCommandInteractionDataOption) string {
+	details := make([]string, 0, len(options))
+
+	for _, o := range options {
+		details = append(details, fmt.Sprintf("%s=%v", o.Name, o.Value))
+	}
+
+	return strings.Join(details, ", ")
+}
+
+func auditLogEmbed(u *discordgo.User, logs []*study.AuditLog, c study.Clock, l i18n.Localizer) *discordgo.MessageEmbed {
+	embed := adminEmbed(u, l.T("매니저 활동 기록"), l.T("최근 %d개의 활동 기록입니다.", len(logs)), 16777215)
+
+	for _, a := range logs {
+		result := l.T("성공")
+		switch {
+		case a.Succeeded:
+		case a.ErrorCode == study.CodeInternal:
+			// messages of internal errors are not shown even to managers
+			result = l.T("실패: %s", l.T("내부 오류"))
+		default:
+			result = l.T("실패: %s", l.Text(a.Error))
+		}
+
+		value := fmt.Sprintf("<@%s> | %s", a.ActorID, result)
+		if a.Details != "" {
+			value = fmt.Sprintf("%s\n```%s```", value, a.Details)
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%s (%s)", a.Action, c.FormatDateTime(a.CreatedAt)),
+			Value: value,
+		})
+	}
+
+	return embed
+}
@@ -0,0 +1,210 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/bot/command"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+)
+
+// handle dashboard command
+func (ac *adminCommand) dashboardHandler(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrUserNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	gs, gr, err := ac.ongoingRoundOfManager(ctx, i, manager.ID)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// send a response with the dashboard and buttons to nudge lagging members
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{dashboardEmbed(s.State.User, gr, l)},
+			Components: []discordgo.MessageComponent{nudgeButtons(gs, gr, l)},
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// send DMs to the lagging members of the target carried by the button
+func (ac *adminCommand) nudgeMembers(s *discordgo.Session, i *discordgo.InteractionCreate) (err error) {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	// args are round id and target of the nudge
+	_, args := command.ParseCustomID(i.MessageComponentData().CustomID)
+	if len(args) != 2 || !study.ReminderTarget(args[1]).IsValid() {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("잘못된 알림 정보입니다"))
+	}
+
+	target := study.ReminderTarget(args[1])
+
+	var recipients []string
+
+	// record the action of the manager
+	defer func() {
+		ac.recordAuditLog(i.GuildID, manager.ID, nudgeButtonCustomID, fmt.Sprintf("%s=%d", target, len(recipients)), err)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// managers are checked again as anyone can click the button
+	gs, gr, err := ac.ongoingRoundOfManager(ctx, i, manager.ID)
+	if err != nil {
+		return err
+	}
+
+	// the dashboard of a finished round should not nudge members of the next one
+	if gr.ID != args[0] {
+		return study.ErrRoundNotFound
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// members opted out of reminders are not nudged either
+	recipients = gr.ReminderRecipients(target, gs.ReminderOptOuts)
+	if len(recipients) == 0 {
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: l.T("알림을 보낼 멤버가 없습니다."),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+
+	// nudges are reminders of the current stage sent right away
+	go func() {
+		embed := reminderEmbed(s.State.User, gs, gr, study.NewReminderRule(gr.Stage, 0, target), i18n.New(gs.Clock().Locale()))
+		for _, id := range recipients {
+			ac.sendDMToMember(s, &discordgo.User{ID: id}, embed)
+		}
+	}()
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: l.T("%d명에게 %s 알림을 보냈습니다.", len(recipients), l.ReminderTarget(target)),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// study and its ongoing round if the user is a manager of the study
+func (ac *adminCommand) ongoingRoundOfManager(ctx context.Context, i *discordgo.InteractionCreate, managerID string) (*study.Study, *study.Round, error) {
+	// get study
+	gs, err := ac.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// check manager
+	if !gs.IsManager(managerID, utils.GetGuildMemberRolesFromInteraction(i)...) {
+		return nil, nil, study.ErrNotManager
+	}
+
+	if gs.CurrentStage.IsNone() || gs.CurrentStage.IsWait() || gs.OngoingRoundID == "" {
+		return nil, nil, study.ErrRoundNotFound
+	}
+
+	// get round
+	gr, err := ac.svc.GetRound(ctx, gs.OngoingRoundID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gs, gr, nil
+}
+
+// progress of the round with the members who have outstanding work
+func dashboardEmbed(u *discordgo.User, gr *study.Round, l i18n.Localizer) *discordgo.MessageEmbed {
+	speakers := gr.Speakers()
+
+	submitted := []string{}
+	for _, id := range speakers {
+		if m, _ := gr.GetMember(id); m.ContentURL != "" {
+			submitted = append(submitted, id)
+		}
+	}
+
+	mentions := func(ids []string) []string {
+		lines := make([]string, 0, len(ids))
+		for _, id := range ids {
+			lines = append(lines, fmt.Sprintf("<@%s>", id))
+		}
+		return lines
+	}
+
+	// reviewers with assignments show how many of them they have reviewed
+	reviewers := gr.ReminderRecipients(study.ReminderTargetFeedback, nil)
+	reviewerLines := make([]string, 0, len(reviewers))
+	for _, id := range reviewers {
+		if done, total := gr.ReviewProgress(id); total > 0 {
+			reviewerLines = append(reviewerLines, fmt.Sprintf("<@%s> %d/%d", id, done, total))
+			continue
+		}
+		reviewerLines = append(reviewerLines, fmt.Sprintf("<@%s>", id))
+	}
+
+	missingContent := gr.ReminderRecipients(study.ReminderTargetContent, nil)
+	missingReflection := gr.ReminderRecipients(study.ReminderTargetReflection, nil)
+
+	field := func(name string, lines []string) *discordgo.MessageEmbedField {
+		value := l.T("없음")
+		if len(lines) > 0 {
+			value = truncate(strings.Join(lines, "\n"), 1024)
+		}
+		return &discordgo.MessageEmbedField{Name: name, Value: value, Inline: true}
+	}
+
+	embed := adminEmbed(u, l.T("%s 진행 현황", gr.Title), l.T("**<%s>** 단계 · 발표자 %d명", l.Stage(gr.Stage), len(speakers)), 0x5865f2)
+	embed.Fields = []*discordgo.MessageEmbedField{
+		field(l.T("발표 자료 제출 (%d)", len(submitted)), mentions(submitted)),
+		field(l.T("발표 자료 미제출 (%d)", len(missingContent)), mentions(missingContent)),
+		field(l.T("회고 미작성 (%d)", len(missingReflection)), mentions(missingReflection)),
+		field(l.T("피드백 미작성 (%d)", len(reviewers)), reviewerLines),
+	}
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: l.T("리마인더를 받지 않는 멤버에게는 알림을 보내지 않습니다."),
+	}
+
+	return embed
+}
+
+// buttons to nudge each lagging group, groups without members to nudge are disabled
+func nudgeButtons(gs *study.Study, gr *study.Round, l i18n.Localizer) discordgo.ActionsRow {
+	buttons := []discordgo.MessageComponent{}
+
+	for _, t := range study.ReminderTargets() {
+		n := len(gr.ReminderRecipients(t, gs.ReminderOptOuts))
+
+		buttons = append(buttons, discordgo.Button{
+			CustomID: command.CustomID(nudgeButtonCustomID, gr.ID, t.String()),
+			Label:    l.T("%s 알림 (%d명)", l.ReminderTarget(t), n),
+			Style:    discordgo.PrimaryButton,
+			Disabled: n == 0,
+		})
+	}
+
+	return discordgo.ActionsRow{Components: buttons}
+}
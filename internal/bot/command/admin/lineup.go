@@ -0,0 +1,238 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+)
+
+var mentionRegexp = regexp.MustCompile(`<@!?(\d+)>`)
+
+// handle lineup command
+func (ac *adminCommand) lineupHandler(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrUserNotFound
+	}
+
+	options := i.ApplicationCommandData().Options
+
+	cmd := options[0].StringValue()
+
+	var order string
+
+	for _, o := range options[1:] {
+		switch o.Name {
+		case "순서":
+			order = o.StringValue()
+		}
+	}
+
+	var err error
+
+	switch cmd {
+	case "lineup-random", "lineup-registration", "lineup-manual":
+		err = ac.setLineup(s, i, study.LineupOrder(strings.TrimPrefix(cmd, "lineup-")), mentionedIDs(order))
+	case "post-lineup":
+		err = ac.postLineup(s, i)
+	default:
+		err = study.ErrInvalidCommand
+	}
+
+	// record the action of the manager
+	ac.recordAuditLog(i.GuildID, manager.ID, cmd, auditDetails(options[1:]), err)
+
+	return err
+}
+
+// ids of users mentioned in the text in order
+func mentionedIDs(text string) []string {
+	ids := []string{}
+	for _, m := range mentionRegexp.FindAllStringSubmatch(text, -1) {
+		ids = append(ids, m[1])
+	}
+	return ids
+}
+
+// set presentation order of the ongoing round
+func (ac *adminCommand) setLineup(s *discordgo.Session, i *discordgo.InteractionCreate, order study.LineupOrder, memberIDs []string) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// set lineup
+	_, gr, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		Order:          order,
+		MemberIDs:      memberIDs,
+	}, service.SetLineup, service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToSetLineup)
+	if err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{lineupEmbed(s.State.User, gr, i18n.FromInteraction(i))},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// post live message of the presentation to the notice channel, lineup is set in order of registration if it is not set
+func (ac *adminCommand) postLineup(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// prepare lineup
+	gs, gr, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+	}, service.PrepareLineup, service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToStartPresentation)
+	if err != nil {
+		return err
+	}
+
+	if gs.NoticeChannelID == "" {
+		return study.ErrChannelNotFound
+	}
+
+	sl := i18n.New(gs.Clock().Locale())
+
+	// send live message to the notice channel
+	_, err = s.ChannelMessageSendComplex(gs.NoticeChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{lineupEmbed(s.State.User, gr, sl)},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{nextSpeakerButton(sl, gr.IsLineupFinished())},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.FromInteraction(i).T("발표 진행 메시지를 게시했습니다."),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// move to the next speaker by the button of the live message, the message is updated in place
+func (ac *adminCommand) moveToNextSpeaker(s *discordgo.Session, i *discordgo.InteractionCreate) (err error) {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	// record the action of the manager
+	defer func() {
+		ac.recordAuditLog(i.GuildID, manager.ID, nextSpeakerButtonCustomID, "", err)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// move to the next speaker
+	gs, gr, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+	}, service.MoveToNextSpeaker, service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToMoveToNextSpeaker)
+	if err != nil {
+		return err
+	}
+
+	sl := i18n.New(gs.Clock().Locale())
+
+	// update the live message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{lineupEmbed(s.State.User, gr, sl)},
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{nextSpeakerButton(sl, gr.IsLineupFinished())},
+				},
+			},
+		},
+	})
+}
+
+func lineupEmbed(u *discordgo.User, r *study.Round, l i18n.Localizer) *discordgo.MessageEmbed {
+	embed := adminEmbed(u, l.T("%s 발표 진행", r.Title), "", 0x5865f2)
+
+	speaker := func(id string) string {
+		if id == "" {
+			return l.T("없음")
+		}
+		m, _ := r.GetMember(id)
+		return fmt.Sprintf("<@%s> %s", id, m.Subject)
+	}
+
+	next, _ := r.NextSpeaker()
+
+	lineup := make([]string, 0, len(r.Lineup))
+	for n, id := range r.Lineup {
+		m, _ := r.GetMember(id)
+
+		mark := "⬜"
+		switch {
+		case !m.IsRegistered():
+			mark = "➖"
+		case id == r.CurrentSpeaker:
+			mark = "🎤"
+		case !m.TalkEndedAt.IsZero():
+			mark = "✅"
+		}
+
+		line := fmt.Sprintf("%s %d. <@%s> %s", mark, n+1, id, m.Subject)
+		if d := m.TalkDuration(); d > 0 {
+			line = fmt.Sprintf("%s (%s)", line, l.Duration(d))
+		}
+
+		lineup = append(lineup, line)
+	}
+
+	value := l.T("없음")
+	if len(lineup) > 0 {
+		value = strings.Join(lineup, "\n")
+	}
+
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: l.T("지금 발표"), Value: speaker(r.CurrentSpeaker)},
+		{Name: l.T("다음 발표"), Value: speaker(next)},
+		{Name: l.T("발표 순서"), Value: value},
+	}
+
+	if r.IsLineupFinished() {
+		embed.Description = l.T("모든 발표가 끝났습니다")
+	}
+
+	return embed
+}
@@ -0,0 +1,179 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+)
+
+// add co-manager of study
+func (ac *adminCommand) addManager(s *discordgo.Session, i *discordgo.InteractionCreate, u *discordgo.User) error {
+	if u == nil {
+		return study.ErrUserNotFound
+	}
+
+	if u.Bot {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("봇은 매니저로 추가할 수 없습니다"))
+	}
+
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// add manager
+	gs, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:   i.GuildID,
+		ManagerID: manager.ID,
+		MemberID:  u.ID,
+	}, service.AddManager, service.ValidateToCheckOwner, service.ValidateToAddManager)
+	if err != nil {
+		return err
+	}
+
+	sl := i18n.New(gs.Clock().Locale())
+
+	embed := adminEmbed(s.State.User, sl.T("매니저 추가"), sl.T("%s님이 스터디 매니저로 추가되었습니다.", u.Mention()))
+
+	// send a DM to the user
+	go ac.sendDMToMember(s, u, embed)
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.FromInteraction(i).T("%s님이 매니저로 추가되었습니다.", u.Mention()),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// remove co-manager of study
+func (ac *adminCommand) removeManager(s *discordgo.Session, i *discordgo.InteractionCreate, u *discordgo.User) error {
+	if u == nil {
+		return study.ErrUserNotFound
+	}
+
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// remove manager
+	_, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:   i.GuildID,
+		ManagerID: manager.ID,
+		MemberID:  u.ID,
+	}, service.RemoveManager, service.ValidateToCheckOwner, service.ValidateToRemoveManager)
+	if err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.FromInteraction(i).T("%s님이 매니저에서 제외되었습니다.", u.Mention()),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// transfer ownership of study
+func (ac *adminCommand) transferOwnership(s *discordgo.Session, i *discordgo.InteractionCreate, u *discordgo.User) error {
+	if u == nil {
+		return study.ErrUserNotFound
+	}
+
+	if u.Bot {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("봇에게 스터디 소유권을 이전할 수 없습니다"))
+	}
+
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// transfer ownership
+	gs, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:   i.GuildID,
+		ManagerID: manager.ID,
+		MemberID:  u.ID,
+	}, service.TransferOwnership, service.ValidateToCheckOwner, service.ValidateToTransferOwnership)
+	if err != nil {
+		return err
+	}
+
+	sl := i18n.New(gs.Clock().Locale())
+
+	embed := adminEmbed(s.State.User, sl.T("스터디 소유권 이전"), sl.T("%s님에게 스터디 소유권이 이전되었습니다.", u.Mention()))
+
+	// send a DM to the user
+	go ac.sendDMToMember(s, u, embed)
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.FromInteraction(i).T("%s님에게 스터디 소유권이 이전되었습니다.", u.Mention()),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// set role that grants manager rights, no role removes it
+func (ac *adminCommand) setManagerRole(s *discordgo.Session, i *discordgo.InteractionCreate, role *discordgo.Role) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	var roleID string
+	if role != nil {
+		roleID = role.ID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// set manager role
+	_, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:   i.GuildID,
+		ManagerID: manager.ID,
+		RoleID:    roleID,
+	}, service.SetManagerRoleID, service.ValidateToCheckOwner)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	content := l.T("매니저 역할이 해제되었습니다.")
+	if role != nil {
+		content = l.T("매니저 역할이 %s로 설정되었습니다.", role.Mention())
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -0,0 +1,142 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+)
+
+// handle member command
+func (ac *adminCommand) memberHandler(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrUserNotFound
+	}
+
+	options := i.ApplicationCommandData().Options
+
+	cmd := options[0].StringValue()
+
+	var u *discordgo.User
+	var name, subject, link string
+
+	for _, o := range options[1:] {
+		switch o.Name {
+		case "사용자":
+			u = o.UserValue(s)
+		case "이름":
+			name = o.StringValue()
+		case "주제":
+			subject = o.StringValue()
+		case "링크":
+			link = o.StringValue()
+		}
+	}
+
+	var err error
+
+	if u == nil {
+		err = study.ErrUserNotFound
+	} else {
+		params := &service.UpdateParams{
+			GuildID:        i.GuildID,
+			ManagerID:      manager.ID,
+			ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+			MemberID:       u.ID,
+			MemberName:     name,
+			Subject:        subject,
+			ContentURL:     link,
+		}
+
+		switch cmd {
+		case "force-register":
+			err = ac.updateMember(s, i, params, service.ForceRegisterMember, service.ValidateToForceRegister)
+		case "unregister":
+			err = ac.updateMember(s, i, params, service.WithdrawMember, service.ValidateToUnregister)
+		case "edit-member":
+			err = ac.updateMember(s, i, params, service.EditMember, service.ValidateToEditMember)
+		case "reset-content", "reset-attendance", "reset-reviewers", "reset-reflection":
+			params.Flag = study.MemberFlag(strings.TrimPrefix(cmd, "reset-"))
+			err = ac.updateMember(s, i, params, service.ResetMember, service.ValidateToResetMember)
+		default:
+			err = study.ErrInvalidCommand
+		}
+	}
+
+	// record the action of the manager
+	ac.recordAuditLog(i.GuildID, manager.ID, cmd, auditDetails(options[1:]), err)
+
+	return err
+}
+
+// update the member of the ongoing round and show the result
+func (ac *adminCommand) updateMember(s *discordgo.Session, i *discordgo.InteractionCreate, params *service.UpdateParams,
+	update service.UpdateFunc, validator service.UpdateValidator) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// update the member
+	gs, gr, err := ac.svc.UpdateRound(ctx, params, update,
+		service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, validator)
+	if err != nil {
+		return err
+	}
+
+	// unregistered member gives the seat to the first waiting member
+	go ac.notifyPromoted(s, gs, gr, params.Promoted)
+
+	l := i18n.FromInteraction(i)
+
+	member, _ := gr.GetMember(params.MemberID)
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{memberEmbed(s.State.User, params.MemberID, member, gr.WaitlistPosition(params.MemberID), l)},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+func memberEmbed(u *discordgo.User, memberID string, m study.Member, waitlist int, l i18n.Localizer) *discordgo.MessageEmbed {
+	embed := adminEmbed(u, l.T("발표자 정보가 변경되었습니다."), fmt.Sprintf("<@%s>", memberID), 16777215)
+
+	mark := func(ok bool) string {
+		if ok {
+			return "```O```"
+		}
+		return "```X```"
+	}
+
+	registered := mark(m.IsRegistered())
+	if waitlist > 0 {
+		registered = fmt.Sprintf("```%s```", l.T("대기 %d번", waitlist))
+	}
+
+	orDefault := func(s string) string {
+		if s == "" {
+			return fmt.Sprintf("```%s```", l.T("미등록"))
+		}
+		return fmt.Sprintf("```%s```", s)
+	}
+
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: l.T("이름"), Value: orDefault(m.Name), Inline: true},
+		{Name: l.T("발표자 등록"), Value: registered, Inline: true},
+		{Name: l.T("발표 참여"), Value: mark(m.IsAttended()), Inline: true},
+		{Name: l.T("발표 주제"), Value: orDefault(m.Subject)},
+		{Name: l.T("발표 자료"), Value: orDefault(m.ContentURL)},
+		{Name: l.T("피드백"), Value: fmt.Sprintf("```%s```", l.T("%d명", len(m.Reviewers))), Inline: true},
+		{Name: l.T("회고"), Value: mark(m.HasSentReflection()), Inline: true},
+	}
+
+	return embed
+}
@@ -0,0 +1,307 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+	"go.uber.org/zap"
+)
+
+// handle reminder command
+func (ac *adminCommand) reminderHandler(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrUserNotFound
+	}
+
+	options := i.ApplicationCommandData().Options
+
+	cmd := options[0].StringValue()
+
+	var stage study.Stage
+	var target study.ReminderTarget
+	var before string
+	var position int
+
+	for _, o := range options[1:] {
+		switch o.Name {
+		case "단계":
+			stage = study.Stage(o.IntValue())
+		case "대상":
+			target = study.ReminderTarget(o.StringValue())
+		case "시간":
+			before = o.StringValue()
+		case "번호":
+			position = int(o.IntValue())
+		}
+	}
+
+	var err error
+
+	switch cmd {
+	case "add-reminder":
+		err = ac.addReminder(s, i, stage, target, before)
+	case "remove-reminder":
+		err = ac.removeReminder(s, i, position)
+	case "show-reminders":
+		err = ac.showReminders(s, i)
+	default:
+		err = study.ErrInvalidCommand
+	}
+
+	// record the action of the manager
+	ac.recordAuditLog(i.GuildID, manager.ID, cmd, auditDetails(options[1:]), err)
+
+	return err
+}
+
+// add reminder rule of the study
+func (ac *adminCommand) addReminder(s *discordgo.Session, i *discordgo.InteractionCreate, stage study.Stage, target study.ReminderTarget, before string) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	if stage.IsNone() || target == "" || before == "" {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("진행 단계, 대상, 시간은 필수입니다"))
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(before))
+	if err != nil {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("시간은 48h, 90m 형식으로 입력해주세요: %s", before))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// add reminder
+	gs, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		Reminder:       study.NewReminderRule(stage, d, target),
+	}, service.AddReminder, service.ValidateToCheckManager, service.ValidateToAddReminder)
+	if err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{remindersEmbed(s.State.User, gs, i18n.FromInteraction(i))},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// remove reminder rule at the position
+func (ac *adminCommand) removeReminder(s *discordgo.Session, i *discordgo.InteractionCreate, position int) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	if position == 0 {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("삭제할 리마인더의 번호를 입력해주세요"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// remove reminder
+	gs, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		Position:       position,
+	}, service.RemoveReminder, service.ValidateToCheckManager, service.ValidateToRemoveReminder)
+	if err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{remindersEmbed(s.State.User, gs, i18n.FromInteraction(i))},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// show reminder rules of the study
+func (ac *adminCommand) showReminders(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// get study
+	gs, err := ac.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	// check manager
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
+		return study.ErrNotManager
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{remindersEmbed(s.State.User, gs, i18n.FromInteraction(i))},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+func remindersEmbed(u *discordgo.User, gs *study.Study, l i18n.Localizer) *discordgo.MessageEmbed {
+	if len(gs.Reminders) == 0 {
+		return adminEmbed(u, l.T("리마인더"), l.T("설정된 리마인더가 없습니다."))
+	}
+
+	lines := make([]string, 0, len(gs.Reminders))
+	for n, rr := range gs.Reminders {
+		lines = append(lines, l.T("%d. %s 마감 %s 전 · %s", n+1, l.Stage(rr.Stage), l.Duration(rr.Before), l.ReminderTarget(rr.Target)))
+	}
+
+	embed := adminEmbed(u, l.T("리마인더"), strings.Join(lines, "\n"))
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: l.T("리마인더를 받지 않는 멤버: %d명", len(gs.ReminderOptOuts)),
+	}
+
+	return embed
+}
+
+// reminderScheduler sends due reminders of ongoing rounds to members with outstanding work
+type reminderScheduler struct {
+	*adminCommand
+	schedulerConfig
+}
+
+func NewReminderScheduler(svc service.Service, sugar *zap.SugaredLogger, opts ...SchedulerOptsFunc) Scheduler {
+	return &reminderScheduler{
+		adminCommand: &adminCommand{
+			svc:   svc,
+			sugar: sugar,
+		},
+		schedulerConfig: newSchedulerConfig(opts...),
+	}
+}
+
+// run scheduler until the context is done
+func (rs *reminderScheduler) Run(ctx context.Context, s *discordgo.Session) {
+	rs.run(ctx, func(ctx context.Context) {
+		rs.sendDueReminders(ctx, s)
+	})
+}
+
+// send reminders of ongoing rounds which are due
+func (rs *reminderScheduler) sendDueReminders(ctx context.Context, s *discordgo.Session) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	rounds, err := rs.svc.GetOngoingRounds(ctx)
+	if err != nil {
+		rs.sugar.Errorw("failed to get ongoing rounds", "error", err, "event", "send-due-reminders")
+		return
+	}
+
+	now := time.Now()
+
+	for _, r := range rounds {
+		// skip rounds of guilds the bot is not in
+		if _, err := s.State.Guild(r.GuildID); err != nil {
+			continue
+		}
+
+		gs, err := rs.svc.GetStudy(ctx, r.GuildID)
+		if err != nil {
+			rs.sugar.Errorw("failed to get study", "error", err, "event", "send-due-reminders", "guild", r.GuildID)
+			continue
+		}
+
+		for _, rr := range r.DueReminders(gs.Reminders, now) {
+			// mark the reminder as sent first so that it is sent once
+			params := &service.UpdateParams{
+				GuildID:  r.GuildID,
+				Reminder: rr,
+			}
+
+			gs, gr, err := rs.svc.UpdateRound(ctx, params, service.SendReminder, service.ValidateToCheckOngoingRound, service.ValidateToSendReminder)
+			// record the action of the scheduler on behalf of the bot
+			rs.recordAuditLog(r.GuildID, s.State.User.ID, "scheduled-send-reminder", fmt.Sprintf("%s=%d", rr.Target, len(params.Reminded)), err)
+
+			if err != nil {
+				rs.sugar.Errorw("failed to send reminder", "error", err, "event", "send-due-reminders", "guild", r.GuildID, "round", r.ID)
+				continue
+			}
+
+			go rs.sendReminder(s, gs, gr, rr, params.Reminded)
+
+			rs.sugar.Infow("reminder sent by scheduler", "guild", gr.GuildID, "round", gr.ID, "target", rr.Target, "members", len(params.Reminded))
+		}
+	}
+}
+
+// send the reminder to the members in the locale of the study
+func (rs *reminderScheduler) sendReminder(s *discordgo.Session, gs *study.Study, gr *study.Round, rr study.ReminderRule, memberIDs []string) {
+	if len(memberIDs) == 0 {
+		return
+	}
+
+	embed := reminderEmbed(s.State.User, gs, gr, rr, i18n.New(gs.Clock().Locale()))
+
+	for _, id := range memberIDs {
+		rs.sendDMToMember(s, &discordgo.User{ID: id}, embed)
+	}
+}
+
+func reminderEmbed(u *discordgo.User, gs *study.Study, gr *study.Round, rr study.ReminderRule, l i18n.Localizer) *discordgo.MessageEmbed {
+	var description string
+
+	switch rr.Target {
+	case study.ReminderTargetContent:
+		description = l.T("발표 자료를 아직 제출하지 않았습니다. /발표-자료-제출 명령어로 제출해주세요.")
+	case study.ReminderTargetFeedback:
+		description = l.T("아직 보내지 않은 피드백이 있습니다. /피드백 명령어로 피드백을 보내주세요.")
+	case study.ReminderTargetReflection:
+		description = l.T("발표회고를 아직 작성하지 않았습니다. /발표회고 명령어로 작성해주세요.")
+	}
+
+	embed := adminEmbed(u, l.T("%s 리마인더", gr.Title), description, 0xfee75c)
+
+	if deadline, ok := gr.GetDeadline(rr.Stage); ok {
+		c := gs.Clock()
+
+		// nudges can be sent after the deadline has passed
+		value := l.T("%s (마감 지남)", c.FormatDateTime(deadline))
+		if left := time.Until(deadline); left > 0 {
+			value = l.T("%s (%s 남음)", c.FormatDateTime(deadline), l.Duration(left.Round(time.Minute)))
+		}
+
+		embed.Fields = []*discordgo.MessageEmbedField{
+			{Name: l.T("마감"), Value: value},
+		}
+	}
+
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: l.T("/리마인더 명령어로 리마인더 DM을 끌 수 있습니다."),
+	}
+
+	return embed
+}
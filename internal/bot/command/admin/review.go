@@ -0,0 +1,281 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+)
+
+// handle review assignment command
+func (ac *adminCommand) reviewHandler(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrUserNotFound
+	}
+
+	options := i.ApplicationCommandData().Options
+
+	cmd := options[0].StringValue()
+
+	var reviews *int
+	var only *bool
+
+	for _, o := range options[1:] {
+		switch o.Name {
+		case "인원":
+			n := int(o.IntValue())
+			reviews = &n
+		case "제한":
+			b := o.BoolValue()
+			only = &b
+		}
+	}
+
+	var err error
+
+	switch cmd {
+	case "set-reviews-per-member":
+		err = ac.setReviewsPerMember(s, i, reviews)
+	case "set-assigned-only":
+		err = ac.setAssignedReviewsOnly(s, i, only)
+	case "assign-reviewers":
+		err = ac.assignReviewers(s, i)
+	case "show-assignments":
+		err = ac.showAssignments(s, i)
+	default:
+		err = study.ErrInvalidCommand
+	}
+
+	// record the action of the manager
+	ac.recordAuditLog(i.GuildID, manager.ID, cmd, auditDetails(options[1:]), err)
+
+	return err
+}
+
+// set number of speakers assigned to each reviewer when reviews open
+func (ac *adminCommand) setReviewsPerMember(s *discordgo.Session, i *discordgo.InteractionCreate, reviews *int) error {
+	if reviews == nil {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("배정할 발표자 수를 입력해주세요"))
+	}
+
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// set reviews per member
+	_, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		Reviews:        *reviews,
+	}, service.SetReviewsPerMember, service.ValidateToCheckManager, service.ValidateToSetReviewsPerMember)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	content := l.T("리뷰 시작 단계에서 리뷰어마다 발표자 %d명이 배정됩니다.", *reviews)
+	if *reviews == 0 {
+		content = l.T("리뷰어 배정이 해제되었습니다.")
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// set whether members can review only the speakers assigned to them
+func (ac *adminCommand) setAssignedReviewsOnly(s *discordgo.Session, i *discordgo.InteractionCreate, only *bool) error {
+	if only == nil {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("피드백 제한 여부를 선택해주세요"))
+	}
+
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// set assigned reviews only
+	_, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		AssignedOnly:   *only,
+	}, service.SetAssignedReviewsOnly, service.ValidateToCheckManager)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	content := l.T("배정된 발표자에게만 피드백을 보낼 수 있습니다.")
+	if !*only {
+		content = l.T("배정과 관계없이 모든 발표자에게 피드백을 보낼 수 있습니다.")
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// assign reviewers of the ongoing round again, used when attendance has changed after reviews opened
+func (ac *adminCommand) assignReviewers(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// assign reviewers
+	params := &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+	}
+
+	gs, gr, err := ac.svc.UpdateRound(ctx, params, service.AssignReviewers,
+		service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToAssignReviewers)
+	if err != nil {
+		return err
+	}
+
+	if params.Assigned {
+		go ac.notifyReviewAssignments(s, gs, gr)
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{assignmentsEmbed(s.State.User, gr, i18n.FromInteraction(i))},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// show assignments of the ongoing round with progress of each reviewer
+func (ac *adminCommand) showAssignments(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// get study
+	gs, err := ac.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	// check manager
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
+		return study.ErrNotManager
+	}
+
+	if gs.OngoingRoundID == "" {
+		return study.ErrRoundNotFound
+	}
+
+	// get round
+	gr, err := ac.svc.GetRound(ctx, gs.OngoingRoundID)
+	if err != nil {
+		return err
+	}
+
+	if !gr.HasAssignments() {
+		return study.ErrReviewersNotAssigned
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{assignmentsEmbed(s.State.User, gr, i18n.FromInteraction(i))},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// send each reviewer the speakers assigned to them in the locale of the study
+func (ac *adminCommand) notifyReviewAssignments(s *discordgo.Session, gs *study.Study, gr *study.Round) {
+	l := i18n.New(gs.Clock().Locale())
+
+	for _, reviewerID := range gr.AssignedReviewers() {
+		lines := []string{}
+		for _, id := range gr.AssignedReviewees(reviewerID) {
+			m, _ := gr.GetMember(id)
+			lines = append(lines, fmt.Sprintf("<@%s> %s", id, m.Subject))
+		}
+
+		description := l.T("아래 발표자에게 /피드백 명령어로 피드백을 보내주세요.")
+		if gs.AssignedReviewsOnly {
+			description += "\n" + l.T("배정된 발표자에게만 피드백을 보낼 수 있습니다.")
+		}
+
+		embed := adminEmbed(s.State.User, l.T("%s 리뷰 배정", gr.Title), description, 0x5865f2)
+		embed.Fields = []*discordgo.MessageEmbedField{
+			{Name: l.T("발표자"), Value: truncate(strings.Join(lines, "\n"), 1024)},
+		}
+
+		ac.sendDMToMember(s, &discordgo.User{ID: reviewerID}, embed)
+	}
+}
+
+// assignments of each reviewer with the number of speakers reviewed
+func assignmentsEmbed(u *discordgo.User, gr *study.Round, l i18n.Localizer) *discordgo.MessageEmbed {
+	reviewers := gr.AssignedReviewers()
+
+	completed := 0
+	lines := make([]string, 0, len(reviewers))
+
+	for _, reviewerID := range reviewers {
+		done, total := gr.ReviewProgress(reviewerID)
+
+		mark := "⬜"
+		if done == total {
+			mark = "✅"
+			completed++
+		}
+
+		reviewees := make([]string, 0, total)
+		for _, id := range gr.AssignedReviewees(reviewerID) {
+			reviewees = append(reviewees, fmt.Sprintf("<@%s>", id))
+		}
+
+		lines = append(lines, fmt.Sprintf("%s <@%s> %d/%d → %s", mark, reviewerID, done, total, strings.Join(reviewees, ", ")))
+	}
+
+	description := l.T("리뷰어 %d명 중 %d명이 배정된 피드백을 모두 보냈습니다.", len(reviewers), completed)
+
+	return adminEmbed(u, l.T("%s 리뷰 배정 현황", gr.Title), truncate(description+"\n\n"+strings.Join(lines, "\n"), 4096), 0x5865f2)
+}
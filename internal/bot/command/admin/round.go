@@ -0,0 +1,260 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+)
+
+// move round back to the previous stage
+func (ac *adminCommand) rollbackRoundStage(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// rollback stage
+	gs, gr, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+	}, service.RollbackStage, service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToRollbackStage)
+	if err != nil {
+		return err
+	}
+
+	sl := i18n.New(gs.Clock().Locale())
+
+	embed := adminEmbed(s.State.User, sl.Stage(gr.Stage), sl.T("진행 단계가 **<%s>**(으)로 되돌려졌습니다.", sl.Stage(gr.Stage)))
+
+	if err := ac.notifyRound(s, gs, embed); err != nil {
+		return err
+	}
+
+	// presentation is resumed with members in the voice channel
+	if err := ac.recordVoicePresence(ctx, s, gs); err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: l.T("스터디 라운드가 **<%s>** 단계로 되돌려졌습니다.", l.Stage(gr.Stage)),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// pause round, member actions and deadlines are held until the round is resumed
+func (ac *adminCommand) pauseRound(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// pause round
+	gs, _, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+	}, service.PauseRound, service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToPauseRound)
+	if err != nil {
+		return err
+	}
+
+	sl := i18n.New(gs.Clock().Locale())
+
+	embed := adminEmbed(s.State.User, sl.T("라운드 일시 중지"), sl.T("스터디 라운드가 일시 중지되었습니다. 재개될 때까지 기다려주세요."), 16776960)
+
+	if err := ac.notifyRound(s, gs, embed); err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.FromInteraction(i).T("스터디 라운드가 일시 중지되었습니다."),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// resume paused round
+func (ac *adminCommand) resumeRound(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// resume round
+	gs, gr, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+	}, service.ResumeRound, service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToResumeRound)
+	if err != nil {
+		return err
+	}
+
+	sl := i18n.New(gs.Clock().Locale())
+
+	embed := adminEmbed(s.State.User, sl.T("라운드 재개"), sl.T("스터디 라운드가 **<%s>** 단계부터 재개되었습니다.", sl.Stage(gr.Stage)))
+
+	if err := ac.notifyRound(s, gs, embed); err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.FromInteraction(i).T("스터디 라운드가 재개되었습니다."),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// show modal to confirm cancelling round with the reason
+func (ac *adminCommand) cancelRound(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// get study
+	gs, err := ac.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	// check manager
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
+		return study.ErrNotManager
+	}
+
+	if gs.CurrentStage.IsNone() || gs.CurrentStage.IsWait() || gs.OngoingRoundID == "" {
+		return study.ErrRoundNotFound
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// show cancel modal
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: cancelRoundModalCustomID,
+			Title:    l.T("스터디 라운드 취소"),
+			Flags:    discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{cancelReasonTextInput(l)},
+				},
+			},
+		},
+	})
+}
+
+// cancel ongoing round after the modal is submitted
+func (ac *adminCommand) cancelRoundConfirm(s *discordgo.Session, i *discordgo.InteractionCreate) (err error) {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	var reason string
+
+	for _, c := range i.ModalSubmitData().Components {
+		row, ok := c.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+
+		for _, c := range row.Components {
+			input, ok := c.(*discordgo.TextInput)
+			if !ok {
+				continue
+			}
+
+			reason = input.Value
+		}
+	}
+
+	// record the action of the manager
+	defer func() {
+		ac.recordAuditLog(i.GuildID, manager.ID, cancelRoundModalCustomID, reason, err)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// cancel round
+	gs, gr, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		Reason:         reason,
+	}, service.CancelRound, service.ValidateToCheckManager, service.ValidateToCheckOngoingRound)
+	if err != nil {
+		return err
+	}
+
+	sl := i18n.New(gs.Clock().Locale())
+
+	description := sl.T("**%d회차 %s** 라운드가 취소되었습니다.", gr.Number, gr.Title)
+	if reason != "" {
+		description = fmt.Sprintf("%s\n\n%s", description, sl.T("사유: %s", reason))
+	}
+
+	embed := adminEmbed(s.State.User, sl.T("라운드 취소"), description, 16711680)
+
+	if err := ac.notifyRound(s, gs, embed); err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.FromInteraction(i).T("스터디 라운드가 취소되었습니다."),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// send notice about the round to all members and notice channel
+func (ac *adminCommand) notifyRound(s *discordgo.Session, gs *study.Study, embed *discordgo.MessageEmbed) error {
+	// send a DM to all members
+	go ac.sendDMsToAllMember(s, embed, gs.GuildID)
+
+	// send a notice message
+	if gs.NoticeChannelID != "" {
+		_, err := s.ChannelMessageSendEmbed(gs.NoticeChannelID, embed)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"go.uber.org/zap"
+)
+
+var defaultSchedulerInterval = 30 * time.Second
+
+type Scheduler interface {
+	Run(ctx context.Context, s *discordgo.Session)
+}
+
+type schedulerConfig struct {
+	interval time.Duration
+}
+
+type SchedulerOptsFunc func(*schedulerConfig)
+
+func WithSchedulerInterval(interval time.Duration) SchedulerOptsFunc {
+	return func(sc *schedulerConfig) {
+		sc.interval = interval
+	}
+}
+
+func newSchedulerConfig(opts ...SchedulerOptsFunc) schedulerConfig {
+	sc := schedulerConfig{
+		interval: defaultSchedulerInterval,
+	}
+
+	for _, opt := range opts {
+		opt(&sc)
+	}
+
+	return sc
+}
+
+// run fn on every interval until the context is done
+func (sc schedulerConfig) run(ctx context.Context, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(sc.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx)
+		}
+	}
+}
+
+// stageScheduler moves the stage of ongoing rounds when the deadline of the current stage has passed
+type stageScheduler struct {
+	*adminCommand
+	schedulerConfig
+}
+
+func NewStageScheduler(svc service.Service, sugar *zap.SugaredLogger, opts ...SchedulerOptsFunc) Scheduler {
+	return &stageScheduler{
+		adminCommand: &adminCommand{
+			svc:   svc,
+			sugar: sugar,
+		},
+		schedulerConfig: newSchedulerConfig(opts...),
+	}
+}
+
+// run scheduler until the context is done
+func (ss *stageScheduler) Run(ctx context.Context, s *discordgo.Session) {
+	ss.run(ctx, func(ctx context.Context) {
+		ss.moveDueRounds(ctx, s)
+	})
+}
+
+// move stage of rounds whose deadline has passed
+func (ss *stageScheduler) moveDueRounds(ctx context.Context, s *discordgo.Session) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rounds, err := ss.svc.GetOngoingRounds(ctx)
+	if err != nil {
+		ss.sugar.Errorw("failed to get ongoing rounds", "error", err, "event", "move-due-rounds")
+		return
+	}
+
+	now := time.Now()
+
+	for _, r := range rounds {
+		if !r.IsDeadlinePassed(now) {
+			continue
+		}
+
+		// skip rounds of guilds the bot is not in
+		if _, err := s.State.Guild(r.GuildID); err != nil {
+			continue
+		}
+
+		// move stage through the same path as the manager does
+		params := &service.UpdateParams{
+			GuildID: r.GuildID,
+		}
+
+		gs, gr, err := ss.svc.UpdateRound(ctx, params, service.MoveStage, service.ValidateToCheckOngoingRound, service.ValidateToMoveStageOnDeadline)
+		if err != nil {
+			// failures are retried on every tick, so they are logged rather than recorded in the audit log
+			ss.sugar.Errorw("failed to move stage", "error", err, "event", "move-due-rounds", "guild", r.GuildID, "round", r.ID)
+			continue
+		}
+
+		// record the action of the scheduler on behalf of the bot
+		ss.recordAuditLog(r.GuildID, s.State.User.ID, "scheduled-move-stage", r.Stage.String(), nil)
+
+		if err := ss.notifyStageMoved(s, gs, gr); err != nil {
+			ss.sugar.Errorw("failed to notify stage moved", "error", err, "event", "move-due-rounds", "guild", r.GuildID, "round", r.ID)
+		}
+
+		if err := ss.recordVoicePresence(ctx, s, gs); err != nil {
+			ss.sugar.Errorw("failed to record voice presence", "error", err, "event", "move-due-rounds", "guild", r.GuildID, "round", r.ID)
+		}
+
+		if needsAttendanceReview(gs, gr) {
+			go ss.notifyAttendanceReview(s, gs, gr)
+		}
+
+		if params.Assigned {
+			go ss.notifyReviewAssignments(s, gs, gr)
+		}
+
+		ss.sugar.Infow("stage moved by scheduler", "guild", gr.GuildID, "round", gr.ID, "stage", gr.Stage.String())
+	}
+}
@@ -0,0 +1,392 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+	"go.uber.org/zap"
+)
+
+// handle series command
+func (ac *adminCommand) seriesHandler(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrUserNotFound
+	}
+
+	options := i.ApplicationCommandData().Options
+
+	cmd := options[0].StringValue()
+
+	var cron, titleTemplate, offsets, holidays, pipeline string
+
+	for _, o := range options[1:] {
+		switch o.Name {
+		case "일정":
+			cron = o.StringValue()
+		case "제목-형식":
+			titleTemplate = o.StringValue()
+		case "단계별-마감":
+			offsets = o.StringValue()
+		case "휴일":
+			holidays = o.StringValue()
+		case "진행-방식":
+			pipeline = o.StringValue()
+		}
+	}
+
+	var err error
+
+	switch cmd {
+	case "set-series":
+		err = ac.setSeries(s, i, cron, titleTemplate, offsets, holidays, pipeline)
+	case "show-series":
+		err = ac.showSeries(s, i)
+	case "remove-series":
+		err = ac.removeSeries(s, i)
+	default:
+		err = study.ErrInvalidCommand
+	}
+
+	// record the action of the manager
+	ac.recordAuditLog(i.GuildID, manager.ID, cmd, auditDetails(options[1:]), err)
+
+	return err
+}
+
+// set series of study, previous series is replaced
+func (ac *adminCommand) setSeries(s *discordgo.Session, i *discordgo.InteractionCreate, cron, titleTemplate, offsets, holidays, pipeline string) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	if cron == "" {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("반복 일정은 필수입니다"))
+	}
+
+	l := i18n.FromInteraction(i)
+
+	series, err := newSeries(cron, titleTemplate, offsets, holidays, pipeline, l)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// set series
+	gs, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		Series:         series,
+	}, service.SetSeries, service.ValidateToCheckManager, service.ValidateToSetSeries)
+	if err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{seriesEmbed(s.State.User, gs.Series, study.NewClock(gs.TimeZone, l.Locale()), l)},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// show series of study
+func (ac *adminCommand) showSeries(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// get study
+	gs, err := ac.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	// check manager
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
+		return study.ErrNotManager
+	}
+
+	if !gs.HasSeries() {
+		return study.ErrSeriesNotFound
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{seriesEmbed(s.State.User, gs.Series, study.NewClock(gs.TimeZone, l.Locale()), l)},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// remove series of study, ongoing round is not affected
+func (ac *adminCommand) removeSeries(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// remove series
+	_, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+	}, service.RemoveSeries, service.ValidateToCheckManager, service.ValidateToCheckSeries)
+	if err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.FromInteraction(i).T("정기 라운드 설정이 해제되었습니다."),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// build series from command options, the next run is scheduled when it is set to the study
+func newSeries(cron, titleTemplate, offsets, holidays, pipeline string, l i18n.Localizer) (*study.Series, error) {
+	series := study.NewSeries(cron, titleTemplate, pipeline)
+
+	p, err := study.PipelineTemplate(pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	// offsets are written as "<stage>=<duration>" separated by comma
+	for _, item := range splitList(offsets) {
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, errors.Join(study.ErrInvalidArgs, study.Errorf("단계별 마감은 <단계>=<시간> 형식으로 입력해주세요: %s", item))
+		}
+
+		stage, ok := stageByName(p, strings.TrimSpace(name), l)
+		if !ok {
+			return nil, errors.Join(study.ErrInvalidStage, study.Errorf("진행 방식에 포함되지 않은 단계입니다: %s", name))
+		}
+
+		offset, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, errors.Join(study.ErrInvalidArgs, study.Errorf("시간은 48h, 90m 형식으로 입력해주세요: %s", value))
+		}
+
+		series.SetStageOffset(stage, offset)
+	}
+
+	for _, date := range splitList(holidays) {
+		series.AddHoliday(date)
+	}
+
+	if err := series.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &series, nil
+}
+
+// find stage of the pipeline by its korean or localized name
+func stageByName(p study.Pipeline, name string, l i18n.Localizer) (study.Stage, bool) {
+	for _, step := range p.Steps {
+		if step.Stage.String() == name || strings.EqualFold(l.Stage(step.Stage), name) {
+			return step.Stage, true
+		}
+	}
+	return study.StageNone, false
+}
+
+// split comma or newline separated list, empty items are dropped
+func splitList(s string) []string {
+	items := []string{}
+
+	for _, item := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func seriesEmbed(u *discordgo.User, series *study.Series, c study.Clock, l i18n.Localizer) *discordgo.MessageEmbed {
+	embed := adminEmbed(u, l.T("정기 라운드"), l.T("다음 라운드는 **%s**에 생성됩니다.", c.FormatDateTime(series.NextRunAt)), 16777215)
+
+	pipeline := series.Pipeline
+	if pipeline == "" {
+		pipeline = study.DefaultPipelineName
+	}
+
+	offsets := make([]string, 0, len(series.StageOffsets))
+	for _, stage := range series.OffsetStages() {
+		offsets = append(offsets, l.T("%s: 시작 후 %s", l.Stage(stage), series.StageOffsets[stage]))
+	}
+
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: l.T("일정"), Value: fmt.Sprintf("```%s (%s)```", series.Cron, c.Location()), Inline: true},
+		{Name: l.T("제목 형식"), Value: fmt.Sprintf("```%s```", series.TitleTemplate), Inline: true},
+		{Name: l.T("진행 방식"), Value: fmt.Sprintf("```%s```", pipeline), Inline: true},
+		{Name: l.T("단계별 마감"), Value: fmt.Sprintf("```%s```", joinOrDefault(offsets, l.T("미설정")))},
+		{Name: l.T("휴일"), Value: fmt.Sprintf("```%s```", joinOrDefault(series.Holidays, l.T("없음")))},
+	}
+
+	return embed
+}
+
+func joinOrDefault(items []string, def string) string {
+	if len(items) == 0 {
+		return def
+	}
+	return strings.Join(items, "\n")
+}
+
+// seriesScheduler creates rounds of studies when their series is due
+type seriesScheduler struct {
+	*adminCommand
+	schedulerConfig
+}
+
+func NewSeriesScheduler(svc service.Service, sugar *zap.SugaredLogger, opts ...SchedulerOptsFunc) Scheduler {
+	return &seriesScheduler{
+		adminCommand: &adminCommand{
+			svc:   svc,
+			sugar: sugar,
+		},
+		schedulerConfig: newSchedulerConfig(opts...),
+	}
+}
+
+// run scheduler until the context is done
+func (ss *seriesScheduler) Run(ctx context.Context, s *discordgo.Session) {
+	ss.run(ctx, func(ctx context.Context) {
+		ss.createDueRounds(ctx, s)
+	})
+}
+
+// create rounds of studies whose series is due
+func (ss *seriesScheduler) createDueRounds(ctx context.Context, s *discordgo.Session) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+
+	studies, err := ss.svc.GetStudiesWithDueSeries(ctx, now)
+	if err != nil {
+		ss.sugar.Errorw("failed to get studies with due series", "error", err, "event", "create-due-rounds")
+		return
+	}
+
+	for _, gs := range studies {
+		// skip studies of guilds the bot is not in
+		if _, err := s.State.Guild(gs.GuildID); err != nil {
+			continue
+		}
+
+		scheduled := gs.Series.NextRunAt
+
+		// claim the run first so that the round is created only once
+		_, err := ss.svc.UpdateStudy(ctx, &service.UpdateParams{
+			GuildID: gs.GuildID,
+		}, service.AdvanceSeries, service.ValidateToRunSeries)
+		if err != nil {
+			ss.sugar.Errorw("failed to advance series", "error", err, "event", "create-due-rounds", "guild", gs.GuildID)
+			continue
+		}
+
+		if reason := seriesSkipReason(gs, scheduled, now); reason != "" {
+			ss.recordAuditLog(gs.GuildID, s.State.User.ID, "scheduled-skip-round", reason, nil)
+			ss.sugar.Infow("series run skipped", "guild", gs.GuildID, "scheduled", scheduled, "reason", reason)
+			continue
+		}
+
+		title := gs.Series.Title(gs.TotalRound + 1)
+
+		err = ss.createSeriesRound(ctx, s, gs, title, scheduled)
+		// record the action of the scheduler on behalf of the bot
+		ss.recordAuditLog(gs.GuildID, s.State.User.ID, "scheduled-create-round", title, err)
+
+		if err != nil {
+			ss.sugar.Errorw("failed to create round of series", "error", err, "event", "create-due-rounds", "guild", gs.GuildID)
+			continue
+		}
+
+		ss.sugar.Infow("round created by scheduler", "guild", gs.GuildID, "title", title)
+	}
+}
+
+// reason to skip the run, empty if the round should be created
+func seriesSkipReason(gs *study.Study, scheduled, now time.Time) string {
+	c := gs.Clock()
+
+	switch {
+	case gs.Series.IsHoliday(c.In(scheduled)):
+		return fmt.Sprintf("휴일 (%s)", c.FormatDate(scheduled))
+	case now.Sub(scheduled) > seriesGracePeriod:
+		return fmt.Sprintf("지난 일정 (%s)", c.FormatDateTime(scheduled))
+	case !(gs.CurrentStage.IsNone() || gs.CurrentStage.IsWait()):
+		return "진행중인 라운드가 있습니다"
+	default:
+		return ""
+	}
+}
+
+// create round on behalf of the owner with deadlines from the scheduled time
+func (ss *seriesScheduler) createSeriesRound(ctx context.Context, s *discordgo.Session, gs *study.Study, title string, scheduled time.Time) error {
+	memberIDs, err := guildMemberIDs(s, gs.GuildID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	deadlines := map[study.Stage]time.Time{}
+	for stage, offset := range gs.Series.StageOffsets {
+		// deadlines passed while the run was delayed are not set
+		if deadline := scheduled.Add(offset); deadline.After(now) {
+			deadlines[stage] = deadline
+		}
+	}
+
+	gs, err = ss.svc.NewRound(ctx, &service.NewRoundParams{
+		GuildID:   gs.GuildID,
+		ManagerID: gs.ManagerID,
+		Title:     title,
+		MemberIDs: memberIDs,
+		Pipeline:  gs.Series.Pipeline,
+		Deadlines: deadlines,
+	})
+	if err != nil {
+		return err
+	}
+
+	l := i18n.New(gs.Clock().Locale())
+
+	embed := adminEmbed(s.State.User, l.T("스터디 라운드 생성"), l.T("**<%s>**가 생성되었습니다.", title))
+
+	return ss.notifyRound(s, gs, embed)
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
 )
 
 var (
@@ -36,6 +38,22 @@ var (
 						Name:  "스터디 라운드 이동",
 						Value: "move-round-stage",
 					},
+					{
+						Name:  "스터디 라운드 되돌리기",
+						Value: "rollback-round-stage",
+					},
+					{
+						Name:  "스터디 라운드 일시 중지",
+						Value: "pause-round",
+					},
+					{
+						Name:  "스터디 라운드 재개",
+						Value: "resume-round",
+					},
+					{
+						Name:  "스터디 라운드 취소",
+						Value: "cancel-round",
+					},
 					{
 						Name:  "발표자 참여 확정",
 						Value: "confirm-attendance",
@@ -56,6 +74,42 @@ var (
 						Name:  "스프레드시트 설정",
 						Value: "set-spreadsheet",
 					},
+					{
+						Name:  "시간대 설정",
+						Value: "set-time-zone",
+					},
+					{
+						Name:  "언어 설정",
+						Value: "set-locale",
+					},
+					{
+						Name:  "피드백 방식 설정",
+						Value: "set-feedback-mode",
+					},
+					{
+						Name:  "진행 단계 마감 시간 설정",
+						Value: "set-stage-deadline",
+					},
+					{
+						Name:  "매니저 추가",
+						Value: "add-manager",
+					},
+					{
+						Name:  "매니저 제외",
+						Value: "remove-manager",
+					},
+					{
+						Name:  "스터디 소유권 이전",
+						Value: "transfer-ownership",
+					},
+					{
+						Name:  "매니저 역할 설정",
+						Value: "set-manager-role",
+					},
+					{
+						Name:  "매니저 활동 기록",
+						Value: "audit-log",
+					},
 				},
 				Required: true,
 			},
@@ -74,25 +128,524 @@ var (
 				Description: "채널을 선택해주세요.",
 				Type:        discordgo.ApplicationCommandOptionChannel,
 			},
+			{
+				Name:        "역할",
+				Description: "역할을 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionRole,
+			},
+			{
+				Name:        "단계",
+				Description: "진행 단계를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Choices:     stageChoices(),
+			},
+			{
+				Name:        "진행-방식",
+				Description: "스터디 라운드의 진행 방식을 선택해주세요. 기본 진행 방식이 사용됩니다.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices:     pipelineChoices(),
+			},
+			{
+				Name:        "언어",
+				Description: "스터디에서 사용할 언어를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices:     localeChoices(),
+			},
+			{
+				Name:        "피드백-방식",
+				Description: "발표자가 받은 피드백을 누가 볼 수 있는지 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices:     feedbackModeChoices(),
+			},
+		},
+	}
+	seriesCmd = discordgo.ApplicationCommand{
+		Name:        "정기-라운드",
+		Description: "정해진 일정에 스터디 라운드를 자동으로 생성합니다. 매니저만 사용할 수 있습니다.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "명령어",
+				Description: "사용할 명령어를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "설정",
+						Value: "set-series",
+					},
+					{
+						Name:  "조회",
+						Value: "show-series",
+					},
+					{
+						Name:  "해제",
+						Value: "remove-series",
+					},
+				},
+				Required: true,
+			},
+			{
+				Name:        "일정",
+				Description: "분 시 일 월 요일 형식의 반복 일정입니다. (예: 0 14 * * 6 → 매주 토요일 14시)",
+				Type:        discordgo.ApplicationCommandOptionString,
+			},
+			{
+				Name:        "제목-형식",
+				Description: "라운드 제목 형식입니다. {n}은 라운드 번호로 바뀝니다. (기본: {n}회차 발표)",
+				Type:        discordgo.ApplicationCommandOptionString,
+			},
+			{
+				Name:        "단계별-마감",
+				Description: "라운드 시작부터 각 단계 마감까지의 시간입니다. (예: 발표자 등록=48h, 발표 자료 제출=120h)",
+				Type:        discordgo.ApplicationCommandOptionString,
+			},
+			{
+				Name:        "휴일",
+				Description: "라운드를 생성하지 않을 날짜입니다. (예: 2024-12-25, 2025-01-01)",
+				Type:        discordgo.ApplicationCommandOptionString,
+			},
+			{
+				Name:        "진행-방식",
+				Description: "생성되는 라운드의 진행 방식을 선택해주세요. 기본 진행 방식이 사용됩니다.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices:     pipelineChoices(),
+			},
+		},
+	}
+	waitlistCmd = discordgo.ApplicationCommand{
+		Name:        "대기열",
+		Description: "발표자 정원과 대기열을 관리합니다. 매니저만 사용할 수 있습니다.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "명령어",
+				Description: "사용할 명령어를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "정원 설정",
+						Value: "set-speaker-cap",
+					},
+					{
+						Name:  "조회",
+						Value: "show-waitlist",
+					},
+					{
+						Name:  "순서 변경",
+						Value: "move-waitlist-member",
+					},
+				},
+				Required: true,
+			},
+			{
+				Name:        "정원",
+				Description: "라운드의 발표자 정원입니다. 0으로 설정하면 제한이 없습니다.",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				MinValue:    &minSpeakerCap,
+			},
+			{
+				Name:        "사용자",
+				Description: "순서를 변경할 대기자를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionUser,
+			},
+			{
+				Name:        "순번",
+				Description: "대기자를 옮길 순번입니다. 1번이 가장 먼저 등록됩니다.",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				MinValue:    &minWaitlistPosition,
+			},
+		},
+	}
+	memberCmd = discordgo.ApplicationCommand{
+		Name:        "발표자-관리",
+		Description: "라운드 참여자의 등록 정보와 상태를 수정합니다. 매니저만 사용할 수 있습니다.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "명령어",
+				Description: "사용할 명령어를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "강제 등록",
+						Value: "force-register",
+					},
+					{
+						Name:  "등록 취소",
+						Value: "unregister",
+					},
+					{
+						Name:  "정보 수정",
+						Value: "edit-member",
+					},
+					{
+						Name:  "발표 자료 초기화",
+						Value: "reset-content",
+					},
+					{
+						Name:  "발표 참여 초기화",
+						Value: "reset-attendance",
+					},
+					{
+						Name:  "피드백 초기화",
+						Value: "reset-reviewers",
+					},
+					{
+						Name:  "회고 초기화",
+						Value: "reset-reflection",
+					},
+				},
+				Required: true,
+			},
+			{
+				Name:        "사용자",
+				Description: "대상 사용자를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Required:    true,
+			},
+			{
+				Name:        "이름",
+				Description: "발표자의 이름을 입력해 주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+			},
+			{
+				Name:        "주제",
+				Description: "발표 주제를 입력해 주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+			},
+			{
+				Name:        "링크",
+				Description: "발표 자료 링크를 입력해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+			},
+		},
+	}
+	lineupCmd = discordgo.ApplicationCommand{
+		Name:        "발표-순서",
+		Description: "발표 순서를 정하고 발표 진행 메시지를 게시합니다. 매니저만 사용할 수 있습니다.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "명령어",
+				Description: "사용할 명령어를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "무작위로 정하기",
+						Value: "lineup-random",
+					},
+					{
+						Name:  "등록순으로 정하기",
+						Value: "lineup-registration",
+					},
+					{
+						Name:  "직접 정하기",
+						Value: "lineup-manual",
+					},
+					{
+						Name:  "발표 진행 메시지 게시",
+						Value: "post-lineup",
+					},
+				},
+				Required: true,
+			},
+			{
+				Name:        "순서",
+				Description: "발표자를 발표할 순서대로 멘션해주세요. 멘션하지 않은 발표자는 등록순으로 뒤에 배치됩니다.",
+				Type:        discordgo.ApplicationCommandOptionString,
+			},
 		},
 	}
-	noticeTextInput = discordgo.TextInput{
+	voiceCmd = discordgo.ApplicationCommand{
+		Name:        "음성-출석",
+		Description: "발표 중 음성 채널 참여로 출석을 확인합니다. 매니저만 사용할 수 있습니다.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "명령어",
+				Description: "사용할 명령어를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "음성 채널 설정",
+						Value: "set-voice-channel",
+					},
+					{
+						Name:  "음성 채널 해제",
+						Value: "remove-voice-channel",
+					},
+					{
+						Name:  "출석 인정 비율 설정",
+						Value: "set-attendance-share",
+					},
+					{
+						Name:  "출석 검토",
+						Value: "review-attendance",
+					},
+				},
+				Required: true,
+			},
+			{
+				Name:         "채널",
+				Description:  "발표가 진행되는 음성 채널 또는 스테이지 채널을 선택해주세요.",
+				Type:         discordgo.ApplicationCommandOptionChannel,
+				ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildVoice, discordgo.ChannelTypeGuildStageVoice},
+			},
+			{
+				Name:        "비율",
+				Description: "발표 시간 중 음성 채널에 머물러야 출석으로 인정되는 비율(%)입니다.",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				MinValue:    &minAttendanceShare,
+				MaxValue:    maxAttendanceShare,
+			},
+		},
+	}
+	reviewCmd = discordgo.ApplicationCommand{
+		Name:        "리뷰-배정",
+		Description: "발표자마다 피드백이 고르게 돌아가도록 리뷰어를 배정합니다. 매니저만 사용할 수 있습니다.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "명령어",
+				Description: "사용할 명령어를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "리뷰어당 발표자 수 설정",
+						Value: "set-reviews-per-member",
+					},
+					{
+						Name:  "배정된 발표자만 피드백 허용",
+						Value: "set-assigned-only",
+					},
+					{
+						Name:  "리뷰어 다시 배정",
+						Value: "assign-reviewers",
+					},
+					{
+						Name:  "배정 현황",
+						Value: "show-assignments",
+					},
+				},
+				Required: true,
+			},
+			{
+				Name:        "인원",
+				Description: "리뷰어마다 배정할 발표자 수입니다. 0이면 배정하지 않습니다.",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				MinValue:    &minReviewsPerMember,
+				MaxValue:    study.MaxReviewsPerMember,
+			},
+			{
+				Name:        "제한",
+				Description: "배정된 발표자에게만 피드백을 보낼 수 있게 할지 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+			},
+		},
+	}
+	reminderCmd = discordgo.ApplicationCommand{
+		Name:        "리마인더-설정",
+		Description: "마감 전에 할 일이 남은 멤버에게 DM을 보내는 리마인더를 설정합니다. 매니저만 사용할 수 있습니다.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "명령어",
+				Description: "사용할 명령어를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "리마인더 추가",
+						Value: "add-reminder",
+					},
+					{
+						Name:  "리마인더 삭제",
+						Value: "remove-reminder",
+					},
+					{
+						Name:  "리마인더 목록",
+						Value: "show-reminders",
+					},
+				},
+				Required: true,
+			},
+			{
+				Name:        "단계",
+				Description: "마감 시간을 기준으로 리마인더를 보낼 진행 단계를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Choices:     stageChoices(),
+			},
+			{
+				Name:        "대상",
+				Description: "리마인더를 받을 멤버를 선택해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Choices:     reminderTargetChoices(),
+			},
+			{
+				Name:        "시간",
+				Description: "마감 몇 시간 전에 보낼지 24h, 90m 형식으로 입력해주세요.",
+				Type:        discordgo.ApplicationCommandOptionString,
+			},
+			{
+				Name:        "번호",
+				Description: "삭제할 리마인더의 번호입니다. 리마인더 목록에서 확인할 수 있습니다.",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				MinValue:    &minReminderPosition,
+			},
+		},
+	}
+	dashboardCmd = discordgo.ApplicationCommand{
+		Name:        "진행-현황",
+		Description: "진행중인 라운드에서 할 일이 남은 멤버를 확인하고 알림을 보냅니다. 매니저만 사용할 수 있습니다.",
+	}
+)
+
+var (
+	minSpeakerCap       float64 = 0
+	minWaitlistPosition float64 = 1
+	minAttendanceShare  float64 = 1
+	maxAttendanceShare  float64 = 100
+	minReviewsPerMember float64 = 0
+	minReminderPosition float64 = 1
+)
+
+const (
+	noticeModalCustomID            = "notice"
+	cancelRoundModalCustomID       = "cancel-round"
+	stageMoveConfirmButtonCustomID = "confirm-move-stage"
+	nextSpeakerButtonCustomID      = "next-speaker"
+	reviewAttendanceCustomID       = "review-attendance"
+	nudgeButtonCustomID            = "nudge"
+	auditLogLimit                  = 10
+
+	// runs of series later than this are skipped instead of creating a round
+	seriesGracePeriod = time.Hour
+)
+
+// choices of stages that can have a deadline
+func stageChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+
+	for stage := study.StageRegistrationOpened; stage <= study.StageReviewClosed; stage++ {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  stage.String(),
+			Value: int(stage),
+		})
+	}
+
+	return choices
+}
+
+// choices of pipeline templates for new round
+func pipelineChoices() []*discordgo.ApplicationCommandOptionChoice {
+	names := map[string]string{
+		study.DefaultPipelineName:   "기본",
+		study.LightningPipelineName: "라이트닝 토크 (자료 제출·피드백 생략)",
+		study.NoReviewPipelineName:  "피드백 생략",
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+
+	for _, name := range study.PipelineTemplateNames() {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  names[name],
+			Value: name,
+		})
+	}
+
+	return choices
+}
+
+// choices of supported locales
+func localeChoices() []*discordgo.ApplicationCommandOptionChoice {
+	names := map[study.Locale]string{
+		study.LocaleKorean:  "한국어",
+		study.LocaleEnglish: "English",
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+
+	for _, l := range study.Locales() {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  names[l],
+			Value: l.String(),
+		})
+	}
+
+	return choices
+}
+
+// choices of members reminded
+func reminderTargetChoices() []*discordgo.ApplicationCommandOptionChoice {
+	names := map[study.ReminderTarget]string{
+		study.ReminderTargetContent:    "발표 자료를 제출하지 않은 발표자",
+		study.ReminderTargetFeedback:   "피드백을 보내지 않은 멤버",
+		study.ReminderTargetReflection: "회고를 작성하지 않은 발표자",
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+
+	for _, t := range study.ReminderTargets() {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  names[t],
+			Value: t.String(),
+		})
+	}
+
+	return choices
+}
+
+// choices of supported feedback modes
+func feedbackModeChoices() []*discordgo.ApplicationCommandOptionChoice {
+	names := map[study.FeedbackMode]string{
+		study.FeedbackModeNamed:     "실명 (발표자가 작성자와 함께 확인)",
+		study.FeedbackModeAnonymous: "익명 (발표자가 작성자 없이 확인)",
+		study.FeedbackModeManagers:  "매니저 전용 (매니저만 작성자와 함께 확인)",
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+
+	for _, m := range study.FeedbackModes() {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  names[m],
+			Value: m.String(),
+		})
+	}
+
+	return choices
+}
+
+func noticeTextInput(l i18n.Localizer) discordgo.TextInput {
+	return discordgo.TextInput{
 		CustomID:    "notice",
-		Label:       "공지",
+		Label:       l.T("공지"),
 		Style:       discordgo.TextInputParagraph,
-		Placeholder: "공지 내용을 입력해주세요.",
+		Placeholder: l.T("공지 내용을 입력해주세요."),
 		Required:    true,
 		MaxLength:   3000,
 		MinLength:   10,
 	}
-	stageMoveConfirmButton = discordgo.Button{
-		CustomID: "confirm-move-stage",
-		Label:    "확인",
+}
+
+func cancelReasonTextInput(l i18n.Localizer) discordgo.TextInput {
+	return discordgo.TextInput{
+		CustomID:    "cancel-reason",
+		Label:       l.T("취소 사유"),
+		Style:       discordgo.TextInputParagraph,
+		Placeholder: l.T("라운드를 취소하는 사유를 입력해주세요."),
+		Required:    true,
+		MaxLength:   500,
+	}
+}
+
+func stageMoveConfirmButton(l i18n.Localizer) discordgo.Button {
+	return discordgo.Button{
+		CustomID: stageMoveConfirmButtonCustomID,
+		Label:    l.T("확인"),
 		Style:    discordgo.SuccessButton,
 	}
-)
+}
 
-const noticeModalCustomID = "notice"
+func nextSpeakerButton(l i18n.Localizer, disabled bool) discordgo.Button {
+	return discordgo.Button{
+		CustomID: nextSpeakerButtonCustomID,
+		Label:    l.T("다음 발표자"),
+		Style:    discordgo.PrimaryButton,
+		Disabled: disabled,
+	}
+}
 
 func adminEmbed(u *discordgo.User, title, description string, color ...int) *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{
@@ -0,0 +1,404 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+)
+
+// handle voice attendance command
+func (ac *adminCommand) voiceHandler(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrUserNotFound
+	}
+
+	options := i.ApplicationCommandData().Options
+
+	cmd := options[0].StringValue()
+
+	var ch *discordgo.Channel
+	var share int
+
+	for _, o := range options[1:] {
+		switch o.Name {
+		case "채널":
+			ch = o.ChannelValue(s)
+		case "비율":
+			share = int(o.IntValue())
+		}
+	}
+
+	var err error
+
+	switch cmd {
+	case "set-voice-channel":
+		err = ac.setVoiceChannel(s, i, ch)
+	case "remove-voice-channel":
+		err = ac.removeVoiceChannel(s, i)
+	case "set-attendance-share":
+		err = ac.setAttendanceShare(s, i, share)
+	case "review-attendance":
+		err = ac.showAttendanceReview(s, i)
+	default:
+		err = study.ErrInvalidCommand
+	}
+
+	// record the action of the manager
+	ac.recordAuditLog(i.GuildID, manager.ID, cmd, auditDetails(options[1:]), err)
+
+	return err
+}
+
+// set voice channel whose presence is tracked during presentations
+func (ac *adminCommand) setVoiceChannel(s *discordgo.Session, i *discordgo.InteractionCreate, ch *discordgo.Channel) error {
+	// check if the channel is nil
+	if ch == nil {
+		return study.ErrChannelNotFound
+	}
+
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// set voice channel
+	gs, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		ChannelID:      ch.ID,
+	}, service.UpdateVoiceChannelID, service.ValidateToCheckManager)
+	if err != nil {
+		return err
+	}
+
+	// members already in the channel are recorded if the presentation is going on
+	if err := ac.recordVoicePresence(ctx, s, gs); err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.FromInteraction(i).T("음성 채널이 %s로 설정되었습니다. 발표 중 이 채널에 %d%% 이상 참여한 발표자는 자동으로 출석 처리됩니다.",
+				ch.Mention(), gs.VoiceAttendanceShare()),
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// stop tracking presence in the voice channel
+func (ac *adminCommand) removeVoiceChannel(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// remove voice channel
+	_, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+	}, service.UpdateVoiceChannelID, service.ValidateToCheckManager)
+	if err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.FromInteraction(i).T("음성 채널 설정이 해제되었습니다. 출석은 매니저가 직접 확인합니다."),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// set share of the presentation session that speakers should stay in the voice channel
+func (ac *adminCommand) setAttendanceShare(s *discordgo.Session, i *discordgo.InteractionCreate, share int) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// set attendance share
+	_, err := ac.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		Share:          share,
+	}, service.SetAttendanceShare, service.ValidateToCheckManager, service.ValidateToSetAttendanceShare)
+	if err != nil {
+		return err
+	}
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: i18n.FromInteraction(i).T("출석 인정 비율이 %d%%로 설정되었습니다.", share),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// show attendance of speakers with their presence in the voice channel to be confirmed or overridden
+func (ac *adminCommand) showAttendanceReview(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// get study
+	gs, err := ac.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	// check manager
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
+		return study.ErrNotManager
+	}
+
+	if gs.CurrentStage.IsNone() || gs.CurrentStage.IsWait() || gs.OngoingRoundID == "" {
+		return study.ErrRoundNotFound
+	}
+
+	// get round
+	gr, err := ac.svc.GetRound(ctx, gs.OngoingRoundID)
+	if err != nil {
+		return err
+	}
+
+	if len(gr.ReviewSpeakers()) == 0 {
+		return study.ErrNoSpeakers
+	}
+
+	// send a response with select menu of speakers
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: attendanceReviewData(s.State.User, gs, gr, i18n.FromInteraction(i)),
+	})
+}
+
+// confirm attendance of the speakers selected, the others are not attended
+func (ac *adminCommand) reviewAttendance(s *discordgo.Session, i *discordgo.InteractionCreate) (err error) {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	// record the action of the manager
+	defer func() {
+		ac.recordAuditLog(i.GuildID, manager.ID, reviewAttendanceCustomID, "", err)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// review attendance
+	gs, gr, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		MemberIDs:      i.MessageComponentData().Values,
+	}, service.ReviewAttendance, service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToReviewAttendance)
+	if err != nil {
+		return err
+	}
+
+	// update the review message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: attendanceReviewData(s.State.User, gs, gr, i18n.FromInteraction(i)),
+	})
+}
+
+// members already in the voice channel when the presentation starts do not trigger voice state updates,
+// they are recorded from the state of the session
+func (ac *adminCommand) recordVoicePresence(ctx context.Context, s *discordgo.Session, gs *study.Study) error {
+	if !gs.TracksVoice() || gs.CurrentStage != study.StagePresentationStarted {
+		return nil
+	}
+
+	g, err := s.State.Guild(gs.GuildID)
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	for _, vs := range g.VoiceStates {
+		if vs.ChannelID == gs.VoiceChannelID && vs.UserID != s.State.User.ID {
+			ids = append(ids, vs.UserID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, _, err = ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:   gs.GuildID,
+		MemberIDs: ids,
+	}, service.JoinVoice, service.ValidateToCheckOngoingRound, service.ValidateToTrackVoice)
+	return err
+}
+
+// check if the round has just finished the presentation tracked by voice presence
+func needsAttendanceReview(gs *study.Study, gr *study.Round) bool {
+	return gs.TracksVoice() && gr.HasPresentationSession() && !gr.AttendanceReviewed &&
+		gr.GetPipeline().Prev(gr.Stage) == study.StagePresentationStarted && len(gr.ReviewSpeakers()) > 0
+}
+
+// send the result of voice attendance to the owner and the co-managers of the study, who review it with the command
+func (ac *adminCommand) notifyAttendanceReview(s *discordgo.Session, gs *study.Study, gr *study.Round) {
+	sl := i18n.New(gs.Clock().Locale())
+
+	embed := attendanceReviewEmbed(s.State.User, gs, gr, sl)
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: sl.T("/음성-출석 명령어의 출석 검토로 결과를 확정하거나 수정할 수 있습니다."),
+	}
+
+	ac.sendDMToMember(s, &discordgo.User{ID: gs.ManagerID}, embed)
+
+	for _, id := range gs.GetManagerIDs() {
+		if !gs.IsOwner(id) {
+			ac.sendDMToMember(s, &discordgo.User{ID: id}, embed)
+		}
+	}
+}
+
+func attendanceReviewData(u *discordgo.User, gs *study.Study, gr *study.Round, l i18n.Localizer) *discordgo.InteractionResponseData {
+	return &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{attendanceReviewEmbed(u, gs, gr, l)},
+		Flags:  discordgo.MessageFlagsEphemeral,
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{attendanceReviewSelectMenu(gr, l)},
+			},
+		},
+	}
+}
+
+func attendanceReviewEmbed(u *discordgo.User, gs *study.Study, gr *study.Round, l i18n.Localizer) *discordgo.MessageEmbed {
+	tracked := gs.TracksVoice() && gr.HasPresentationSession()
+
+	description := l.T("출석을 인정할 발표자를 선택해 확정해주세요. 선택하지 않은 발표자는 미참여로 처리됩니다.")
+	if tracked {
+		description = l.T("발표 시간의 %d%% 이상 음성 채널에 참여한 발표자는 자동으로 출석 처리되었습니다.", gs.VoiceAttendanceShare()) +
+			"\n" + description
+	}
+
+	embed := adminEmbed(u, l.T("%s 출석 검토", gr.Title), description, 0x57f287)
+
+	now := time.Now()
+	speakers := gr.ReviewSpeakers()
+
+	lines := make([]string, 0, len(speakers))
+	for _, id := range speakers {
+		m, _ := gr.GetMember(id)
+
+		mark := "❌"
+		if m.IsAttended() {
+			mark = "✅"
+		}
+
+		line := fmt.Sprintf("%s <@%s> %s", mark, id, m.Name)
+		if tracked {
+			line = fmt.Sprintf("%s · %d%%", line, gr.PresenceShare(id, now))
+		}
+
+		lines = append(lines, line)
+	}
+
+	// field values have at most 1024 characters and can not be empty
+	value := l.T("없음")
+	if len(lines) > 0 {
+		value = truncate(strings.Join(lines, "\n"), 1024)
+	}
+
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: l.T("발표자"), Value: value},
+	}
+
+	if tracked {
+		from, to := gr.PresentationStartedAt, gr.PresentationEndedAt
+		if to.IsZero() {
+			to = now
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: l.T("발표 시간"), Value: l.Duration(to.Sub(from)), Inline: true,
+		})
+	}
+
+	if gr.AttendanceReviewed {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: l.T("검토"), Value: l.T("완료"), Inline: true,
+		})
+	}
+
+	return embed
+}
+
+// labels and descriptions of select menu options have at most 100 characters
+const maxSelectMenuTextLength = 100
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+func attendanceReviewSelectMenu(gr *study.Round, l i18n.Localizer) discordgo.SelectMenu {
+	speakers := gr.ReviewSpeakers()
+
+	options := make([]discordgo.SelectMenuOption, 0, len(speakers))
+	for _, id := range speakers {
+		m, _ := gr.GetMember(id)
+
+		label := m.Name
+		if label == "" {
+			label = id
+		}
+
+		options = append(options, discordgo.SelectMenuOption{
+			Label:       truncate(label, maxSelectMenuTextLength),
+			Value:       id,
+			Description: truncate(m.Subject, maxSelectMenuTextLength),
+			Default:     m.IsAttended(),
+		})
+	}
+
+	minValues := 0
+
+	return discordgo.SelectMenu{
+		CustomID:    reviewAttendanceCustomID,
+		Placeholder: l.T("출석을 인정할 발표자 선택"),
+		MinValues:   &minValues,
+		MaxValues:   len(options),
+		Options:     options,
+	}
+}
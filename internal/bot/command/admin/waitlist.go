@@ -0,0 +1,223 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/bot/command"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+)
+
+// handle waitlist command
+func (ac *adminCommand) waitlistHandler(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrUserNotFound
+	}
+
+	options := i.ApplicationCommandData().Options
+
+	cmd := options[0].StringValue()
+
+	var u *discordgo.User
+	speakerCap, position := -1, 0
+
+	for _, o := range options[1:] {
+		switch o.Name {
+		case "정원":
+			speakerCap = int(o.IntValue())
+		case "사용자":
+			u = o.UserValue(s)
+		case "순번":
+			position = int(o.IntValue())
+		}
+	}
+
+	var err error
+
+	switch cmd {
+	case "set-speaker-cap":
+		err = ac.setSpeakerCap(s, i, speakerCap)
+	case "show-waitlist":
+		err = ac.showWaitlist(s, i)
+	case "move-waitlist-member":
+		err = ac.moveWaitlistMember(s, i, u, position)
+	default:
+		err = study.ErrInvalidCommand
+	}
+
+	// record the action of the manager
+	ac.recordAuditLog(i.GuildID, manager.ID, cmd, auditDetails(options[1:]), err)
+
+	return err
+}
+
+// set speaker cap of the ongoing round, waiting members are promoted if seats are opened
+func (ac *adminCommand) setSpeakerCap(s *discordgo.Session, i *discordgo.InteractionCreate, speakerCap int) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	if speakerCap < 0 {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("발표자 정원을 입력해주세요"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	params := &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		SpeakerCap:     speakerCap,
+	}
+
+	// set speaker cap
+	gs, gr, err := ac.svc.UpdateRound(ctx, params, service.SetSpeakerCap,
+		service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToSetSpeakerCap)
+	if err != nil {
+		return err
+	}
+
+	go ac.notifyPromoted(s, gs, gr, params.Promoted)
+
+	l := i18n.FromInteraction(i)
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{waitlistEmbed(s.State.User, gr, l)},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// show speaker cap and waitlist of the ongoing round
+func (ac *adminCommand) showWaitlist(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// get study
+	gs, err := ac.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	// check manager
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
+		return study.ErrNotManager
+	}
+
+	if gs.OngoingRoundID == "" {
+		return study.ErrRoundNotFound
+	}
+
+	// get round
+	gr, err := ac.svc.GetRound(ctx, gs.OngoingRoundID)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{waitlistEmbed(s.State.User, gr, l)},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// move the waiting member to the position
+func (ac *adminCommand) moveWaitlistMember(s *discordgo.Session, i *discordgo.InteractionCreate, u *discordgo.User, position int) error {
+	if u == nil {
+		return study.ErrUserNotFound
+	}
+
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	if position == 0 {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("옮길 순번을 입력해주세요"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// move the member
+	_, gr, err := ac.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:        i.GuildID,
+		ManagerID:      manager.ID,
+		ManagerRoleIDs: utils.GetGuildMemberRolesFromInteraction(i),
+		MemberID:       u.ID,
+		Position:       position,
+	}, service.MoveWaitlistMember,
+		service.ValidateToCheckManager, service.ValidateToCheckOngoingRound, service.ValidateToMoveWaitlistMember)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// send a response message
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{waitlistEmbed(s.State.User, gr, l)},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// send DMs to members promoted from the waitlist
+func (ac *adminCommand) notifyPromoted(s *discordgo.Session, gs *study.Study, gr *study.Round, memberIDs []string) {
+	if err := command.NotifyPromoted(s, gs, gr, memberIDs); err != nil {
+		ac.sugar.Errorw(err.Error(), "event", "notify-promoted")
+	}
+}
+
+func waitlistEmbed(u *discordgo.User, r *study.Round, l i18n.Localizer) *discordgo.MessageEmbed {
+	embed := adminEmbed(u, l.T("발표자 대기열"), "", 16777215)
+
+	speakerCap := l.T("제한 없음")
+	if r.HasSpeakerCap() {
+		speakerCap = l.T("%d명", r.SpeakerCap)
+	}
+
+	waitlist := make([]string, 0, len(r.Waitlist))
+	for i, id := range r.Waitlist {
+		m, _ := r.GetMember(id)
+		waitlist = append(waitlist, fmt.Sprintf("%d. <@%s> %s (%s)", i+1, id, m.Name, m.Subject))
+	}
+
+	value := l.T("없음")
+	if len(waitlist) > 0 {
+		value = strings.Join(waitlist, "\n")
+	}
+
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: l.T("발표자 정원"), Value: fmt.Sprintf("```%s```", speakerCap), Inline: true},
+		{Name: l.T("등록된 발표자"), Value: fmt.Sprintf("```%s```", l.T("%d명", r.RegisteredCount())), Inline: true},
+		{Name: l.T("대기열"), Value: value},
+	}
+
+	return embed
+}
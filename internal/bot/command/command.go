@@ -1,6 +1,11 @@
 package command
 
-import "github.com/bwmarrin/discordgo"
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+)
 
 type Registerer interface {
 	RegisterCommand(command discordgo.ApplicationCommand, fn HandleFunc)
@@ -15,6 +20,23 @@ type Command interface {
 
 type HandleFunc func(s *discordgo.Session, i *discordgo.InteractionCreate) error
 
+// Status is shown as the presence of the bot, it is the same for every guild the bot is in
+const Status = "/도움"
+
+// custom ids of components carry arguments after the name of the handler
+const customIDSeparator = ":"
+
+// CustomID returns custom id of the component handled by the name with arguments
+func CustomID(name string, args ...string) string {
+	return strings.Join(append([]string{name}, args...), customIDSeparator)
+}
+
+// ParseCustomID splits custom id into the name of the handler and its arguments
+func ParseCustomID(customID string) (string, []string) {
+	parts := strings.Split(customID, customIDSeparator)
+	return parts[0], parts[1:]
+}
+
 type commandRegisterer struct {
 	cmds  []*discordgo.ApplicationCommand
 	funcs map[string]HandleFunc
@@ -28,6 +50,9 @@ func NewRegisterer() Registerer {
 }
 
 func (r *commandRegisterer) RegisterCommand(command discordgo.ApplicationCommand, fn HandleFunc) {
+	// commands are written in korean, other locales are set from the catalogs
+	i18n.LocalizeCommand(&command)
+
 	r.cmds = append(r.cmds, &command)
 	r.funcs[command.Name] = fn
 }
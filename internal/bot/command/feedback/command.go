@@ -2,29 +2,39 @@ package feedback
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command"
+	"github.com/piatoss3612/my-study-bot/internal/cache"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
 	"github.com/piatoss3612/my-study-bot/internal/study"
 	"github.com/piatoss3612/my-study-bot/internal/study/service"
 	"github.com/piatoss3612/my-study-bot/internal/utils"
 )
 
 type feedbackCommand struct {
-	svc service.Service
+	svc   service.Service
+	cache cache.Cache
 }
 
-func NewFeedbackCommand(svc service.Service) command.Command {
+func NewFeedbackCommand(svc service.Service, cache cache.Cache) command.Command {
 	return &feedbackCommand{
-		svc: svc,
+		svc:   svc,
+		cache: cache,
 	}
 }
 
 func (fc *feedbackCommand) Register(reg command.Registerer) {
 	reg.RegisterCommand(cmd, fc.showSendFeedbackModal)
+	reg.RegisterCommand(receivedCmd, fc.showReceivedFeedbacks)
+	reg.RegisterCommand(summaryCmd, fc.showFeedbackSummary)
 	reg.RegisterHandler(feedbackModalCustomID, fc.sendFeedback)
+	reg.RegisterHandler(feedbackPageCustomID, fc.moveFeedbackPage)
 }
 
 // show send feedback modal
@@ -46,11 +56,11 @@ func (fc *feedbackCommand) showSendFeedbackModal(s *discordgo.Session, i *discor
 	}
 
 	if speaker == nil {
-		return errors.Join(study.ErrRequiredArgs, errors.New("리뷰 대상자는 필수 입력 사항입니다"))
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("리뷰 대상자는 필수 입력 사항입니다"))
 	}
 
 	if speaker.Bot {
-		return errors.New("봇은 리뷰 대상자로 지정할 수 없습니다")
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("봇은 리뷰 대상자로 지정할 수 없습니다"))
 	}
 
 	// reviewer can't feedback to yourself
@@ -58,47 +68,79 @@ func (fc *feedbackCommand) showSendFeedbackModal(s *discordgo.Session, i *discor
 		return study.ErrFeedbackYourself
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	// get study to show the feedback mode
+	gs, err := fc.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	// keep the speaker in the cache, the modal carries only the token
+	token, err := newFeedbackToken()
+	if err != nil {
+		return err
+	}
+
+	err = fc.cache.Set(ctx, feedbackTargetKey(token), feedbackTarget{
+		GuildID:    i.GuildID,
+		ReviewerID: reviewer.ID,
+		SpeakerID:  speaker.ID,
+	}, feedbackTargetTTL)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
 	// show modal
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
-			CustomID: feedbackModalCustomID,
-			Title:    "피드백 작성",
+			CustomID: command.CustomID(feedbackModalCustomID, token),
+			Title:    l.T("피드백 작성 (%s)", l.FeedbackMode(gs.GetFeedbackMode())),
 			Flags:    discordgo.MessageFlagsEphemeral,
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{
-						discordgo.TextInput{
-							CustomID:    "speaker-id",
-							Label:       "발표자",
-							Style:       discordgo.TextInputShort,
-							Placeholder: "발표자의 ID 입니다. 임의로 변경하지 마세요.",
-							Value:       speaker.ID,
-							Required:    true,
-							MaxLength:   20,
-							MinLength:   1,
-						},
-					},
+					Components: []discordgo.MessageComponent{feedbackTextInput(l)},
 				},
 				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{textInput},
+					Components: []discordgo.MessageComponent{scoreTextInput("clarity", l.T("명확성"), l)},
+				},
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{scoreTextInput("depth", l.T("깊이"), l)},
+				},
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{scoreTextInput("delivery", l.T("전달력"), l)},
 				},
 			},
 		},
 	})
 }
 
+// random token of the feedback modal
+func newFeedbackToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // send feedback
 func (fc *feedbackCommand) sendFeedback(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	// command should be used in guild
 	reviewer := utils.GetGuildUserFromInteraction(i)
 	if reviewer == nil {
-		return errors.Join(study.ErrUserNotFound, errors.New("리뷰어 정보를 찾을 수 없습니다"))
+		return errors.Join(study.ErrUserNotFound, study.Errorf("리뷰어 정보를 찾을 수 없습니다"))
 	}
 
 	data := i.ModalSubmitData()
 
-	var speakerID, feedback string
+	var feedback string
+	var rubric study.Rubric
+	var err error
 
 	for _, c := range data.Components {
 		row, ok := c.(*discordgo.ActionsRow)
@@ -113,50 +155,350 @@ func (fc *feedbackCommand) sendFeedback(s *discordgo.Session, i *discordgo.Inter
 			}
 
 			switch input.CustomID {
-			case "speaker-id":
-				speakerID = input.Value
 			case "feedback":
 				feedback = input.Value
+			case "clarity":
+				rubric.Clarity, err = study.ParseScore(input.Value)
+			case "depth":
+				rubric.Depth, err = study.ParseScore(input.Value)
+			case "delivery":
+				rubric.Delivery, err = study.ParseScore(input.Value)
+			}
+
+			if err != nil {
+				return err
 			}
 		}
 	}
 
-	if speakerID == "" || feedback == "" {
-		return errors.Join(study.ErrRequiredArgs, errors.New("리뷰 대상자의 아이디 또는 피드백 정보를 찾을 수 없습니다"))
+	if feedback == "" {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("피드백 정보를 찾을 수 없습니다"))
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	// set reviewer id
-	_, _, err := fc.svc.UpdateRound(ctx, &service.UpdateParams{
+	// find the speaker by the token, the target should be opened by the same reviewer in the same guild
+	_, args := command.ParseCustomID(data.CustomID)
+	if len(args) != 1 {
+		return study.ErrFeedbackExpired
+	}
+
+	var target feedbackTarget
+
+	if err := fc.cache.Get(ctx, feedbackTargetKey(args[0]), &target); err != nil {
+		return errors.Join(study.ErrFeedbackExpired, err)
+	}
+
+	if target.GuildID != i.GuildID || target.ReviewerID != reviewer.ID {
+		return study.ErrFeedbackExpired
+	}
+
+	// store feedback and set reviewer id
+	gs, _, err := fc.svc.UpdateRound(ctx, &service.UpdateParams{
 		GuildID:    i.GuildID,
 		ReviewerID: reviewer.ID,
-		RevieweeID: speakerID,
-	}, service.SetReviewer, service.ValidateToSetReviewer)
+		RevieweeID: target.SpeakerID,
+		Content:    feedback,
+		Rubric:     rubric,
+	}, service.SendFeedback, service.ValidateToSendFeedback)
 	if err != nil {
 		return err
 	}
 
-	// create dm channel and send feedback
+	// the modal can't be submitted twice
+	_ = fc.cache.Delete(ctx, feedbackTargetKey(args[0]))
+
+	l := i18n.FromInteraction(i)
+	f := study.NewFeedback(reviewer.ID, target.SpeakerID, feedback, rubric, gs.GetFeedbackMode())
+
+	content := l.T("피드백이 전송되었습니다.")
+
+	// feedback is sent in the locale of the study, it is kept even if the DM fails
+	if !f.GetMode().VisibleToSpeaker() {
+		content = l.T("피드백이 저장되었습니다. 매니저만 확인할 수 있습니다.")
+	} else if err := sendFeedbackDM(s, target.SpeakerID, feedbackEmbed(s.State.User, &f, i18n.New(gs.Clock().Locale()))); err != nil {
+		content = l.T("피드백이 저장되었습니다. 발표자에게 DM을 보내지 못했지만 발표자는 /받은-피드백 명령어로 확인할 수 있습니다.")
+	}
+
+	// send response
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+func sendFeedbackDM(s *discordgo.Session, speakerID string, embed *discordgo.MessageEmbed) error {
 	channel, err := s.UserChannelCreate(speakerID)
 	if err != nil {
 		return err
 	}
 
-	embed := feedbackEmbed(s.State.User, feedback)
-
 	_, err = s.ChannelMessageSendEmbed(channel.ID, embed)
+	return err
+}
+
+// show feedbacks the user received in the round page by page
+func (fc *feedbackCommand) showReceivedFeedbacks(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	user := utils.GetGuildUserFromInteraction(i)
+	if user == nil {
+		return study.ErrUserNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	gs, err := fc.svc.GetStudy(ctx, i.GuildID)
 	if err != nil {
 		return err
 	}
 
+	gr, err := fc.findRound(ctx, gs, roundNumberOption(i))
+	if err != nil {
+		return err
+	}
+
+	feedbacks, err := fc.speakerFeedbacks(ctx, gr.ID, user.ID)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
 	// send response
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "피드백이 전송되었습니다.",
-			Flags:   discordgo.MessageFlagsEphemeral,
+			Flags:      discordgo.MessageFlagsEphemeral,
+			Embeds:     []*discordgo.MessageEmbed{feedbackPageEmbed(s.State.User, gr, feedbacks, 0, l)},
+			Components: []discordgo.MessageComponent{feedbackPageButtons(gr.ID, "", 0, len(feedbacks), l)},
 		},
 	})
 }
+
+// move to the page of feedbacks carried by the button
+func (fc *feedbackCommand) moveFeedbackPage(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	user := utils.GetGuildUserFromInteraction(i)
+	if user == nil {
+		return study.ErrUserNotFound
+	}
+
+	// args are round id, page and the speaker if managers are reviewing feedbacks
+	_, args := command.ParseCustomID(i.MessageComponentData().CustomID)
+	if len(args) != 2 && len(args) != 3 {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("잘못된 페이지 정보입니다"))
+	}
+
+	page, err := strconv.Atoi(args[1])
+	if err != nil {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("잘못된 페이지 정보입니다"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	gr, err := fc.svc.GetRound(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if gr.GuildID != i.GuildID {
+		return study.ErrRoundNotFound
+	}
+
+	var revieweeID string
+	var feedbacks []*study.Feedback
+
+	if len(args) == 3 {
+		// managers are checked again as anyone can click the button
+		revieweeID = args[2]
+		feedbacks, err = fc.managerFeedbacks(ctx, i, gr.ID, revieweeID)
+	} else {
+		// feedbacks are always found by the user clicking the button
+		feedbacks, err = fc.speakerFeedbacks(ctx, gr.ID, user.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if page >= len(feedbacks) {
+		page = len(feedbacks) - 1
+	}
+
+	if page < 0 {
+		page = 0
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// update the page
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{feedbackPageEmbed(s.State.User, gr, feedbacks, page, l)},
+			Components: []discordgo.MessageComponent{feedbackPageButtons(gr.ID, revieweeID, page, len(feedbacks), l)},
+		},
+	})
+}
+
+// show number of feedbacks and average scores per speaker to managers, or feedbacks of the speaker if given
+func (fc *feedbackCommand) showFeedbackSummary(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return study.ErrManagerNotFound
+	}
+
+	var speaker *discordgo.User
+
+	for _, o := range i.ApplicationCommandData().Options {
+		if o.Name == "발표자" {
+			speaker = o.UserValue(s)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	gs, err := fc.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	// check manager
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
+		return study.ErrNotManager
+	}
+
+	gr, err := fc.findRound(ctx, gs, roundNumberOption(i))
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// show feedbacks of the speaker page by page
+	if speaker != nil {
+		feedbacks, err := fc.managerFeedbacks(ctx, i, gr.ID, speaker.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags:      discordgo.MessageFlagsEphemeral,
+				Embeds:     []*discordgo.MessageEmbed{feedbackPageEmbed(s.State.User, gr, feedbacks, 0, l)},
+				Components: []discordgo.MessageComponent{feedbackPageButtons(gr.ID, speaker.ID, 0, len(feedbacks), l)},
+			},
+		})
+	}
+
+	feedbacks, err := fc.svc.GetFeedbacks(ctx, gr.ID, "")
+	if err != nil {
+		return err
+	}
+
+	if len(feedbacks) == 0 {
+		return study.ErrFeedbackNotFound
+	}
+
+	// send response
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:  discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{feedbackSummaryEmbed(s.State.User, gr, study.SummarizeFeedbacks(feedbacks), l)},
+		},
+	})
+}
+
+// feedbacks the speaker can read, feedbacks only for managers are excluded
+func (fc *feedbackCommand) speakerFeedbacks(ctx context.Context, roundID, speakerID string) ([]*study.Feedback, error) {
+	feedbacks, err := fc.svc.GetFeedbacks(ctx, roundID, speakerID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*study.Feedback, 0, len(feedbacks))
+	for _, f := range feedbacks {
+		if f.GetMode().VisibleToSpeaker() {
+			visible = append(visible, f)
+		}
+	}
+
+	if len(visible) == 0 {
+		return nil, study.ErrFeedbackNotFound
+	}
+
+	return visible, nil
+}
+
+// feedbacks the speaker received, only managers can read them
+func (fc *feedbackCommand) managerFeedbacks(ctx context.Context, i *discordgo.InteractionCreate, roundID, speakerID string) ([]*study.Feedback, error) {
+	manager := utils.GetGuildUserFromInteraction(i)
+	if manager == nil {
+		return nil, study.ErrManagerNotFound
+	}
+
+	gs, err := fc.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !gs.IsManager(manager.ID, utils.GetGuildMemberRolesFromInteraction(i)...) {
+		return nil, study.ErrNotManager
+	}
+
+	feedbacks, err := fc.svc.GetFeedbacks(ctx, roundID, speakerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(feedbacks) == 0 {
+		return nil, study.ErrFeedbackNotFound
+	}
+
+	return feedbacks, nil
+}
+
+// round number given by the option, zero if it is not given
+func roundNumberOption(i *discordgo.InteractionCreate) int {
+	for _, o := range i.ApplicationCommandData().Options {
+		if o.Name == "라운드" {
+			return int(o.IntValue())
+		}
+	}
+	return 0
+}
+
+// find the round of the number, the ongoing or the latest round is found if the number is zero
+func (fc *feedbackCommand) findRound(ctx context.Context, gs *study.Study, number int) (*study.Round, error) {
+	if number == 0 && gs.OngoingRoundID != "" {
+		return fc.svc.GetRound(ctx, gs.OngoingRoundID)
+	}
+
+	rounds, err := fc.svc.GetRounds(ctx, gs.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *study.Round
+
+	for _, r := range rounds {
+		switch {
+		case number > 0 && int(r.Number) == number:
+			return r, nil
+		case number == 0 && (found == nil || r.Number > found.Number):
+			found = r
+		}
+	}
+
+	if found == nil {
+		return nil, study.ErrRoundNotFound
+	}
+
+	return found, nil
+}
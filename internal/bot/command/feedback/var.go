@@ -1,9 +1,15 @@
 package feedback
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/bot/command"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
 )
 
 var (
@@ -19,27 +25,180 @@ var (
 			},
 		},
 	}
-	textInput = discordgo.TextInput{
+	receivedCmd = discordgo.ApplicationCommand{
+		Name:        "받은-피드백",
+		Description: "라운드에서 받은 피드백을 다시 확인합니다.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "라운드",
+				Description: "피드백을 확인할 라운드 번호입니다. 입력하지 않으면 진행중이거나 가장 최근 라운드를 확인합니다.",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				MinValue:    &minRoundNumber,
+			},
+		},
+	}
+	summaryCmd = discordgo.ApplicationCommand{
+		Name:        "피드백-집계",
+		Description: "발표자별 피드백 수와 평균 점수를 확인합니다. 매니저만 사용할 수 있습니다.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "라운드",
+				Description: "집계할 라운드 번호입니다. 입력하지 않으면 진행중이거나 가장 최근 라운드를 집계합니다.",
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				MinValue:    &minRoundNumber,
+			},
+			{
+				Name:        "발표자",
+				Description: "피드백 내용을 확인할 발표자를 선택해주세요. 익명 피드백의 작성자는 표시되지 않습니다.",
+				Type:        discordgo.ApplicationCommandOptionUser,
+			},
+		},
+	}
+
+	minRoundNumber float64 = 1
+
+	feedbackModalCustomID = "feedback-modal"
+	feedbackPageCustomID  = "feedback-page"
+
+	// target of the feedback modal is kept in the cache, not in the modal which reviewers can edit
+	feedbackTargetTTL = 15 * time.Minute
+)
+
+// target of the feedback modal
+type feedbackTarget struct {
+	GuildID    string `json:"guild_id"`
+	ReviewerID string `json:"reviewer_id"`
+	SpeakerID  string `json:"speaker_id"`
+}
+
+func feedbackTargetKey(token string) string {
+	return "feedback-target:" + token
+}
+
+func feedbackTextInput(l i18n.Localizer) discordgo.TextInput {
+	return discordgo.TextInput{
 		CustomID:    "feedback",
-		Label:       "피드백",
+		Label:       l.T("피드백"),
 		Style:       discordgo.TextInputParagraph,
-		Placeholder: "피드백을 입력해주세요.",
+		Placeholder: l.T("피드백을 입력해주세요."),
 		Required:    true,
 		MaxLength:   1000,
 		MinLength:   10,
 	}
+}
 
-	feedbackModalCustomID = "feedback-modal"
-)
+// optional score input of the rubric
+func scoreTextInput(customID, label string, l i18n.Localizer) discordgo.TextInput {
+	return discordgo.TextInput{
+		CustomID:    customID,
+		Label:       l.T("%s (%d-%d점, 선택)", label, study.MinScore, study.MaxScore),
+		Style:       discordgo.TextInputShort,
+		Placeholder: l.T("점수를 숫자로 입력해주세요."),
+		Required:    false,
+		MaxLength:   1,
+	}
+}
+
+// fields of scores of the rubric, nothing is shown if the feedback is not scored
+func rubricFields(r study.Rubric, l i18n.Localizer) []*discordgo.MessageEmbedField {
+	if r.IsZero() {
+		return nil
+	}
+
+	score := func(v int) string {
+		if v == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%d/%d", v, study.MaxScore)
+	}
+
+	return []*discordgo.MessageEmbedField{
+		{Name: l.T("명확성"), Value: score(r.Clarity), Inline: true},
+		{Name: l.T("깊이"), Value: score(r.Depth), Inline: true},
+		{Name: l.T("전달력"), Value: score(r.Delivery), Inline: true},
+	}
+}
+
+// feedback rendered by the mode it was written in, the reviewer is shown only if the mode reveals it
+func feedbackEmbed(u *discordgo.User, f *study.Feedback, l i18n.Localizer) *discordgo.MessageEmbed {
+	mode := f.GetMode()
+
+	var fields []*discordgo.MessageEmbedField
+
+	if mode.RevealsReviewer() {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  l.T("작성자"),
+			Value: fmt.Sprintf("<@%s>", f.ReviewerID),
+		})
+	}
+
+	return &discordgo.MessageEmbed{
+		Author: &discordgo.MessageEmbedAuthor{
+			Name:    l.FeedbackMode(mode),
+			IconURL: u.AvatarURL(""),
+		},
+		Title:       l.T("피드백"),
+		Description: f.Content,
+		Fields:      append(fields, rubricFields(f.Rubric, l)...),
+		Color:       0x00ff00,
+		Timestamp:   f.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// page of the feedbacks the speaker received
+func feedbackPageEmbed(u *discordgo.User, r *study.Round, feedbacks []*study.Feedback, page int, l i18n.Localizer) *discordgo.MessageEmbed {
+	embed := feedbackEmbed(u, feedbacks[page], l)
+	embed.Title = l.T("받은 피드백 %d/%d", page+1, len(feedbacks))
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: r.Title}
+	return embed
+}
+
+// buttons to move between pages of feedbacks, the round, the page and the speaker reviewed by managers are carried by custom ids
+func feedbackPageButtons(roundID, revieweeID string, page, total int, l i18n.Localizer) discordgo.ActionsRow {
+	customID := func(page int) string {
+		if revieweeID == "" {
+			return command.CustomID(feedbackPageCustomID, roundID, strconv.Itoa(page))
+		}
+		return command.CustomID(feedbackPageCustomID, roundID, strconv.Itoa(page), revieweeID)
+	}
+
+	return discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				CustomID: customID(page - 1),
+				Label:    l.T("이전"),
+				Style:    discordgo.SecondaryButton,
+				Disabled: page <= 0,
+			},
+			discordgo.Button{
+				CustomID: customID(page + 1),
+				Label:    l.T("다음"),
+				Style:    discordgo.SecondaryButton,
+				Disabled: page >= total-1,
+			},
+		},
+	}
+}
+
+// number of feedbacks and average scores per speaker
+func feedbackSummaryEmbed(u *discordgo.User, r *study.Round, summaries []study.RubricSummary, l i18n.Localizer) *discordgo.MessageEmbed {
+	lines := make([]string, 0, len(summaries))
+
+	for _, s := range summaries {
+		line := l.T("<@%s> · 피드백 %d개", s.RevieweeID, s.Count)
+		if s.Scored > 0 {
+			line += " · " + l.T("명확성 %.1f · 깊이 %.1f · 전달력 %.1f (%d개 채점)", s.Clarity, s.Depth, s.Delivery, s.Scored)
+		}
+		lines = append(lines, line)
+	}
 
-func feedbackEmbed(u *discordgo.User, content string) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
 		Author: &discordgo.MessageEmbedAuthor{
-			Name:    "익명",
+			Name:    u.Username,
 			IconURL: u.AvatarURL(""),
 		},
-		Title:       "피드백",
-		Description: content,
+		Title:       l.T("%s 피드백 집계", r.Title),
+		Description: strings.Join(lines, "\n"),
 		Color:       0x00ff00,
 		Timestamp:   time.Now().Format(time.RFC3339),
 	}
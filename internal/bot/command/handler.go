@@ -20,7 +20,10 @@ func NewHandler(funcs map[string]HandleFunc) Handler {
 	}
 }
 
+// handle the interaction by the name, arguments of custom id are read by the handler
 func (h *handler) Handle(name string, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	name, _ = ParseCustomID(name)
+
 	fn, ok := h.funcs[name]
 	if !ok {
 		return fmt.Errorf("command %s not found", name)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
 	"github.com/piatoss3612/my-study-bot/internal/study"
 )
 
@@ -17,24 +18,26 @@ func NewHelpCommand() command.Command {
 
 func (h *helpCommand) Register(reg command.Registerer) {
 	reg.RegisterCommand(cmd, h.help)
-	reg.RegisterHandler(selectMenu.CustomID, h.selectHelpMenu)
+	reg.RegisterHandler(selectMenuCustomID, h.selectHelpMenu)
 }
 
 // show help embed
 func (h *helpCommand) help(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	l := i18n.FromInteraction(i)
+
 	response := &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Flags:  discordgo.MessageFlagsEphemeral,
-			Embeds: []*discordgo.MessageEmbed{HelpIntroEmbed(s.State.User)},
+			Embeds: []*discordgo.MessageEmbed{HelpIntroEmbed(s.State.User, l)},
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
-						selectMenu,
+						helpSelectMenu(l),
 					},
 				},
 				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{button},
+					Components: []discordgo.MessageComponent{helpButton(l)},
 				},
 			},
 		},
@@ -44,20 +47,22 @@ func (h *helpCommand) help(s *discordgo.Session, i *discordgo.InteractionCreate)
 }
 
 func (h *helpCommand) selectHelpMenu(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	l := i18n.FromInteraction(i)
+
 	var embed *discordgo.MessageEmbed
 
 	data := i.MessageComponentData().Values
 	if len(data) == 0 {
-		return errors.Join(study.ErrRequiredArgs, errors.New("옵션을 찾을 수 없습니다"))
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("옵션을 찾을 수 없습니다"))
 	}
 
 	switch data[0] {
 	case "default":
-		embed = HelpDefaultEmbed(s.State.User)
+		embed = HelpDefaultEmbed(s.State.User, l)
 	case "study":
-		embed = HelpStudyEmbed(s.State.User)
+		embed = HelpStudyEmbed(s.State.User, l)
 	default:
-		return errors.Join(study.ErrRequiredArgs, errors.New("옵션을 찾을 수 없습니다"))
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("옵션을 찾을 수 없습니다"))
 	}
 
 	response := &discordgo.InteractionResponse{
@@ -67,7 +72,7 @@ func (h *helpCommand) selectHelpMenu(s *discordgo.Session, i *discordgo.Interact
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
-						selectMenu,
+						helpSelectMenu(l),
 					},
 				},
 			},
@@ -2,6 +2,7 @@ package help
 
 import (
 	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
 )
 
 var (
@@ -9,46 +10,54 @@ var (
 		Name:        "도움",
 		Description: "도움말을 확인합니다.",
 	}
-	selectMenu = discordgo.SelectMenu{
-		CustomID:    "help",
-		Placeholder: "도움말 옵션 💡",
+)
+
+const selectMenuCustomID = "help"
+
+func helpSelectMenu(l i18n.Localizer) discordgo.SelectMenu {
+	return discordgo.SelectMenu{
+		CustomID:    selectMenuCustomID,
+		Placeholder: l.T("도움말 옵션 💡"),
 		Options: []discordgo.SelectMenuOption{
 			{
-				Label: "기본",
+				Label: l.T("기본"),
 				Value: "default",
 				Emoji: discordgo.ComponentEmoji{
 					Name: "❔",
 				},
-				Description: "기본 명령어 도움말",
+				Description: l.T("기본 명령어 도움말"),
 			},
 			{
-				Label: "스터디",
+				Label: l.T("스터디"),
 				Value: "study",
 				Emoji: discordgo.ComponentEmoji{
 					Name: "📚",
 				},
-				Description: "스터디 명령어 도움말",
+				Description: l.T("스터디 명령어 도움말"),
 			},
 		},
 	}
-	button = discordgo.Button{
+}
+
+func helpButton(l i18n.Localizer) discordgo.Button {
+	return discordgo.Button{
 		Emoji: discordgo.ComponentEmoji{
 			Name: "🔥",
 		},
-		Label: "큰 결심 하기",
+		Label: l.T("큰 결심 하기"),
 		Style: discordgo.LinkButton,
 		URL:   "https://github.com/piatoss3612",
 	}
-)
+}
 
-func HelpIntroEmbed(u *discordgo.User) *discordgo.MessageEmbed {
+func HelpIntroEmbed(u *discordgo.User, l i18n.Localizer) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
 		Author: &discordgo.MessageEmbedAuthor{
 			Name:    u.Username,
 			IconURL: u.AvatarURL(""),
 		},
-		Title:       "도움말",
-		Description: "아래의 도움말 옵션을 선택해주세요!",
+		Title:       l.T("도움말"),
+		Description: l.T("아래의 도움말 옵션을 선택해주세요!"),
 		Thumbnail: &discordgo.MessageEmbedThumbnail{
 			URL: u.AvatarURL(""),
 		},
@@ -56,67 +65,75 @@ func HelpIntroEmbed(u *discordgo.User) *discordgo.MessageEmbed {
 	}
 }
 
-func HelpDefaultEmbed(u *discordgo.User) *discordgo.MessageEmbed {
+func HelpDefaultEmbed(u *discordgo.User, l i18n.Localizer) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
 		Author: &discordgo.MessageEmbedAuthor{
 			Name:    u.Username,
 			IconURL: u.AvatarURL(""),
 		},
-		Title:       "❔ 기본 명령어",
-		Description: "> 명령어 사용 예시: /[명령어]",
+		Title:       l.T("❔ 기본 명령어"),
+		Description: l.T("> 명령어 사용 예시: /[명령어]"),
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:  "도움말",
-				Value: "명령어 도움말 확인",
+				Name:  l.T("도움말"),
+				Value: l.T("명령어 도움말 확인"),
 			},
 			{
-				Name:  "프로필",
-				Value: "발표 진스의 프로필 확인",
+				Name:  l.T("프로필"),
+				Value: l.T("발표 진스의 프로필 확인"),
 			},
 		},
 	}
 }
 
-func HelpStudyEmbed(u *discordgo.User) *discordgo.MessageEmbed {
+func HelpStudyEmbed(u *discordgo.User, l i18n.Localizer) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
 		Author: &discordgo.MessageEmbedAuthor{
 			Name:    u.Username,
 			IconURL: u.AvatarURL(""),
 		},
-		Title:       "📚 스터디 명령어",
-		Description: "> 명령어 사용 예시: /[명령어]",
+		Title:       l.T("📚 스터디 명령어"),
+		Description: l.T("> 명령어 사용 예시: /[명령어]"),
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:  "내-정보",
-				Value: "내 스터디 등록 정보 확인",
+				Name:  l.T("내-정보"),
+				Value: l.T("내 스터디 등록 정보 확인"),
+			},
+			{
+				Name:  l.T("스터디-정보"),
+				Value: l.T("진행중인 스터디 정보 확인"),
+			},
+			{
+				Name:  l.T("라운드-정보"),
+				Value: l.T("진행중인 라운드 정보 확인"),
 			},
 			{
-				Name:  "스터디-정보",
-				Value: "진행중인 스터디 정보 확인",
+				Name:  l.T("발표자-등록"),
+				Value: l.T("발표자로 등록"),
 			},
 			{
-				Name:  "라운드-정보",
-				Value: "진행중인 라운드 정보 확인",
+				Name:  l.T("발표-취소"),
+				Value: l.T("발표자 등록 취소"),
 			},
 			{
-				Name:  "발표자-등록",
-				Value: "발표자로 등록",
+				Name:  l.T("발표-자료-제출"),
+				Value: l.T("발표 자료 제출"),
 			},
 			{
-				Name:  "발표자-등록-취소",
-				Value: "발표자 등록 취소",
+				Name:  l.T("피드백"),
+				Value: l.T("발표자에게 피드백 전송"),
 			},
 			{
-				Name:  "발표-자료-제출",
-				Value: "발표 자료 제출",
+				Name:  l.T("받은-피드백"),
+				Value: l.T("받은 피드백 다시 확인"),
 			},
 			{
-				Name:  "피드백",
-				Value: "발표자에게 피드백 전송",
+				Name:  l.T("발표회고"),
+				Value: l.T("발표회고 작성"),
 			},
 			{
-				Name:  "발표회고",
-				Value: "발표회고 작성",
+				Name:  l.T("리마인더"),
+				Value: l.T("리마인더 DM 받기 설정"),
 			},
 		},
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command"
 	"github.com/piatoss3612/my-study-bot/internal/cache"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
 	"github.com/piatoss3612/my-study-bot/internal/study"
 	"github.com/piatoss3612/my-study-bot/internal/study/service"
 	"github.com/piatoss3612/my-study-bot/internal/utils"
@@ -29,7 +30,7 @@ func (ic *infoCommand) Register(reg command.Registerer) {
 	reg.RegisterCommand(myStudyInfoCmd, ic.showMyStudyInfo)
 	reg.RegisterCommand(studyInfoCmd, ic.showStudyInfo)
 	reg.RegisterCommand(studyRoundInfoCmd, ic.showRoundInfo)
-	reg.RegisterHandler(speakerInfoSelectMenu.CustomID, ic.speakerInfoSelectMenuHandler)
+	reg.RegisterHandler(speakerInfoSelectMenuCustomID, ic.speakerInfoSelectMenuHandler)
 }
 
 // show the user's study info
@@ -76,7 +77,7 @@ func (ic *infoCommand) showMyStudyInfo(s *discordgo.Session, i *discordgo.Intera
 			Content: user.Mention(),
 			Flags:   discordgo.MessageFlagsEphemeral,
 			Embeds: []*discordgo.MessageEmbed{
-				speakerInfoEmbed(user, member),
+				speakerInfoEmbed(user, member, round.WaitlistPosition(user.ID), i18n.FromInteraction(i)),
 			},
 		},
 	})
@@ -104,7 +105,7 @@ func (ic *infoCommand) showStudyInfo(s *discordgo.Session, i *discordgo.Interact
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Flags:  discordgo.MessageFlagsEphemeral,
-			Embeds: []*discordgo.MessageEmbed{studyInfoEmbed(s.State.User, gs)},
+			Embeds: []*discordgo.MessageEmbed{studyInfoEmbed(s.State.User, gs, i18n.FromInteraction(i))},
 		},
 	})
 }
@@ -120,9 +121,13 @@ func (ic *infoCommand) showRoundInfo(s *discordgo.Session, i *discordgo.Interact
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	var gs *study.Study
+	// study is always read to render times in its time zone
+	gs, err := ic.svc.GetStudy(ctx, i.GuildID)
+	if err != nil {
+		return err
+	}
+
 	var round *study.Round
-	var err error
 
 	exists := ic.roundExists(ctx, i.GuildID)
 
@@ -131,11 +136,6 @@ func (ic *infoCommand) showRoundInfo(s *discordgo.Session, i *discordgo.Interact
 		// get round from cache
 		round, err = ic.getRound(ctx, i.GuildID)
 	} else {
-		gs, err = ic.svc.GetStudy(ctx, i.GuildID)
-		if err != nil {
-			return err
-		}
-
 		if gs.OngoingRoundID == "" {
 			return study.ErrRoundNotFound
 		}
@@ -147,8 +147,19 @@ func (ic *infoCommand) showRoundInfo(s *discordgo.Session, i *discordgo.Interact
 		return err
 	}
 
+	// timeline is not cached to show the latest changes
+	timeline, err := ic.svc.GetRoundTimeline(ctx, round.ID)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	// times are rendered in the time zone of the study and the locale of the user
+	c := study.NewClock(gs.TimeZone, l.Locale())
+
 	// round info embed
-	embed := studyRoundInfoEmbed(s.State.User, round)
+	embed := studyRoundInfoEmbed(s.State.User, round, timeline, c, l)
 
 	// if round does not exist in cache, set round to cache
 	if !exists {
@@ -166,7 +177,7 @@ func (ic *infoCommand) showRoundInfo(s *discordgo.Session, i *discordgo.Interact
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
-						speakerInfoSelectMenu,
+						speakerInfoSelectMenu(l),
 					},
 				},
 			},
@@ -184,7 +195,7 @@ func (ic *infoCommand) speakerInfoSelectMenuHandler(s *discordgo.Session, i *dis
 	// get input data
 	data := i.MessageComponentData().Values
 	if len(data) == 0 {
-		return errors.Join(study.ErrRequiredArgs, errors.New("옵션을 찾을 수 없습니다"))
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("옵션을 찾을 수 없습니다"))
 	}
 
 	selectedUserID := data[0]
@@ -215,18 +226,20 @@ func (ic *infoCommand) speakerInfoSelectMenuHandler(s *discordgo.Session, i *dis
 		return err
 	}
 
+	l := i18n.FromInteraction(i)
+
 	var embed *discordgo.MessageEmbed
 
 	member, ok := round.GetMember(selectedUserID)
 	if !ok {
-		embed = errorEmbed("발표자 정보를 찾을 수 없습니다")
+		embed = errorEmbed(l.T("발표자 정보를 찾을 수 없습니다"), l)
 	} else {
 		selectedUser, err := s.User(selectedUserID)
 		if err != nil {
 			return err
 		}
 
-		embed = speakerInfoEmbed(selectedUser, member)
+		embed = speakerInfoEmbed(selectedUser, member, round.WaitlistPosition(selectedUserID), l)
 	}
 
 	// if round does not exist in cache, set round to cache
@@ -245,7 +258,7 @@ func (ic *infoCommand) speakerInfoSelectMenuHandler(s *discordgo.Session, i *dis
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
-						speakerInfoSelectMenu,
+						speakerInfoSelectMenu(l),
 					},
 				},
 			},
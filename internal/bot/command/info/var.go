@@ -2,9 +2,11 @@ package info
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
 	"github.com/piatoss3612/my-study-bot/internal/study"
 )
 
@@ -21,46 +23,114 @@ var (
 		Name:        "라운드-정보",
 		Description: "진행중인 스터디 라운드 정보를 확인합니다.",
 	}
-	speakerInfoSelectMenu = discordgo.SelectMenu{
-		CustomID:    "speaker-info",
-		Placeholder: "발표자 등록 정보 검색 🔍",
+)
+
+const speakerInfoSelectMenuCustomID = "speaker-info"
+
+func speakerInfoSelectMenu(l i18n.Localizer) discordgo.SelectMenu {
+	return discordgo.SelectMenu{
+		CustomID:    speakerInfoSelectMenuCustomID,
+		Placeholder: l.T("발표자 등록 정보 검색 🔍"),
 		MenuType:    discordgo.UserSelectMenu,
 	}
+}
+
+const (
+	maxTimelineLines    = 15
+	maxEmbedFieldLength = 1024
 )
 
-func studyInfoEmbed(u *discordgo.User, s *study.Study) *discordgo.MessageEmbed {
+func studyInfoEmbed(u *discordgo.User, s *study.Study, l i18n.Localizer) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
 		Author: &discordgo.MessageEmbedAuthor{
 			Name:    u.Username,
 			IconURL: u.AvatarURL(""),
 		},
-		Title:     "스터디 정보",
+		Title:     l.T("스터디 정보"),
 		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: u.AvatarURL("")},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "관리자",
+				Name:   l.T("관리자"),
 				Value:  fmt.Sprintf("```%s```", s.ManagerID),
 				Inline: true,
 			},
 			{
-				Name:  "생성일",
-				Value: fmt.Sprintf("```%s```", s.CreatedAt.Format(time.RFC3339)),
+				Name: l.T("공동 관리자"),
+				Value: func() string {
+					ids := s.GetManagerIDs()
+					if len(ids) == 0 {
+						return fmt.Sprintf("```%s```", l.T("없음"))
+					}
+					return fmt.Sprintf("```%s```", strings.Join(ids, ", "))
+				}(),
+				Inline: true,
+			},
+			{
+				Name:  l.T("생성일"),
+				Value: fmt.Sprintf("```%s```", study.NewClock(s.TimeZone, l.Locale()).FormatDateTime(s.CreatedAt)),
+			},
+			{
+				Name:   l.T("시간대"),
+				Value:  fmt.Sprintf("```%s```", s.Clock().Location()),
+				Inline: true,
+			},
+			{
+				Name:   l.T("언어"),
+				Value:  fmt.Sprintf("```%s```", s.Clock().Locale()),
+				Inline: true,
 			},
 			{
-				Name:   "총 라운드 수",
+				Name:   l.T("총 라운드 수"),
 				Value:  fmt.Sprintf("```%d```", s.TotalRound),
 				Inline: true,
 			},
 			{
-				Name:   "진행 단계",
-				Value:  fmt.Sprintf("```%s```", s.CurrentStage),
+				Name:   l.T("진행 단계"),
+				Value:  fmt.Sprintf("```%s```", l.Stage(s.CurrentStage)),
+				Inline: true,
+			},
+			{
+				Name:   l.T("피드백 방식"),
+				Value:  fmt.Sprintf("```%s```", l.FeedbackMode(s.GetFeedbackMode())),
+				Inline: true,
+			},
+			{
+				Name: l.T("리뷰어 배정"),
+				Value: func() string {
+					if !s.AssignsReviewers() {
+						return l.T("미사용")
+					}
+					if s.AssignedReviewsOnly {
+						return l.T("리뷰어마다 발표자 %d명 (배정된 발표자만 피드백)", s.ReviewsPerMember)
+					}
+					return l.T("리뷰어마다 발표자 %d명", s.ReviewsPerMember)
+				}(),
+				Inline: true,
+			},
+			{
+				Name: l.T("리마인더"),
+				Value: func() string {
+					if len(s.Reminders) == 0 {
+						return l.T("미사용")
+					}
+					return l.T("%d개", len(s.Reminders))
+				}(),
 				Inline: true,
 			},
 			{
-				Name: "이전 라운드 조회",
+				Name: l.T("음성 출석"),
+				Value: func() string {
+					if !s.TracksVoice() {
+						return l.T("미등록")
+					}
+					return l.T("<#%s> 채널에 발표 시간의 %d%% 이상 참여", s.VoiceChannelID, s.VoiceAttendanceShare())
+				}(),
+			},
+			{
+				Name: l.T("이전 라운드 조회"),
 				Value: fmt.Sprintf("```%s```", func() string {
 					if s.SpreadsheetURL == "" {
-						return "미등록"
+						return l.T("미등록")
 					}
 					return s.SpreadsheetURL
 				}()),
@@ -70,74 +140,130 @@ func studyInfoEmbed(u *discordgo.User, s *study.Study) *discordgo.MessageEmbed {
 	}
 }
 
-func studyRoundInfoEmbed(u *discordgo.User, r *study.Round) *discordgo.MessageEmbed {
+func studyRoundInfoEmbed(u *discordgo.User, r *study.Round, timeline []*study.RoundEvent, c study.Clock, l i18n.Localizer) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
 		Author: &discordgo.MessageEmbedAuthor{
 			Name:    u.Username,
 			IconURL: u.AvatarURL(""),
 		},
-		Title:     "현재 진행중인 스터디 라운드 정보",
+		Title:     l.T("현재 진행중인 스터디 라운드 정보"),
 		Thumbnail: &discordgo.MessageEmbedThumbnail{URL: u.AvatarURL("")},
 		Fields: []*discordgo.MessageEmbedField{
 
 			{
-				Name:   "번호",
+				Name:   l.T("번호"),
 				Value:  fmt.Sprintf("```%d```", r.Number),
 				Inline: true,
 			},
 			{
-				Name:   "제목",
+				Name:   l.T("제목"),
 				Value:  fmt.Sprintf("```%s```", r.Title),
 				Inline: true,
 			},
 			{
-				Name:   "진행 단계",
-				Value:  fmt.Sprintf("```%s```", r.Stage.String()),
+				Name: l.T("진행 단계"),
+				Value: fmt.Sprintf("```%s```", func() string {
+					if r.IsPaused() {
+						return l.T("%s (일시 중지)", l.Stage(r.Stage))
+					}
+					return l.Stage(r.Stage)
+				}()),
+				Inline: true,
+			},
+			{
+				Name: l.T("발표자 정원"),
+				Value: fmt.Sprintf("```%s```", func() string {
+					if !r.HasSpeakerCap() {
+						return l.T("제한 없음")
+					}
+					return l.T("%d/%d명 (대기 %d명)", r.RegisteredCount(), r.SpeakerCap, len(r.Waitlist))
+				}()),
 				Inline: true,
 			},
 			{
-				Name: "발표 결과 자료",
+				Name: l.T("진행 단계 마감"),
+				Value: fmt.Sprintf("```%s```", func() string {
+					deadline, ok := r.GetDeadline(r.Stage)
+					if !ok {
+						return l.T("미설정")
+					}
+					return c.FormatDateTime(deadline)
+				}()),
+			},
+			{
+				Name: l.T("발표 결과 자료"),
 				Value: fmt.Sprintf("```%s```", func() string {
 					if r.ContentURL == "" {
-						return "미등록"
+						return l.T("미등록")
 					}
 					return r.ContentURL
 				}()),
 			},
+			{
+				Name:  l.T("진행 기록"),
+				Value: timelineValue(timeline, c, l),
+			},
 		},
 		Timestamp: time.Now().Format(time.RFC3339),
 	}
 }
 
-func speakerInfoEmbed(u *discordgo.User, m study.Member) *discordgo.MessageEmbed {
+// recent events of the round which fit in an embed field
+func timelineValue(timeline []*study.RoundEvent, c study.Clock, l i18n.Localizer) string {
+	if len(timeline) == 0 {
+		return l.T("기록 없음")
+	}
+
+	lines := []string{}
+	length := 0
+
+	for i := len(timeline) - 1; i >= 0 && len(lines) < maxTimelineLines; i-- {
+		line := fmt.Sprintf("`%s` %s", c.FormatShort(timeline[i].CreatedAt), l.RoundEvent(*timeline[i], c))
+
+		if length+len(line)+1 > maxEmbedFieldLength {
+			break
+		}
+
+		lines = append([]string{line}, lines...)
+		length += len(line) + 1
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// waitlist is the position of the member in the waitlist, 0 if the member is not waiting
+func speakerInfoEmbed(u *discordgo.User, m study.Member, waitlist int, l i18n.Localizer) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
-		Title: fmt.Sprintf("%s님의 발표 정보", u.Username),
+		Title: l.T("%s님의 발표 정보", u.Username),
 		Thumbnail: &discordgo.MessageEmbedThumbnail{
 			URL: u.AvatarURL(""),
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name: "이름",
+				Name: l.T("이름"),
 				Value: func() string {
 					if m.Name == "" {
-						return "```미등록```"
+						return fmt.Sprintf("```%s```", l.T("미등록"))
 					}
 					return fmt.Sprintf("```%s```", m.Name)
 				}(),
 				Inline: true,
 			},
 			{
-				Name: "발표자 등록",
+				Name: l.T("발표자 등록"),
 				Value: func() string {
 					if m.Registered {
 						return "```O```"
 					}
+					if waitlist > 0 {
+						return fmt.Sprintf("```%s```", l.T("대기 %d번", waitlist))
+					}
 					return "```X```"
 				}(),
 				Inline: true,
 			},
 			{
-				Name: "발표 참여",
+				Name: l.T("발표 참여"),
 				Value: func() string {
 					if m.Attended {
 						return "```O```"
@@ -147,19 +273,19 @@ func speakerInfoEmbed(u *discordgo.User, m study.Member) *discordgo.MessageEmbed
 				Inline: true,
 			},
 			{
-				Name: "발표 주제",
+				Name: l.T("발표 주제"),
 				Value: func() string {
 					if m.Subject == "" {
-						return "```미등록```"
+						return fmt.Sprintf("```%s```", l.T("미등록"))
 					}
 					return fmt.Sprintf("```%s```", m.Subject)
 				}(),
 			},
 			{
-				Name: "발표 자료",
+				Name: l.T("발표 자료"),
 				Value: func() string {
 					if m.ContentURL == "" {
-						return "```미등록```"
+						return fmt.Sprintf("```%s```", l.T("미등록"))
 					}
 					return fmt.Sprintf("```%s```", m.ContentURL)
 				}(),
@@ -170,9 +296,9 @@ func speakerInfoEmbed(u *discordgo.User, m study.Member) *discordgo.MessageEmbed
 	}
 }
 
-func errorEmbed(msg string) *discordgo.MessageEmbed {
+func errorEmbed(msg string, l i18n.Localizer) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
-		Title:       "오류",
+		Title:       l.T("오류"),
 		Description: msg,
 		Color:       0xff0000,
 	}
@@ -0,0 +1,43 @@
+package command
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+)
+
+// NotifyPromoted sends DMs to members promoted from the waitlist in the locale of the study,
+// so that members get the same message whichever command promoted them
+func NotifyPromoted(s *discordgo.Session, gs *study.Study, gr *study.Round, memberIDs []string) error {
+	l := i18n.New(gs.Clock().Locale())
+
+	embed := &discordgo.MessageEmbed{
+		Author: &discordgo.MessageEmbedAuthor{
+			Name:    s.State.User.Username,
+			IconURL: s.State.User.AvatarURL(""),
+		},
+		Title:       l.T("발표자 등록 완료"),
+		Description: l.T("자리가 나서 **%s** 라운드 발표자로 등록되었습니다.", gr.Title),
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Color:       16777215,
+	}
+
+	var errs []error
+
+	for _, id := range memberIDs {
+		ch, err := s.UserChannelCreate(id)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if _, err := s.ChannelMessageSendEmbed(ch.ID, embed); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
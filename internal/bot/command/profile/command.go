@@ -1,20 +1,26 @@
 package profile
 
 import (
+	"context"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
 	"github.com/piatoss3612/my-study-bot/internal/utils"
 	"go.uber.org/zap"
 )
 
 type profileCommand struct {
+	svc       service.Service
 	startedAt time.Time
 }
 
-func NewProfileCommand(sugar *zap.SugaredLogger) command.Command {
+func NewProfileCommand(svc service.Service, sugar *zap.SugaredLogger) command.Command {
 	return &profileCommand{
+		svc:       svc,
 		startedAt: time.Now(),
 	}
 }
@@ -25,10 +31,15 @@ func (p *profileCommand) Register(reg command.Registerer) {
 
 // show the profile of the bot
 func (p *profileCommand) showBotProfile(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	l := i18n.FromInteraction(i)
+
+	// times are formatted in the time zone of the study and the locale of the user
+	c := study.NewClock(p.clock(i.GuildID).Location().String(), l.Locale())
+
 	u := s.State.User
-	createdAt, _ := utils.FormatSnowflakeToTime(u.ID)
-	rebootedAt := utils.FormatRebootDate(p.startedAt)
-	uptime := utils.FormatUptime(p.startedAt)
+	createdAt, _ := utils.SnowflakeToTime(u.ID)
+	rebootedAt := c.FormatShort(p.startedAt)
+	uptime := l.Duration(time.Since(p.startedAt))
 
 	// show the profile
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -36,9 +47,26 @@ func (p *profileCommand) showBotProfile(s *discordgo.Session, i *discordgo.Inter
 		Data: &discordgo.InteractionResponseData{
 			Content: u.Mention(),
 			Embeds: []*discordgo.MessageEmbed{
-				ProfileEmbed(u, "발표 진스의 프로필", createdAt, rebootedAt, uptime),
+				ProfileEmbed(u, l.T("발표 진스의 프로필"), c.FormatDate(createdAt), rebootedAt, uptime, l),
 			},
 			Flags: discordgo.MessageFlagsEphemeral,
 		},
 	})
 }
+
+// clock of the study in the guild, default clock is used outside of guild or without study
+func (p *profileCommand) clock(guildID string) study.Clock {
+	if guildID == "" {
+		return study.NewClock("", study.DefaultLocale)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	gs, err := p.svc.GetStudy(ctx, guildID)
+	if err != nil || gs == nil {
+		return study.NewClock("", study.DefaultLocale)
+	}
+
+	return gs.Clock()
+}
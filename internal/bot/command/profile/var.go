@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
 )
 
 var cmd = discordgo.ApplicationCommand{
@@ -12,7 +13,7 @@ var cmd = discordgo.ApplicationCommand{
 	Description: "발표 진스의 프로필을 보여줍니다.",
 }
 
-func ProfileEmbed(u *discordgo.User, title, createdAt, rebootedAt, uptime string) *discordgo.MessageEmbed {
+func ProfileEmbed(u *discordgo.User, title, createdAt, rebootedAt, uptime string, l i18n.Localizer) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
 		Author: &discordgo.MessageEmbedAuthor{
 			Name:    u.Username,
@@ -21,32 +22,32 @@ func ProfileEmbed(u *discordgo.User, title, createdAt, rebootedAt, uptime string
 		Title: title,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "이름",
+				Name:   l.T("이름"),
 				Value:  fmt.Sprintf("```%s```", u.Username),
 				Inline: true,
 			},
 			{
-				Name:   "생성일",
+				Name:   l.T("생성일"),
 				Value:  fmt.Sprintf("```%s```", createdAt),
 				Inline: true,
 			},
 			{
-				Name:   "재부팅",
+				Name:   l.T("재부팅"),
 				Value:  fmt.Sprintf("```%s```", rebootedAt),
 				Inline: true,
 			},
 			{
-				Name:   "업타임",
+				Name:   l.T("업타임"),
 				Value:  fmt.Sprintf("```%s```", uptime),
 				Inline: true,
 			},
 			{
-				Name:   "💻 개발자",
+				Name:   l.T("💻 개발자"),
 				Value:  fmt.Sprintf("```%s```", "piatoss3612"),
 				Inline: true,
 			},
 			{
-				Name:  "📝 소스코드",
+				Name:  l.T("📝 소스코드"),
 				Value: fmt.Sprintf("```%s```", "https://github.com/piatoss3612/my-study-bot"),
 			},
 		},
@@ -7,6 +7,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
 	"github.com/piatoss3612/my-study-bot/internal/study"
 	"github.com/piatoss3612/my-study-bot/internal/study/service"
 	"github.com/piatoss3612/my-study-bot/internal/utils"
@@ -37,7 +38,7 @@ func (rc *reflectionCommand) sendReflection(s *discordgo.Session, i *discordgo.I
 
 	// content should not be empty
 	if content == "" {
-		return errors.Join(study.ErrRequiredArgs, errors.New("회고 내용은 필수입니다"))
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("회고 내용은 필수입니다"))
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -57,7 +58,8 @@ func (rc *reflectionCommand) sendReflection(s *discordgo.Session, i *discordgo.I
 		return study.ErrChannelNotFound
 	}
 
-	embed := reflectionEmbed(user, content)
+	// reflection is posted in the locale of the study
+	embed := reflectionEmbed(user, content, i18n.New(gs.Clock().Locale()))
 
 	// send reflection
 	_, err = s.ChannelMessageSendEmbed(gs.ReflectionChannelID, embed)
@@ -69,7 +71,7 @@ func (rc *reflectionCommand) sendReflection(s *discordgo.Session, i *discordgo.I
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "회고가 성공적으로 전송되었습니다.",
+			Content: i18n.FromInteraction(i).T("회고가 성공적으로 전송되었습니다."),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	})
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
 )
 
 var cmd = discordgo.ApplicationCommand{
@@ -19,16 +20,16 @@ var cmd = discordgo.ApplicationCommand{
 	},
 }
 
-func reflectionEmbed(u *discordgo.User, content string) *discordgo.MessageEmbed {
+func reflectionEmbed(u *discordgo.User, content string, l i18n.Localizer) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
 		Author: &discordgo.MessageEmbedAuthor{
 			Name:    u.Username,
 			IconURL: u.AvatarURL(""),
 		},
-		Title: "발표회고",
+		Title: l.T("발표회고"),
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:  "내용",
+				Name:  l.T("내용"),
 				Value: content,
 			},
 		},
@@ -7,24 +7,29 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
 	"github.com/piatoss3612/my-study-bot/internal/study"
 	"github.com/piatoss3612/my-study-bot/internal/study/service"
 	"github.com/piatoss3612/my-study-bot/internal/utils"
+	"go.uber.org/zap"
 )
 
 type registrationCmd struct {
-	svc service.Service
+	svc   service.Service
+	sugar *zap.SugaredLogger
 }
 
-func NewRegistrationCommand(svc service.Service) command.Command {
+func NewRegistrationCommand(svc service.Service, sugar *zap.SugaredLogger) command.Command {
 	return &registrationCmd{
-		svc: svc,
+		svc:   svc,
+		sugar: sugar,
 	}
 }
 
 func (rc *registrationCmd) Register(reg command.Registerer) {
 	reg.RegisterCommand(registerCmd, rc.register)
 	reg.RegisterCommand(changeCmd, rc.showChangeModal)
+	reg.RegisterCommand(withdrawCmd, rc.withdraw)
 	reg.RegisterHandler(changeModalCustomID, rc.submitChangeModal)
 }
 
@@ -47,14 +52,14 @@ func (rc *registrationCmd) register(s *discordgo.Session, i *discordgo.Interacti
 	}
 
 	if name == "" || subject == "" {
-		return errors.Join(study.ErrRequiredArgs, errors.New("이름과 발표 주제는 필수 입력 사항입니다"))
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("이름과 발표 주제는 필수 입력 사항입니다"))
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// register as speaker
-	_, _, err := rc.svc.UpdateRound(ctx, &service.UpdateParams{
+	_, gr, err := rc.svc.UpdateRound(ctx, &service.UpdateParams{
 		GuildID:    i.GuildID,
 		MemberID:   user.ID,
 		MemberName: name,
@@ -65,15 +70,23 @@ func (rc *registrationCmd) register(s *discordgo.Session, i *discordgo.Interacti
 		return err
 	}
 
+	l := i18n.FromInteraction(i)
+
+	embed := registrationEmbed(s.State.User, l.T("등록 완료"), l.T("발표자 등록이 완료되었습니다."))
+
+	// round is full, the member waits for a seat
+	if pos := gr.WaitlistPosition(user.ID); pos > 0 {
+		embed = registrationEmbed(s.State.User, l.T("대기열 등록"),
+			l.T("발표자 정원(%d명)이 가득 차 대기열 %d번에 등록되었습니다. 자리가 나면 DM으로 알려드립니다.", gr.SpeakerCap, pos))
+	}
+
 	// send response
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Content: user.Mention(),
 			Flags:   discordgo.MessageFlagsEphemeral,
-			Embeds: []*discordgo.MessageEmbed{
-				registrationEmbed(s.State.User, "등록 완료", "발표자 등록이 완료되었습니다."),
-			},
+			Embeds:  []*discordgo.MessageEmbed{embed},
 		},
 	})
 }
@@ -98,44 +111,46 @@ func (rc *registrationCmd) showChangeModal(s *discordgo.Session, i *discordgo.In
 		return study.ErrRoundNotFound
 	}
 
-	if !gs.CurrentStage.IsRegistrationOpened() {
-		return errors.Join(study.ErrInvalidStage, errors.New("발표자 등록 정보 변경이 가능한 단계가 아닙니다"))
-	}
-
 	// get round
 	gr, err := rc.svc.GetRound(ctx, gs.OngoingRoundID)
 	if err != nil {
 		return err
 	}
 
+	if !gr.GetPipeline().Allows(gs.CurrentStage, study.ActionRegister) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("발표자 등록 정보 변경이 가능한 단계가 아닙니다"))
+	}
+
 	// get member
 	member, ok := gr.GetMember(user.ID)
 	if !ok {
 		return study.ErrMemberNotFound
 	}
 
-	if !member.IsRegistered() {
+	if !member.IsRegistered() && !gr.IsWaitlisted(user.ID) {
 		return study.ErrMemberNotRegistered
 	}
 
 	name := member.Name
 	subject := member.Subject
 
+	l := i18n.FromInteraction(i)
+
 	// show modal
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseModal,
 		Data: &discordgo.InteractionResponseData{
 			CustomID: changeModalCustomID,
-			Title:    "피드백 작성",
+			Title:    l.T("발표자 등록 정보 변경"),
 			Flags:    discordgo.MessageFlagsEphemeral,
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
 						discordgo.TextInput{
 							CustomID:    "speaker-name",
-							Label:       "발표자 이름",
+							Label:       l.T("발표자 이름"),
 							Style:       discordgo.TextInputShort,
-							Placeholder: "변경할 발표자 이름을 입력해 주세요.",
+							Placeholder: l.T("변경할 발표자 이름을 입력해 주세요."),
 							Value:       name,
 							Required:    true,
 							MaxLength:   20,
@@ -147,9 +162,9 @@ func (rc *registrationCmd) showChangeModal(s *discordgo.Session, i *discordgo.In
 					Components: []discordgo.MessageComponent{
 						discordgo.TextInput{
 							CustomID:    "speaker-subject",
-							Label:       "발표 주제",
+							Label:       l.T("발표 주제"),
 							Style:       discordgo.TextInputShort,
-							Placeholder: "변경할 발표 주제를 입력해 주세요.",
+							Placeholder: l.T("변경할 발표 주제를 입력해 주세요."),
 							Value:       subject,
 							Required:    true,
 							MaxLength:   100,
@@ -195,7 +210,7 @@ func (rc *registrationCmd) submitChangeModal(s *discordgo.Session, i *discordgo.
 	}
 
 	if name == "" || subject == "" {
-		return errors.Join(study.ErrRequiredArgs, errors.New("이름과 발표 주제는 필수 입력 사항입니다"))
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("이름과 발표 주제는 필수 입력 사항입니다"))
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -212,6 +227,47 @@ func (rc *registrationCmd) submitChangeModal(s *discordgo.Session, i *discordgo.
 		return err
 	}
 
+	l := i18n.FromInteraction(i)
+
+	// send response
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: user.Mention(),
+			Flags:   discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{
+				registrationEmbed(s.State.User, l.T("등록 변경 완료"), l.T("발표자 등록 정보 변경이 완료되었습니다.")),
+			},
+		},
+	})
+}
+
+// withdraw registration, the first waiting member takes the seat
+func (rc *registrationCmd) withdraw(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	user := utils.GetGuildUserFromInteraction(i)
+	if user == nil {
+		return study.ErrUserNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	params := &service.UpdateParams{
+		GuildID:  i.GuildID,
+		MemberID: user.ID,
+	}
+
+	// withdraw registration
+	gs, gr, err := rc.svc.UpdateRound(ctx, params, service.WithdrawMember, service.ValidateToWithdraw)
+	if err != nil {
+		return err
+	}
+
+	// send DMs to members promoted from the waitlist
+	go rc.notifyPromoted(s, gs, gr, params.Promoted)
+
+	l := i18n.FromInteraction(i)
+
 	// send response
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -219,8 +275,15 @@ func (rc *registrationCmd) submitChangeModal(s *discordgo.Session, i *discordgo.
 			Content: user.Mention(),
 			Flags:   discordgo.MessageFlagsEphemeral,
 			Embeds: []*discordgo.MessageEmbed{
-				registrationEmbed(s.State.User, "등록 변경 완료", "발표자 등록 정보 변경이 완료되었습니다."),
+				registrationEmbed(s.State.User, l.T("등록 취소 완료"), l.T("발표자 등록이 취소되었습니다.")),
 			},
 		},
 	})
 }
+
+// send DMs to members promoted from the waitlist
+func (rc *registrationCmd) notifyPromoted(s *discordgo.Session, gs *study.Study, gr *study.Round, memberIDs []string) {
+	if err := command.NotifyPromoted(s, gs, gr, memberIDs); err != nil {
+		rc.sugar.Errorw(err.Error(), "event", "notify-promoted")
+	}
+}
@@ -31,6 +31,11 @@ var (
 		Description: "발표자 등록 정보를 변경합니다.",
 	}
 
+	withdrawCmd = discordgo.ApplicationCommand{
+		Name:        "발표-취소",
+		Description: "발표자 등록을 취소합니다. 발표자 등록과 발표 자료 제출 단계에서만 가능합니다.",
+	}
+
 	changeModalCustomID = "registration-change-modal"
 )
 
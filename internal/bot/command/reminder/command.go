@@ -0,0 +1,67 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/bot/command"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+	"github.com/piatoss3612/my-study-bot/internal/utils"
+)
+
+type reminderCommand struct {
+	svc service.Service
+}
+
+func NewReminderCommand(svc service.Service) command.Command {
+	return &reminderCommand{
+		svc: svc,
+	}
+}
+
+func (rc *reminderCommand) Register(reg command.Registerer) {
+	reg.RegisterCommand(cmd, rc.setReminderOptOut)
+}
+
+// opt in or out of reminders of the study
+func (rc *reminderCommand) setReminderOptOut(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	// user should be in guild
+	user := utils.GetGuildUserFromInteraction(i)
+	if user == nil {
+		return study.ErrUserNotFound
+	}
+
+	receive := i.ApplicationCommandData().Options[0].BoolValue()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// set opt out
+	_, err := rc.svc.UpdateStudy(ctx, &service.UpdateParams{
+		GuildID:  i.GuildID,
+		MemberID: user.ID,
+		OptOut:   !receive,
+	}, service.SetReminderOptOut, service.ValidateToSetReminderOptOut)
+	if err != nil {
+		return err
+	}
+
+	l := i18n.FromInteraction(i)
+
+	content := l.T("리마인더 DM을 받습니다.")
+	if !receive {
+		content = l.T("리마인더 DM을 받지 않습니다. /리마인더 명령어로 다시 받을 수 있습니다.")
+	}
+
+	// send response
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -0,0 +1,16 @@
+package reminder
+
+import "github.com/bwmarrin/discordgo"
+
+var cmd = discordgo.ApplicationCommand{
+	Name:        "리마인더",
+	Description: "제출하지 않은 발표 자료, 피드백, 회고를 알려주는 DM을 받을지 설정합니다.",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "받기",
+			Description: "리마인더 DM을 받으려면 True, 받지 않으려면 False를 선택해주세요.",
+			Required:    true,
+		},
+	},
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/piatoss3612/my-study-bot/internal/bot/command"
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
 	"github.com/piatoss3612/my-study-bot/internal/study"
 	"github.com/piatoss3612/my-study-bot/internal/study/service"
 	"github.com/piatoss3612/my-study-bot/internal/utils"
@@ -45,7 +46,7 @@ func (sc *submitCommand) submitContent(s *discordgo.Session, i *discordgo.Intera
 	}
 
 	if content == "" {
-		return errors.Join(study.ErrRequiredArgs, errors.New("발표 자료 링크는 필수 입력 사항입니다"))
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("발표 자료 링크는 필수 입력 사항입니다"))
 	}
 
 	_, err := url.Parse(content)
@@ -67,6 +68,8 @@ func (sc *submitCommand) submitContent(s *discordgo.Session, i *discordgo.Intera
 		return err
 	}
 
+	l := i18n.FromInteraction(i)
+
 	// send response
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -74,7 +77,7 @@ func (sc *submitCommand) submitContent(s *discordgo.Session, i *discordgo.Intera
 			Content: user.Mention(),
 			Flags:   discordgo.MessageFlagsEphemeral,
 			Embeds: []*discordgo.MessageEmbed{
-				submitEmbed(s.State.User, "제출 완료", "발표 자료가 제출되었습니다.", content),
+				submitEmbed(s.State.User, l.T("제출 완료"), l.T("발표 자료가 제출되었습니다."), content),
 			},
 		},
 	})
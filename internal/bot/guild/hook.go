@@ -0,0 +1,41 @@
+package guild
+
+import (
+	"context"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/bot"
+	"github.com/piatoss3612/my-study-bot/internal/cache"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+)
+
+type guildHook struct {
+	svc   service.Service
+	cache cache.Cache
+}
+
+func NewGuildHook(svc service.Service, cache cache.Cache) bot.GuildHook {
+	return &guildHook{
+		svc:   svc,
+		cache: cache,
+	}
+}
+
+// store guild settings when the bot joins or the guild is updated
+func (h *guildHook) OnGuildJoin(ctx context.Context, g *discordgo.Guild) error {
+	_, err := h.svc.JoinGuild(ctx, &service.JoinGuildParams{
+		GuildID: g.ID,
+		Name:    g.Name,
+		OwnerID: g.OwnerID,
+	})
+	return err
+}
+
+// deactivate guild settings and clean up cached data when the bot leaves
+func (h *guildHook) OnGuildLeave(ctx context.Context, guildID string) error {
+	if _, err := h.svc.LeaveGuild(ctx, guildID); err != nil {
+		return err
+	}
+
+	return h.cache.Delete(ctx, guildID)
+}
@@ -0,0 +1,67 @@
+package guild
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/bot"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/service"
+)
+
+type voiceHook struct {
+	svc service.Service
+}
+
+func NewVoiceHook(svc service.Service) bot.VoiceHook {
+	return &voiceHook{
+		svc: svc,
+	}
+}
+
+// record join and leave of the voice channel of the study during presentations
+func (h *voiceHook) OnVoiceStateUpdate(ctx context.Context, v *discordgo.VoiceStateUpdate) error {
+	gs, err := h.svc.GetStudy(ctx, v.GuildID)
+	if err != nil {
+		// guilds without study are not tracked
+		if errors.Is(err, study.ErrStudyNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if !gs.TracksVoice() || gs.CurrentStage != study.StagePresentationStarted || gs.OngoingRoundID == "" {
+		return nil
+	}
+
+	gr, err := h.svc.GetRound(ctx, gs.OngoingRoundID)
+	if err != nil {
+		return err
+	}
+
+	member, ok := gr.GetMember(v.UserID)
+	if !ok {
+		return nil
+	}
+
+	// mute or deafen updates in the same channel do not change presence
+	joined := v.ChannelID == gs.VoiceChannelID
+
+	var update service.UpdateFunc
+
+	switch {
+	case joined && !member.IsInVoice():
+		update = service.JoinVoice
+	case !joined && member.IsInVoice():
+		update = service.LeaveVoice
+	default:
+		return nil
+	}
+
+	_, _, err = h.svc.UpdateRound(ctx, &service.UpdateParams{
+		GuildID:   v.GuildID,
+		MemberIDs: []string{v.UserID},
+	}, update, service.ValidateToCheckOngoingRound, service.ValidateToTrackVoice)
+	return err
+}
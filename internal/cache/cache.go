@@ -2,11 +2,15 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+var ErrCacheMiss = errors.New("cache: key is missing")
+
 type Cache interface {
 	Exists(ctx context.Context, key string) bool
 	Get(ctx context.Context, key string, value interface{}) error
 	Set(ctx context.Context, key string, value interface{}, ttl ...time.Duration) error
+	Delete(ctx context.Context, key string) error
 }
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/piatoss3612/my-study-bot/internal/cache"
+)
+
+var defaultTTL = 1 * time.Hour
+
+type item struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+type memoryCache struct {
+	items map[string]item
+	mtx   sync.RWMutex
+}
+
+// NewCache returns cache which keeps values in memory, values are encoded to avoid sharing references
+func NewCache() cache.Cache {
+	return &memoryCache{
+		items: make(map[string]item),
+	}
+}
+
+func (c *memoryCache) Exists(_ context.Context, key string) bool {
+	_, ok := c.get(key)
+	return ok
+}
+
+func (c *memoryCache) Get(_ context.Context, key string, value interface{}) error {
+	it, ok := c.get(key)
+	if !ok {
+		return cache.ErrCacheMiss
+	}
+
+	return json.Unmarshal(it.value, value)
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value interface{}, ttl ...time.Duration) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	d := defaultTTL
+	if len(ttl) > 0 && ttl[0] > 0 {
+		d = ttl[0]
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now()
+
+	// remove expired items
+	for k, it := range c.items {
+		if now.After(it.expiresAt) {
+			delete(c.items, k)
+		}
+	}
+
+	c.items[key] = item{value: b, expiresAt: now.Add(d)}
+	return nil
+}
+
+func (c *memoryCache) Delete(_ context.Context, key string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.items, key)
+	return nil
+}
+
+func (c *memoryCache) get(key string) (item, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	it, ok := c.items[key]
+	if !ok || time.Now().After(it.expiresAt) {
+		return item{}, false
+	}
+
+	return it, true
+}
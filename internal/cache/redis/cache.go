@@ -37,3 +37,7 @@ func (c *redisCache) Set(ctx context.Context, key string, value interface{}, ttl
 
 	return c.client.Set(item)
 }
+
+func (c *redisCache) Delete(ctx context.Context, key string) error {
+	return c.client.Delete(ctx, key)
+}
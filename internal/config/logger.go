@@ -1,6 +1,10 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type LoggerConfig struct {
 	RabbitMQ struct {
@@ -8,7 +12,25 @@ type LoggerConfig struct {
 		Exchange string `mapstructure:"exchange"`
 		Kind     string `mapstructure:"kind"`
 		Queue    string `mapstructure:"queue"`
+		// retry failed events with exponential backoff starting from retry delay
+		MaxRetries int           `mapstructure:"max_retries"`
+		RetryDelay time.Duration `mapstructure:"retry_delay"`
 	} `mapstructure:"rabbitmq"`
+	Redis struct {
+		Addr string `mapstructure:"addr"`
+	} `mapstructure:"redis"`
+	// events are recorded to every sink, google sheets is used if empty
+	Sinks []SinkConfig `mapstructure:"sinks"`
+}
+
+type SinkConfig struct {
+	Type            string `mapstructure:"type"` // sheets, csv, jsonl, markdown or webhook
+	Name            string `mapstructure:"name"` // tracks events recorded by the sink, type and position if empty
+	Dir             string `mapstructure:"dir"`  // for csv, jsonl and markdown
+	URL             string `mapstructure:"url"`  // for webhook
+	Credentials     string `mapstructure:"credentials"`
+	SpreadsheetID   string `mapstructure:"spreadsheet_id"`
+	ProgressSheetID int64  `mapstructure:"progress_sheet_id"`
 }
 
 func NewLoggerConfig(filename string) (*LoggerConfig, error) {
@@ -3,10 +3,10 @@ package config
 import "github.com/spf13/viper"
 
 type StudyConfig struct {
+	// mongo (default) or memory, memory driver runs without mongodb, redis and rabbitmq
+	Driver  string `mapstructure:"driver"`
 	Discord struct {
-		BotToken  string `mapstructure:"bot_token"`
-		GuildID   string `mapstructure:"guild_id"`
-		ManagerID string `mapstructure:"manager_id"`
+		BotToken string `mapstructure:"bot_token"`
 	} `mapstructure:"discord"`
 	MongoDB struct {
 		URI    string `mapstructure:"uri"`
@@ -20,6 +20,8 @@ type StudyConfig struct {
 		Exchange string `mapstructure:"exchange"`
 		Kind     string `mapstructure:"kind"`
 	} `mapstructure:"rabbitmq"`
+	// sinks of the logger running in the same process with memory driver
+	Sinks []SinkConfig `mapstructure:"sinks"`
 }
 
 func NewStudyConfig(filename string) (*StudyConfig, error) {
@@ -0,0 +1,665 @@
+package i18n
+
+// english names of commands and options
+var enNames = map[string]string{
+	// commands
+	"매니저":          "manager",
+	"정기-라운드":       "recurring-round",
+	"도움":           "help",
+	"프로필":          "profile",
+	"내-정보":         "my-info",
+	"스터디-정보":       "study-info",
+	"라운드-정보":       "round-info",
+	"발표자-등록":       "register-speaker",
+	"발표자-등록-정보-변경": "edit-registration",
+	"발표-자료-제출":     "submit-content",
+	"피드백":          "feedback",
+	"발표회고":         "reflection",
+
+	// options
+	"명령어":     "command",
+	"텍스트":     "text",
+	"사용자":     "user",
+	"채널":      "channel",
+	"역할":      "role",
+	"단계":      "stage",
+	"진행-방식":   "pipeline",
+	"언어":      "language",
+	"일정":      "schedule",
+	"제목-형식":   "title-format",
+	"단계별-마감":  "stage-deadlines",
+	"휴일":      "holidays",
+	"발표자":     "speaker",
+	"내용":      "content",
+	"링크":      "link",
+	"이름":      "name",
+	"주제":      "subject",
+	"대기열":     "waitlist",
+	"정원":      "cap",
+	"순번":      "position",
+	"발표-취소":   "withdraw",
+	"발표자-관리":  "members",
+	"발표-순서":   "lineup",
+	"순서":      "order",
+	"음성-출석":   "voice-attendance",
+	"비율":      "share",
+	"받은-피드백":  "received-feedback",
+	"피드백-집계":  "feedback-summary",
+	"라운드":     "round",
+	"피드백-방식":  "feedback-mode",
+	"리뷰-배정":   "review-assignment",
+	"인원":      "count",
+	"제한":      "restrict",
+	"리마인더-설정": "reminder-settings",
+	"리마인더":    "reminder",
+	"받기":      "receive",
+	"대상":      "target",
+	"시간":      "time",
+	"번호":      "number",
+	"진행-현황":   "progress",
+}
+
+// english messages
+var en = map[string]string{
+	// stages
+	"다음 라운드 대기":   "Waiting for next round",
+	"발표자 등록":      "Speaker registration",
+	"발표자 등록 마감":   "Speaker registration closed",
+	"발표 자료 제출":    "Content submission",
+	"발표 자료 제출 마감": "Content submission closed",
+	"발표":          "Presentation",
+	"발표 종료":       "Presentation finished",
+	"피드백":         "Feedback",
+	"피드백 마감":      "Feedback closed",
+	"라운드 종료":      "Round finished",
+	"라운드 취소":      "Round cancelled",
+	"초기화":         "Initialized",
+
+	// errors
+	"오류": "Error",
+	"알 수 없는 오류가 발생했습니다. 문제가 계속되면 매니저에게 오류 ID를 알려주세요.": "An unknown error occurred. If the problem persists, please tell a manager the error ID.",
+	"오류 ID: %s": "Error ID: %s",
+	"내부 오류":     "Internal error",
+	"사용자 정보를 찾을 수 없습니다":                "User not found",
+	"매니저 정보를 찾을 수 없습니다":                "Manager not found",
+	"서버 정보를 찾을 수 없습니다":                 "Server not found",
+	"스터디 정보를 찾을 수 없습니다":                "Study not found",
+	"라운드 정보를 찾을 수 없습니다":                "Round not found",
+	"등록된 사용자 정보를 찾을 수 없습니다":            "Member not found",
+	"채널 정보를 찾을 수 없습니다":                 "Channel not found",
+	"정기 라운드 설정을 찾을 수 없습니다":             "Recurring round is not set",
+	"매니저가 아닙니다":                        "You are not a manager",
+	"매니저만 사용할 수 있는 명령어입니다":             "Only managers can use this command",
+	"스터디 소유자만 사용할 수 있는 명령어입니다":         "Only the owner of the study can use this command",
+	"이미 매니저입니다":                        "Already a manager",
+	"이미 진행중인 스터디가 있습니다":                "There is already an ongoing study",
+	"이미 진행중인 라운드가 있습니다":                "There is already an ongoing round",
+	"이미 진행중인 스터디 라운드가 있습니다":            "There is already an ongoing study round",
+	"이미 등록된 발표자입니다":                    "Already registered as a speaker",
+	"등록되지 않은 발표자입니다":                   "Not registered as a speaker",
+	"등록된 발표자가 아닙니다":                    "Not a registered speaker",
+	"참석하지 않은 발표자입니다":                   "The speaker did not attend",
+	"이미 리뷰를 작성하셨습니다":                   "You have already written a review",
+	"이미 회고를 작성하셨습니다":                   "You have already written a reflection",
+	"자기 자신에게 피드백을 보낼 수 없습니다":           "You cannot send feedback to yourself",
+	"자기 자신을 리뷰할 수 없습니다":                "You cannot review yourself",
+	"필수 인자가 없습니다":                      "Required arguments are missing",
+	"인자가 올바르지 않습니다":                    "Invalid arguments",
+	"올바르지 않은 명령어입니다":                   "Invalid command",
+	"잘못된 스터디 단계입니다":                    "Invalid stage of the study",
+	"잘못된 업데이트 파라미터입니다":                 "Invalid update parameters",
+	"잘못된 마감 시간입니다":                     "Invalid deadline",
+	"잘못된 반복 일정입니다":                     "Invalid schedule",
+	"잘못된 시간대입니다":                       "Invalid time zone",
+	"잘못된 이벤트 데이터입니다":                   "Invalid event data",
+	"알 수 없는 이벤트 토픽입니다":                 "Unknown event topic",
+	"지원하지 않는 언어입니다":                    "Unsupported language",
+	"존재하지 않는 진행 방식입니다":                 "Unknown pipeline",
+	"진행 단계의 마감 시간이 지나지 않았습니다":          "The deadline of the stage has not passed",
+	"일시 중지된 라운드입니다":                    "The round is paused",
+	"일시 중지된 라운드가 아닙니다":                 "The round is not paused",
+	"정기 라운드 일정이 아직 되지 않았습니다":           "The recurring round is not due yet",
+	"다른 요청에 의해 정보가 변경되었습니다. 다시 시도해주세요": "The data was changed by another request. Please try again",
+	"파라미터가 nil입니다":                     "Parameters are nil",
+	"함수가 nil입니다":                       "Function is nil",
+	"옵션을 찾을 수 없습니다":                    "Option not found",
+
+	// detailed errors
+	"공지로 전송할 내용을 입력해주세요":                "Please enter the content of the notice",
+	"스터디 제목은 필수입니다":                     "Title of the study is required",
+	"언어를 선택해주세요":                        "Please select a language",
+	"마감 시간을 설정할 진행 단계를 선택해주세요":          "Please select the stage to set the deadline of",
+	"마감 시간은 %s 형식으로 입력해주세요":             "Please enter the deadline as %s",
+	"봇은 매니저로 추가할 수 없습니다":                "Bots cannot be managers",
+	"봇에게 스터디 소유권을 이전할 수 없습니다":           "Ownership cannot be transferred to bots",
+	"이름과 발표 주제는 필수 입력 사항입니다":            "Name and subject are required",
+	"발표자 등록 정보 변경이 가능한 단계가 아닙니다":        "Registration cannot be changed in this stage",
+	"리뷰 대상자는 필수 입력 사항입니다":               "Speaker to review is required",
+	"봇은 리뷰 대상자로 지정할 수 없습니다":             "Bots cannot be reviewed",
+	"리뷰어 정보를 찾을 수 없습니다":                 "Reviewer not found",
+	"발표 자료 링크는 필수 입력 사항입니다":             "Link of the content is required",
+	"회고 내용은 필수입니다":                      "Content of the reflection is required",
+	"반복 일정은 필수입니다":                      "Schedule is required",
+	"단계별 마감은 <단계>=<시간> 형식으로 입력해주세요: %s": "Please enter stage deadlines as <stage>=<duration>: %s",
+	"진행 방식에 포함되지 않은 단계입니다: %s":          "The stage is not in the pipeline: %s",
+	"시간은 48h, 90m 형식으로 입력해주세요: %s":      "Please enter durations like 48h, 90m: %s",
+	"마감 시간은 라운드 시작 이후여야 합니다: %s":        "The deadline must be after the start of the round: %s",
+	"휴일은 %s 형식으로 입력해주세요: %s":            "Please enter holidays as %s: %s",
+	"다음 일정을 찾을 수 없습니다":                  "The next schedule is not found",
+	"일정은 분 시 일 월 요일의 5개 항목으로 입력해주세요":    "Please enter the schedule as 5 fields of minute hour day month weekday",
+	"잘못된 간격입니다: %s":                     "Invalid step: %s",
+	"잘못된 범위입니다: %s":                     "Invalid range: %s",
+	"잘못된 값입니다: %s":                      "Invalid value: %s",
+	"허용 범위(%d-%d)를 벗어난 값입니다: %s":        "Value out of range (%d-%d): %s",
+	"지원하는 언어: %s, %s":                   "Supported languages: %s, %s",
+	"시간대를 입력해주세요":                       "Please enter a time zone",
+	"알 수 없는 시간대입니다: %s":                 "Unknown time zone: %s",
+	"매니저로 추가할 사용자 ID가 없습니다":             "User ID to add as a manager is missing",
+	"매니저에서 제외할 사용자 ID가 없습니다":            "User ID to remove from managers is missing",
+	"스터디 소유자는 매니저에서 제외할 수 없습니다":         "The owner of the study cannot be removed from managers",
+	"소유권을 넘겨받을 사용자 ID가 없습니다":            "User ID to transfer ownership to is missing",
+	"이미 스터디 소유자입니다":                     "Already the owner of the study",
+	"정기 라운드 설정이 없습니다":                   "Recurring round is missing",
+	"이전 단계로 되돌릴 수 없습니다":                 "Cannot roll back to the previous stage",
+	"등록할 사용자 ID가 없습니다":                  "User ID to register is missing",
+	"발표자 등록이 불가능한 단계입니다":                "Speakers cannot register in this stage",
+	"발표자 등록 정보를 변경할 사용자 ID가 없습니다":       "User ID to change registration of is missing",
+	"발표자 등록 정보 변경이 불가능한 단계입니다":          "Registration cannot be changed in this stage",
+	"발표자료를 제출할 사용자 ID가 없습니다":            "User ID to submit content of is missing",
+	"발표자료 제출이 불가능한 단계입니다":               "Content cannot be submitted in this stage",
+	"발표 참여 여부를 확인할 사용자 ID가 없습니다":        "User ID to confirm attendance of is missing",
+	"발표자 출석체크가 불가능한 단계입니다":              "Attendance cannot be confirmed in this stage",
+	"발표 녹화본 URL이 없습니다":                  "URL of the recording is missing",
+	"발표 녹화본 제출이 불가능한 단계입니다":             "Recording cannot be registered in this stage",
+	"리뷰어 또는 리뷰 대상자 ID가 없습니다":            "Reviewer or speaker ID is missing",
+	"리뷰어 지정이 불가능한 단계입니다":                "Feedback cannot be sent in this stage",
+	"스터디에 참여한 사용자만 리뷰 참여가 가능합니다":        "Only members of the study can send feedback",
+	"리뷰 대상자는 발표에 참여한 사용자여야 합니다":         "The speaker must have attended the presentation",
+	"회고를 작성할 사용자 ID가 없습니다":              "User ID to write reflection of is missing",
+	"회고 작성이 불가능한 단계입니다":                 "Reflections cannot be written in this stage",
+	"마감 시간을 설정할 수 없는 단계입니다":             "Deadline cannot be set to this stage",
+	"이미 지나간 단계입니다":                      "The stage has already passed",
+	"마감 시간은 현재 시간 이후여야 합니다":             "The deadline must be in the future",
+
+	// durations
+	"%d시간": "%dh",
+	"%d분":  "%dm",
+	"%d초":  "%ds",
+
+	// common
+	"확인":     "Confirm",
+	"없음":     "None",
+	"미등록":    "Not registered",
+	"미설정":    "Not set",
+	"성공":     "Succeeded",
+	"실패: %s": "Failed: %s",
+	"사유: %s": "Reason: %s",
+	"기본":     "Default",
+	"스터디":    "Study",
+
+	// command descriptions and options
+	"스터디 관리 명령어입니다. 매니저만 사용할 수 있습니다.":                           "Commands to manage the study. Only managers can use them.",
+	"정해진 일정에 스터디 라운드를 자동으로 생성합니다. 매니저만 사용할 수 있습니다.":             "Creates study rounds on a schedule automatically. Only managers can use it.",
+	"사용할 명령어를 선택해주세요.":                                          "Select a command.",
+	"텍스트를 입력해주세요.":                                              "Enter text.",
+	"사용자를 선택해주세요.":                                              "Select a user.",
+	"채널을 선택해주세요.":                                               "Select a channel.",
+	"역할을 선택해주세요.":                                               "Select a role.",
+	"진행 단계를 선택해주세요.":                                            "Select a stage.",
+	"스터디 라운드의 진행 방식을 선택해주세요. 기본 진행 방식이 사용됩니다.":                  "Select the pipeline of the round. The default pipeline is used if not selected.",
+	"생성되는 라운드의 진행 방식을 선택해주세요. 기본 진행 방식이 사용됩니다.":                 "Select the pipeline of created rounds. The default pipeline is used if not selected.",
+	"스터디에서 사용할 언어를 선택해주세요.":                                     "Select the language of the study.",
+	"분 시 일 월 요일 형식의 반복 일정입니다. (예: 0 14 * * 6 → 매주 토요일 14시)":     "Schedule as minute hour day month weekday. (e.g. 0 14 * * 6 → every Saturday at 14:00)",
+	"라운드 제목 형식입니다. {n}은 라운드 번호로 바뀝니다. (기본: {n}회차 발표)":           "Title format of rounds. {n} is replaced with the round number. (default: {n}회차 발표)",
+	"라운드 시작부터 각 단계 마감까지의 시간입니다. (예: 발표자 등록=48h, 발표 자료 제출=120h)": "Duration from the start of the round to the deadline of each stage. (e.g. Speaker registration=48h, Content submission=120h)",
+	"라운드를 생성하지 않을 날짜입니다. (예: 2024-12-25, 2025-01-01)":           "Dates to skip creating rounds. (e.g. 2024-12-25, 2025-01-01)",
+	"도움말을 확인합니다.":                                               "Shows help.",
+	"발표 진스의 프로필을 보여줍니다.":                                        "Shows the profile of the bot.",
+	"나의 스터디 라운드 등록 정보를 확인합니다.":                                  "Shows my registration of the study round.",
+	"스터디 정보를 확인합니다.":                                            "Shows the study.",
+	"진행중인 스터디 라운드 정보를 확인합니다.":                                   "Shows the ongoing study round.",
+	"발표자 정보를 등록합니다.":                                            "Registers as a speaker.",
+	"발표자의 이름을 입력해 주세요.":                                         "Enter the name of the speaker.",
+	"발표 주제를 입력해 주세요.":                                           "Enter the subject of the presentation.",
+	"발표자 등록 정보를 변경합니다.":                                         "Changes the registration of the speaker.",
+	"발표 자료를 제출합니다.":                                             "Submits the content of the presentation.",
+	"발표 자료 링크를 입력해주세요.":                                         "Enter the link of the content.",
+	"발표자에게 피드백을 보냅니다.":                                          "Sends feedback to a speaker.",
+	"피드백을 받을 발표자를 선택해주세요.":                                      "Select the speaker to receive the feedback.",
+	"발표회고를 작성합니다.":                                              "Writes a reflection on the presentation.",
+	"발표회고 내용을 입력해주세요.":                                          "Enter the reflection.",
+
+	// choices of commands
+	"스터디 생성":         "Create study",
+	"공지":             "Notice",
+	"상태 갱신":          "Refresh status",
+	"스터디 라운드 생성":     "Create study round",
+	"스터디 라운드 이동":     "Move study round",
+	"스터디 라운드 되돌리기":   "Roll back study round",
+	"스터디 라운드 일시 중지":  "Pause study round",
+	"스터디 라운드 재개":     "Resume study round",
+	"스터디 라운드 취소":     "Cancel study round",
+	"발표자 참여 확정":      "Confirm attendance",
+	"발표 녹화 자료 등록":    "Register recording",
+	"공지 채널 설정":       "Set notice channel",
+	"회고 채널 설정":       "Set reflection channel",
+	"스프레드시트 설정":      "Set spreadsheet",
+	"시간대 설정":         "Set time zone",
+	"언어 설정":          "Set language",
+	"진행 단계 마감 시간 설정": "Set stage deadline",
+	"매니저 추가":         "Add manager",
+	"매니저 제외":         "Remove manager",
+	"스터디 소유권 이전":     "Transfer ownership",
+	"매니저 역할 설정":      "Set manager role",
+	"매니저 활동 기록":      "Manager audit log",
+	"설정":             "Set",
+	"조회":             "Show",
+	"해제":             "Remove",
+	"라이트닝 토크 (자료 제출·피드백 생략)": "Lightning talk (no content submission and feedback)",
+	"피드백 생략": "No feedback",
+	"한국어":    "한국어",
+
+	// help
+	"도움말":                 "Help",
+	"도움말 옵션 💡":            "Help options 💡",
+	"기본 명령어 도움말":          "Help of basic commands",
+	"스터디 명령어 도움말":         "Help of study commands",
+	"큰 결심 하기":             "Make a big decision",
+	"아래의 도움말 옵션을 선택해주세요!": "Select a help option below!",
+	"❔ 기본 명령어":            "❔ Basic commands",
+	"📚 스터디 명령어":           "📚 Study commands",
+	"> 명령어 사용 예시: /[명령어]": "> Usage: /[command]",
+	"명령어 도움말 확인":          "Show help of commands",
+	"프로필":                 "profile",
+	"발표 진스의 프로필 확인":       "Show the profile of the bot",
+	"내-정보":                "my-info",
+	"내 스터디 등록 정보 확인":      "Show my registration",
+	"스터디-정보":              "study-info",
+	"진행중인 스터디 정보 확인":      "Show the ongoing study",
+	"라운드-정보":              "round-info",
+	"진행중인 라운드 정보 확인":      "Show the ongoing round",
+	"발표자-등록":              "register-speaker",
+	"발표자로 등록":             "Register as a speaker",
+	"발표-취소":               "withdraw",
+	"발표자 등록 취소":           "Cancel speaker registration",
+	"발표-자료-제출":            "submit-content",
+	"발표자에게 피드백 전송":        "Send feedback to a speaker",
+	"발표회고":                "Reflection",
+	"발표회고 작성":             "Write a reflection",
+
+	// profile
+	"발표 진스의 프로필": "Profile of the bot",
+	"이름":         "Name",
+	"생성일":        "Created",
+	"재부팅":        "Rebooted",
+	"업타임":        "Uptime",
+	"💻 개발자":      "💻 Developer",
+	"📝 소스코드":     "📝 Source code",
+
+	// registration, submission, feedback and reflection
+	"등록 완료": "Registered",
+	"발표자 등록이 완료되었습니다.":       "You are registered as a speaker.",
+	"등록 변경 완료":               "Registration changed",
+	"발표자 등록 정보 변경이 완료되었습니다.": "Your registration has been changed.",
+	"발표자 등록 정보 변경":           "Change registration",
+	"발표자 이름":                 "Name of the speaker",
+	"변경할 발표자 이름을 입력해 주세요.":   "Enter the new name of the speaker.",
+	"발표 주제":                  "Subject",
+	"변경할 발표 주제를 입력해 주세요.":    "Enter the new subject.",
+	"제출 완료":                  "Submitted",
+	"발표 자료가 제출되었습니다.":        "Your content has been submitted.",
+	"피드백 작성":                 "Write feedback",
+	"발표자":                    "Speaker",
+	"피드백을 입력해주세요.":           "Enter your feedback.",
+	"피드백이 전송되었습니다.":          "Your feedback has been sent.",
+	"익명":                     "Anonymous",
+	"내용":                     "Content",
+	"회고가 성공적으로 전송되었습니다.":     "Your reflection has been sent.",
+
+	// info
+	"발표자 등록 정보 검색 🔍": "Search registration of speakers 🔍",
+	"스터디 정보":         "Study",
+	"관리자":            "Owner",
+	"공동 관리자":         "Managers",
+	"시간대":            "Time zone",
+	"언어":             "Language",
+	"총 라운드 수":        "Total rounds",
+	"진행 단계":          "Stage",
+	"이전 라운드 조회":      "Previous rounds",
+	"현재 진행중인 스터디 라운드 정보": "Ongoing study round",
+	"번호":         "Number",
+	"제목":         "Title",
+	"%s (일시 중지)": "%s (paused)",
+	"진행 단계 마감":   "Deadline of the stage",
+	"발표 결과 자료":   "Recording",
+	"진행 기록":      "Timeline",
+	"기록 없음":      "No records",
+	"%s님의 발표 정보": "Presentation of %s",
+	"발표 참여":      "Attended",
+	"발표 자료":      "Content",
+	"발표자 정보를 찾을 수 없습니다": "Speaker not found",
+
+	// timeline
+	"라운드 생성: %s (참여자 %d명)": "Round created: %s (%d members)",
+	"단계 이동: %s":            "Stage moved: %s",
+	"발표 영상 등록":             "Recording registered",
+	"마감 설정: %s (%s)":       "Deadline set: %s (%s)",
+	"마감 해제: %s":            "Deadline removed: %s",
+	"단계 되돌림: %s":           "Stage rolled back: %s",
+	"라운드 일시 중지":            "Round paused",
+	"라운드 재개":               "Round resumed",
+	"라운드 취소: %s":           "Round cancelled: %s",
+	"발표자 등록: %s":           "Speaker registered: %s",
+	"발표 자료 제출: <@%s>":      "Content submitted: <@%s>",
+	"발표 참여: <@%s>":         "Attended: <@%s>",
+	"피드백: <@%s> → <@%s>":   "Feedback: <@%s> → <@%s>",
+	"회고 작성: <@%s>":         "Reflection written: <@%s>",
+
+	// admin
+	"스터디가 생성되었습니다.":       "The study has been created.",
+	"스터디 ID: %s":          "Study ID: %s",
+	"공지 입력":               "Write notice",
+	"공지 내용을 입력해주세요.":      "Enter the content of the notice.",
+	"공지를 전송했습니다.":         "The notice has been sent.",
+	"발표 진스의 상태가 갱신되었습니다.": "The status of the bot has been refreshed.",
+	"**<%s>**가 생성되었습니다.":  "**<%s>** has been created.",
+	"스터디 라운드가 생성되었습니다.":   "The study round has been created.",
+	"스터디 라운드 진행 단계 변경":    "Move stage of the study round",
+	"스터디 라운드 진행 단계가 **<%s>**로 변경됩니다. 진행하시겠습니까?": "The stage of the study round will be moved to **<%s>**. Do you want to proceed?",
+	"스터디 라운드가 이동되었습니다.":                         "The study round has been moved.",
+	"발표 출석 확인": "Attendance confirmed",
+	"**<@%s>**님의 발표 출석이 확인되었습니다.":          "Attendance of **<@%s>** has been confirmed.",
+	"발표 영상이 등록되었습니다.":                      "The recording has been registered.",
+	"공지 채널이 %s로 설정되었습니다.":                  "The notice channel has been set to %s.",
+	"회고 채널이 %s로 설정되었습니다.":                  "The reflection channel has been set to %s.",
+	"스터디 시트가 %s로 설정되었습니다.":                 "The spreadsheet of the study has been set to %s.",
+	"스터디 시간대가 %s로 설정되었습니다. 현재 시간: %s":      "The time zone of the study has been set to %s. Current time: %s",
+	"스터디 언어가 %s로 설정되었습니다. 현재 시간: %s":       "The language of the study has been set to %s. Current time: %s",
+	"**<%s>** 단계의 마감 시간이 %s로 설정되었습니다.":     "The deadline of **<%s>** has been set to %s.",
+	"**<%s>** 단계의 마감 시간이 삭제되었습니다.":         "The deadline of **<%s>** has been removed.",
+	"라운드가 종료되었습니다. 다음 라운드를 준비하세요.":         "The round has finished. Get ready for the next round.",
+	"**<%s>**이(가) 시작되었습니다.":                "**<%s>** has started.",
+	"진행 단계가 **<%s>**(으)로 되돌려졌습니다.":         "The stage has been rolled back to **<%s>**.",
+	"스터디 라운드가 **<%s>** 단계로 되돌려졌습니다.":       "The study round has been rolled back to **<%s>**.",
+	"스터디 라운드가 일시 중지되었습니다. 재개될 때까지 기다려주세요.": "The study round has been paused. Please wait until it is resumed.",
+	"스터디 라운드가 일시 중지되었습니다.":                 "The study round has been paused.",
+	"스터디 라운드가 **<%s>** 단계부터 재개되었습니다.":      "The study round has been resumed from **<%s>**.",
+	"스터디 라운드가 재개되었습니다.":                    "The study round has been resumed.",
+	"취소 사유": "Reason",
+	"라운드를 취소하는 사유를 입력해주세요.":     "Enter the reason for cancelling the round.",
+	"**%d회차 %s** 라운드가 취소되었습니다.": "Round **%d %s** has been cancelled.",
+	"스터디 라운드가 취소되었습니다.":         "The study round has been cancelled.",
+	"%s님이 스터디 매니저로 추가되었습니다.":    "%s has been added as a manager of the study.",
+	"%s님이 매니저로 추가되었습니다.":        "%s has been added as a manager.",
+	"%s님이 매니저에서 제외되었습니다.":       "%s has been removed from managers.",
+	"%s님에게 스터디 소유권이 이전되었습니다.":   "Ownership of the study has been transferred to %s.",
+	"매니저 역할이 해제되었습니다.":          "The manager role has been removed.",
+	"매니저 역할이 %s로 설정되었습니다.":      "The manager role has been set to %s.",
+	"최근 %d개의 활동 기록입니다.":         "Last %d actions.",
+	"정기 라운드": "Recurring round",
+	"다음 라운드는 **%s**에 생성됩니다.": "The next round will be created at **%s**.",
+	"%s: 시작 후 %s": "%s: %s after start",
+	"일정":          "Schedule",
+	"제목 형식":       "Title format",
+	"진행 방식":       "Pipeline",
+	"단계별 마감":      "Stage deadlines",
+	"휴일":          "Holidays",
+	"정기 라운드 설정이 해제되었습니다.": "The recurring round has been removed.",
+
+	// event logs
+	"진행 로그":      "Progress log",
+	"진행 상태":      "Topic",
+	"설명":         "Description",
+	"시간":         "Time",
+	"%d 라운드: %s": "Round %d: %s",
+	"녹화 영상":      "Recording",
+	"생성":         "Created",
+	"최종 수정":      "Last updated",
+
+	// speaker cap and waitlist
+	"발표자 정원과 대기열을 관리합니다. 매니저만 사용할 수 있습니다.": "Manages the speaker cap and the waitlist. Only managers can use it.",
+	"정원 설정": "Set speaker cap",
+	"순서 변경": "Reorder waitlist",
+	"정원":    "Cap",
+	"순번":    "Position",
+	"라운드의 발표자 정원입니다. 0으로 설정하면 제한이 없습니다.": "Speaker cap of the round. 0 means no limit.",
+	"순서를 변경할 대기자를 선택해주세요.":               "Select the waiting member to move.",
+	"대기자를 옮길 순번입니다. 1번이 가장 먼저 등록됩니다.":    "Position to move the waiting member to. Number 1 takes the next seat.",
+	"발표자 정원은 0 이상이어야 합니다":                "Speaker cap must be 0 or more",
+	"발표자 정원을 입력해주세요":                     "Please enter the speaker cap",
+	"옮길 순번을 입력해주세요":                      "Please enter the position to move to",
+	"순서를 변경할 사용자 ID가 없습니다":               "User ID to move is missing",
+	"대기 순번은 1부터 %d 사이여야 합니다":             "Position must be between 1 and %d",
+	"이미 대기열에 등록되어 있습니다":                  "Already on the waitlist",
+	"대기열에 등록된 사용자가 아닙니다":                 "Not on the waitlist",
+	"발표자 대기열":                            "Speaker waitlist",
+	"발표자 정원":                             "Speaker cap",
+	"등록된 발표자":                            "Registered speakers",
+	"대기열":                                "Waitlist",
+	"제한 없음":                              "No limit",
+	"%d명":                                "%d",
+	"%d/%d명 (대기 %d명)":                    "%d/%d (%d waiting)",
+	"대기 %d번":                             "Waiting #%d",
+	"대기열 등록":                             "Waitlisted",
+	"발표자 정원(%d명)이 가득 차 대기열 %d번에 등록되었습니다. 자리가 나면 DM으로 알려드립니다.": "The speaker cap (%d) is full, you are #%d on the waitlist. You will get a DM when a seat opens.",
+	"발표자 등록 완료": "Registered as a speaker",
+	"자리가 나서 **%s** 라운드 발표자로 등록되었습니다.": "A seat opened and you are now a speaker of **%s**.",
+	"발표자 정원 해제":        "Speaker cap removed",
+	"발표자 정원: %d명":      "Speaker cap: %d",
+	"대기열 순서 변경":        "Waitlist reordered",
+	"대기열 등록: %s":       "Waitlisted: %s",
+	"대기열에서 등록: <@%s>":  "Promoted from waitlist: <@%s>",
+	"발표자 등록 취소: <@%s>": "Registration withdrawn: <@%s>",
+
+	// member withdrawal and management
+	"발표자 등록을 취소합니다. 발표자 등록과 발표 자료 제출 단계에서만 가능합니다.": "Withdraws your registration. Only available during speaker registration and content submission.",
+	"등록 취소 완료":              "Withdrawn",
+	"발표자 등록이 취소되었습니다.":      "Your registration has been withdrawn.",
+	"등록을 취소할 사용자 ID가 없습니다":  "User ID to unregister is missing",
+	"발표자 등록 취소가 불가능한 단계입니다": "Registration cannot be withdrawn in this stage",
+	"라운드 참여자의 등록 정보와 상태를 수정합니다. 매니저만 사용할 수 있습니다.": "Edits registration and state of members of the round. Only managers can use it.",
+	"강제 등록":                "Force register",
+	"등록 취소":                "Unregister",
+	"정보 수정":                "Edit member",
+	"발표 자료 초기화":            "Reset content",
+	"발표 참여 초기화":            "Reset attendance",
+	"피드백 초기화":              "Reset feedback",
+	"회고 초기화":               "Reset reflection",
+	"대상 사용자를 선택해주세요.":      "Select the member.",
+	"정보를 수정할 사용자 ID가 없습니다": "User ID to edit is missing",
+	"수정할 이름, 주제 또는 링크를 입력해주세요": "Please enter the name, subject or link to change",
+	"상태를 초기화할 사용자 ID가 없습니다":    "User ID to reset is missing",
+	"초기화할 수 없는 상태입니다: %s":      "Cannot reset the state: %s",
+	"발표자 정보가 변경되었습니다.":         "The member has been updated.",
+	"회고":                     "Reflection",
+	"발표자 정보 수정: <@%s>":       "Member edited: <@%s>",
+	"발표자 상태 초기화: <@%s> (%s)": "Member reset: <@%s> (%s)",
+
+	// presentation lineup
+	"%s 발표 진행":           "%s presentations",
+	"다음 발표":              "Up next",
+	"지금 발표":              "Now presenting",
+	"다음 발표자":             "Next speaker",
+	"발표 순서":              "Lineup",
+	"모든 발표가 끝났습니다":       "All presentations are finished",
+	"등록된 발표자가 없습니다":      "There are no registered speakers",
+	"발표 순서가 정해지지 않았습니다":  "The lineup has not been set",
+	"무작위로 정하기":           "Shuffle randomly",
+	"등록순으로 정하기":          "Order by registration",
+	"직접 정하기":             "Set manually",
+	"발표 진행 메시지 게시":       "Post live lineup message",
+	"발표 진행 메시지를 게시했습니다.": "The live lineup message has been posted.",
+	"발표 순서를 정하고 발표 진행 메시지를 게시합니다. 매니저만 사용할 수 있습니다.":      "Set the presentation order and post the live lineup message. Managers only.",
+	"발표자를 발표할 순서대로 멘션해주세요. 멘션하지 않은 발표자는 등록순으로 뒤에 배치됩니다.": "Mention speakers in presentation order. Speakers not mentioned follow in registration order.",
+	"발표 순서를 정하는 방식을 선택해주세요":                              "Choose how to order the lineup",
+	"발표 순서를 정할 수 없는 단계입니다":                               "The lineup cannot be set in this stage",
+	"발표 단계에서만 발표를 진행할 수 있습니다":                            "Presentations can only run in the presentation stage",
+	"발표 순서 결정 (%d명)": "Lineup set (%d speakers)",
+	"발표 시작: <@%s>":   "Talk started: <@%s>",
+	"발표 종료: <@%s>":   "Talk ended: <@%s>",
+
+	// voice attendance
+	"발표 중 음성 채널 참여로 출석을 확인합니다. 매니저만 사용할 수 있습니다.": "Check attendance by presence in the voice channel during presentations. Managers only.",
+	"음성 채널 설정":    "Set voice channel",
+	"음성 채널 해제":    "Remove voice channel",
+	"출석 인정 비율 설정": "Set attendance share",
+	"출석 검토":       "Review attendance",
+	"발표가 진행되는 음성 채널 또는 스테이지 채널을 선택해주세요.":                             "Select the voice or stage channel where presentations take place.",
+	"발표 시간 중 음성 채널에 머물러야 출석으로 인정되는 비율(%)입니다.":                        "Share of the presentation time (%) a speaker must stay in the voice channel to be attended.",
+	"음성 채널이 %s로 설정되었습니다. 발표 중 이 채널에 %d%% 이상 참여한 발표자는 자동으로 출석 처리됩니다.": "The voice channel has been set to %s. Speakers who stay in it for at least %d%% of the presentation are marked attended automatically.",
+	"음성 채널 설정이 해제되었습니다. 출석은 매니저가 직접 확인합니다.":                          "The voice channel has been removed. Managers confirm attendance manually.",
+	"출석 인정 비율이 %d%%로 설정되었습니다.":                                       "The attendance share has been set to %d%%.",
+	"/음성-출석 명령어의 출석 검토로 결과를 확정하거나 수정할 수 있습니다.":                       "Confirm or change the result with Review attendance of /voice-attendance.",
+	"출석을 인정할 발표자를 선택해 확정해주세요. 선택하지 않은 발표자는 미참여로 처리됩니다.":              "Select the speakers to mark as attended. Speakers not selected are marked as absent.",
+	"발표 시간의 %d%% 이상 음성 채널에 참여한 발표자는 자동으로 출석 처리되었습니다.":                "Speakers who stayed in the voice channel for at least %d%% of the presentation have been marked attended automatically.",
+	"%s 출석 검토":       "%s attendance review",
+	"발표 시간":          "Presentation time",
+	"검토":             "Review",
+	"완료":             "Done",
+	"출석을 인정할 발표자 선택": "Select attended speakers",
+	"음성 출석":          "Voice attendance",
+	"<#%s> 채널에 발표 시간의 %d%% 이상 참여": "At least %[2]d%% of the presentation in <#%[1]s>",
+	"음성 채널이 설정되지 않았습니다":           "The voice channel is not set",
+	"발표 중에만 음성 채널 참여를 기록합니다":      "Voice presence is recorded only during presentations",
+	"음성 채널에 참여한 사용자 ID가 없습니다":     "No user ID of the voice channel is given",
+	"출석 인정 비율은 1부터 100 사이여야 합니다":  "The attendance share must be between 1 and 100",
+	"출석을 검토할 수 없는 사용자입니다: <@%s>":  "Attendance of this user cannot be reviewed: <@%s>",
+	"출석 검토 완료 (%d명 참여)":           "Attendance reviewed (%d attended)",
+	"음성 채널 입장: <@%s>":             "Joined voice channel: <@%s>",
+	"음성 채널 퇴장: <@%s>":             "Left voice channel: <@%s>",
+	"발표 참여: <@%s> (음성 채널 %d%%)":   "Attended: <@%s> (voice %d%%)",
+
+	// feedback archive
+	"%s (%d-%d점, 선택)": "%s (%d-%d, optional)",
+	"점수를 숫자로 입력해주세요.": "Enter the score as a number.",
+	"명확성":             "Clarity",
+	"깊이":              "Depth",
+	"전달력":             "Delivery",
+	"%s 피드백 집계":       "%s feedback summary",
+	"<@%s> · 피드백 %d개": "<@%s> · %d feedback(s)",
+	"명확성 %.1f · 깊이 %.1f · 전달력 %.1f (%d개 채점)": "Clarity %.1f · Depth %.1f · Delivery %.1f (%d scored)",
+	"받은 피드백 %d/%d":  "Received feedback %d/%d",
+	"받은 피드백이 없습니다":  "No feedback received",
+	"이전":            "Previous",
+	"다음":            "Next",
+	"잘못된 페이지 정보입니다": "invalid page",
+	"점수는 %d부터 %d 사이여야 합니다":       "scores must be between %d and %d",
+	"점수는 %d부터 %d 사이의 숫자로 입력해주세요": "enter scores as a number between %d and %d",
+	"피드백 내용을 입력해주세요":             "enter the feedback",
+	"피드백이 저장되었습니다. 발표자에게 DM을 보내지 못했지만 발표자는 /받은-피드백 명령어로 확인할 수 있습니다.": "The feedback has been saved. It could not be sent to the speaker by DM, but the speaker can read it with /received-feedback.",
+	"라운드에서 받은 피드백을 다시 확인합니다.":                               "Review the feedback you received in a round.",
+	"피드백을 확인할 라운드 번호입니다. 입력하지 않으면 진행중이거나 가장 최근 라운드를 확인합니다.": "Round number to review. Defaults to the ongoing or latest round.",
+	"발표자별 피드백 수와 평균 점수를 확인합니다. 매니저만 사용할 수 있습니다.":            "Show the feedback count and average scores per speaker. Managers only.",
+	"집계할 라운드 번호입니다. 입력하지 않으면 진행중이거나 가장 최근 라운드를 집계합니다.":      "Round number to summarize. Defaults to the ongoing or latest round.",
+
+	// help for received feedback
+	"받은 피드백 다시 확인": "Review received feedback",
+
+	// feedback modes
+	"실명": "Named",
+	"익명 (발표자가 작성자 없이 확인)":      "Anonymous (speaker sees feedback without the reviewer)",
+	"실명 (발표자가 작성자와 함께 확인)":     "Named (speaker sees feedback with the reviewer)",
+	"매니저 전용 (매니저만 작성자와 함께 확인)": "Managers only (only managers see feedback with the reviewer)",
+	"매니저 전용": "Managers only",
+	"발표자가 받은 피드백을 누가 볼 수 있는지 선택해주세요.": "Select who can see the feedback speakers receive.",
+	"작성자": "Reviewer",
+	"지원하는 피드백 방식: %s, %s, %s": "supported feedback modes: %s, %s, %s",
+	"피드백 내용을 확인할 발표자를 선택해주세요. 익명 피드백의 작성자는 표시되지 않습니다.": "Select the speaker whose feedback to read. Reviewers of anonymous feedback are not shown.",
+	"피드백 방식 설정":      "Set feedback mode",
+	"피드백 방식":         "Feedback mode",
+	"피드백 방식을 선택해주세요": "select the feedback mode",
+	"피드백 방식이 %s(으)로 설정되었습니다. 이미 작성된 피드백은 작성 당시의 방식을 따릅니다.": "Feedback mode has been set to %s. Feedback already written keeps the mode it was written in.",
+	"피드백 작성 (%s)": "Write feedback (%s)",
+	"피드백 작성 시간이 만료되었습니다. 다시 시도해주세요":  "the feedback form has expired, please try again",
+	"피드백 정보를 찾을 수 없습니다":              "feedback not found",
+	"피드백이 저장되었습니다. 매니저만 확인할 수 있습니다.": "The feedback has been saved. Only managers can read it.",
+
+	// review assignment
+	"%s 리뷰 배정 현황": "%s review assignments",
+	"%s 리뷰 배정":    "%s review assignment",
+	"리뷰 시작 단계에서 리뷰어마다 발표자 %d명이 배정됩니다.":  "Each reviewer will be assigned %d speaker(s) when reviews open.",
+	"리뷰어 %d명 중 %d명이 배정된 피드백을 모두 보냈습니다.": "%[2]d of %[1]d reviewers have sent all assigned feedback.",
+	"리뷰어 다시 배정":                          "Assign reviewers again",
+	"리뷰어 배정 (%d명)":                       "Reviewers assigned (%d)",
+	"리뷰어 배정":                             "Reviewer assignment",
+	"리뷰어 배정이 불가능한 단계입니다":                 "reviewers can't be assigned in this stage",
+	"리뷰어 배정이 해제되었습니다.":                   "Reviewer assignment has been turned off.",
+	"리뷰어가 배정되지 않았습니다":                    "reviewers are not assigned",
+	"리뷰어당 발표자 수 설정":                      "Set speakers per reviewer",
+	"리뷰어마다 발표자 %d명 (배정된 발표자만 피드백)":       "%d speaker(s) per reviewer (assigned speakers only)",
+	"리뷰어마다 발표자 %d명":                      "%d speaker(s) per reviewer",
+	"리뷰어마다 배정할 발표자 수를 먼저 설정해주세요":         "set the number of speakers per reviewer first",
+	"리뷰어마다 배정할 발표자 수입니다. 0이면 배정하지 않습니다.": "Number of speakers assigned to each reviewer. 0 turns assignment off.",
+	"미사용": "Off",
+	"발표자마다 피드백이 고르게 돌아가도록 리뷰어를 배정합니다. 매니저만 사용할 수 있습니다.": "Assign reviewers so every speaker gets feedback evenly. Managers only.",
+	"배정 현황": "Show assignments",
+	"배정과 관계없이 모든 발표자에게 피드백을 보낼 수 있습니다.":  "Feedback can be sent to any speaker regardless of assignments.",
+	"배정된 발표자만 피드백 허용":                    "Allow feedback to assigned speakers only",
+	"배정된 발표자에게만 피드백을 보낼 수 있게 할지 선택해주세요.": "Choose whether feedback can be sent only to assigned speakers.",
+	"배정된 발표자에게만 피드백을 보낼 수 있습니다":          "you can send feedback only to the speakers assigned to you",
+	"배정된 발표자에게만 피드백을 보낼 수 있습니다.":         "Feedback can be sent only to assigned speakers.",
+	"배정할 발표자 수는 0부터 %d 사이여야 합니다":         "speakers per reviewer must be between 0 and %d",
+	"배정할 발표자 수를 입력해주세요":                  "enter the number of speakers per reviewer",
+	"아래 발표자에게 /피드백 명령어로 피드백을 보내주세요.":     "Send feedback to the speakers below with /feedback.",
+	"출석한 발표자가 없어 리뷰어를 배정할 수 없습니다":        "no attended speakers to assign",
+	"피드백 제한 여부를 선택해주세요":                  "choose whether to restrict feedback",
+
+	// reminders
+	"%d. %s 마감 %s 전 · %s": "%d. %[3]s before the %[2]s deadline · %[4]s",
+	"%s (%s 남음)":          "%s (%s left)",
+	"%s 리마인더":             "%s reminder",
+	"/리마인더 명령어로 리마인더 DM을 끌 수 있습니다.":              "You can turn off reminder DMs with /reminder.",
+	"리마인더 DM 받기 설정":                              "Set whether to receive reminder DMs",
+	"리마인더 DM을 받습니다.":                             "You will receive reminder DMs.",
+	"리마인더 DM을 받으려면 True, 받지 않으려면 False를 선택해주세요.": "Choose True to receive reminder DMs, False to stop them.",
+	"리마인더 DM을 받지 않습니다. /리마인더 명령어로 다시 받을 수 있습니다.": "You will not receive reminder DMs. You can turn them back on with /reminder.",
+	"리마인더 목록":            "List reminders",
+	"리마인더 발송 (%d명)":      "Reminder sent (%d members)",
+	"리마인더 삭제":            "Remove reminder",
+	"리마인더 설정을 찾을 수 없습니다": "Reminder not found",
+	"리마인더 수신 여부를 설정할 사용자 ID가 없습니다": "No user ID to set reminder preference for",
+	"리마인더 추가": "Add reminder",
+	"리마인더":    "Reminders",
+	"리마인더는 마감 시간 이전이어야 합니다":                                    "Reminders must be sent before the deadline",
+	"리마인더는 최대 %d개까지 설정할 수 있습니다":                                "Up to %d reminders can be set",
+	"리마인더를 받을 멤버를 선택해주세요.":                                     "Choose the members to remind.",
+	"리마인더를 받지 않는 멤버: %d명":                                      "Members opted out of reminders: %d",
+	"리마인더를 보낼 진행 단계를 선택해주세요":                                   "Choose the stage to send reminders for",
+	"마감 몇 시간 전에 보낼지 24h, 90m 형식으로 입력해주세요.":                     "How long before the deadline to send, e.g. 24h or 90m.",
+	"마감 시간을 기준으로 리마인더를 보낼 진행 단계를 선택해주세요.":                      "Choose the stage whose deadline the reminder is based on.",
+	"마감 전에 할 일이 남은 멤버에게 DM을 보내는 리마인더를 설정합니다. 매니저만 사용할 수 있습니다.": "Set reminders that DM members with outstanding work before the deadline. Managers only.",
+	"마감": "Deadline",
+	"발표 자료를 아직 제출하지 않았습니다. /발표-자료-제출 명령어로 제출해주세요.": "You have not submitted your content yet. Please submit it with /submit-content.",
+	"발표 자료를 제출하지 않은 발표자":                           "Speakers without submitted content",
+	"발표회고를 아직 작성하지 않았습니다. /발표회고 명령어로 작성해주세요.":      "You have not written your reflection yet. Please write it with /reflection.",
+	"보낼 리마인더가 없습니다":                                "No reminder is due",
+	"삭제할 리마인더의 번호를 입력해주세요":                         "Enter the number of the reminder to remove",
+	"삭제할 리마인더의 번호입니다. 리마인더 목록에서 확인할 수 있습니다.":       "Number of the reminder to remove. See the reminder list.",
+	"설정된 리마인더가 없습니다.":                              "No reminders are set.",
+	"아직 보내지 않은 피드백이 있습니다. /피드백 명령어로 피드백을 보내주세요.":   "You have feedback left to send. Please send it with /feedback.",
+	"제출하지 않은 발표 자료, 피드백, 회고를 알려주는 DM을 받을지 설정합니다.":  "Set whether to receive DMs about unsubmitted content, feedback and reflections.",
+	"지원하는 리마인더 대상: %s, %s, %s":                     "Supported reminder targets: %s, %s, %s",
+	"진행 단계, 대상, 시간은 필수입니다":                         "Stage, target and time are required",
+	"피드백을 보내지 않은 멤버":                               "Members who have not sent feedback",
+	"회고를 작성하지 않은 발표자":                              "Speakers without a reflection",
+
+	// reminder count
+	"%d개": "%d",
+
+	// progress dashboard
+	"%d명에게 %s 알림을 보냈습니다.":   "Sent %[2]s nudges to %[1]d members.",
+	"%s 알림 (%d명)":           "Nudge: %s (%d)",
+	"%s 진행 현황":              "%s progress",
+	"**<%s>** 단계 · 발표자 %d명": "Stage **<%s>** · %d speakers",
+	"리마인더를 받지 않는 멤버에게는 알림을 보내지 않습니다.": "Members opted out of reminders are not nudged.",
+	"발표 자료 미제출 (%d)":   "Content missing (%d)",
+	"발표 자료 제출 (%d)":    "Content submitted (%d)",
+	"알림을 보낼 멤버가 없습니다.": "There are no members to nudge.",
+	"잘못된 알림 정보입니다":     "Invalid nudge information",
+	"진행중인 라운드에서 할 일이 남은 멤버를 확인하고 알림을 보냅니다. 매니저만 사용할 수 있습니다.": "Check members with outstanding work in the ongoing round and nudge them. Managers only.",
+	"피드백 미작성 (%d)": "Feedback missing (%d)",
+	"회고 미작성 (%d)":  "Reflection missing (%d)",
+
+	// reminder after deadline
+	"%s (마감 지남)": "%s (deadline passed)",
+}
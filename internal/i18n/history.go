@@ -0,0 +1,78 @@
+package i18n
+
+import "github.com/piatoss3612/my-study-bot/internal/study"
+
+// RoundEvent describes the event of the round for timeline, times are rendered by the clock
+func (l Localizer) RoundEvent(e study.RoundEvent, c study.Clock) string {
+	switch e.Type {
+	case study.RoundEventCreated:
+		return l.T("라운드 생성: %s (참여자 %d명)", e.Data.Title, len(e.Data.MemberIDs))
+	case study.RoundEventStageMoved:
+		return l.T("단계 이동: %s", l.Stage(e.Data.Stage))
+	case study.RoundEventContentSubmitted:
+		return l.T("발표 영상 등록")
+	case study.RoundEventDeadlineSet:
+		return l.T("마감 설정: %s (%s)", l.Stage(e.Data.Stage), c.FormatDateTime(e.Data.Deadline))
+	case study.RoundEventDeadlineRemoved:
+		return l.T("마감 해제: %s", l.Stage(e.Data.Stage))
+	case study.RoundEventStageRolledBack:
+		return l.T("단계 되돌림: %s", l.Stage(e.Data.Stage))
+	case study.RoundEventPaused:
+		return l.T("라운드 일시 중지")
+	case study.RoundEventResumed:
+		return l.T("라운드 재개")
+	case study.RoundEventCancelled:
+		if e.Data.Reason != "" {
+			return l.T("라운드 취소: %s", e.Data.Reason)
+		}
+		return l.T("라운드 취소")
+	case study.RoundEventSpeakerCapSet:
+		if e.Data.SpeakerCap == 0 {
+			return l.T("발표자 정원 해제")
+		}
+		return l.T("발표자 정원: %d명", e.Data.SpeakerCap)
+	case study.RoundEventWaitlistReordered:
+		return l.T("대기열 순서 변경")
+	case study.RoundEventLineupSet:
+		return l.T("발표 순서 결정 (%d명)", len(e.Data.MemberIDs))
+	case study.RoundEventAttendanceReviewed:
+		return l.T("출석 검토 완료 (%d명 참여)", len(e.Data.MemberIDs))
+	case study.RoundEventReviewersAssigned:
+		return l.T("리뷰어 배정 (%d명)", len(e.Data.Assignments))
+	case study.RoundEventReminderSent:
+		return l.T("리마인더 발송 (%d명)", len(e.Data.MemberIDs))
+	case study.RoundEventMemberRegistered:
+		return l.T("발표자 등록: %s", e.Data.Name)
+	case study.RoundEventMemberWaitlisted:
+		return l.T("대기열 등록: %s", e.Data.Name)
+	case study.RoundEventMemberPromoted:
+		return l.T("대기열에서 등록: <@%s>", e.MemberID)
+	case study.RoundEventMemberWithdrawn:
+		return l.T("발표자 등록 취소: <@%s>", e.MemberID)
+	case study.RoundEventMemberEdited:
+		return l.T("발표자 정보 수정: <@%s>", e.MemberID)
+	case study.RoundEventMemberReset:
+		return l.T("발표자 상태 초기화: <@%s> (%s)", e.MemberID, l.MemberFlag(e.Data.Flag))
+	case study.RoundEventTalkStarted:
+		return l.T("발표 시작: <@%s>", e.MemberID)
+	case study.RoundEventTalkEnded:
+		return l.T("발표 종료: <@%s>", e.MemberID)
+	case study.RoundEventVoiceJoined:
+		return l.T("음성 채널 입장: <@%s>", e.MemberID)
+	case study.RoundEventVoiceLeft:
+		return l.T("음성 채널 퇴장: <@%s>", e.MemberID)
+	case study.RoundEventMemberContentSubmitted:
+		return l.T("발표 자료 제출: <@%s>", e.MemberID)
+	case study.RoundEventMemberAttended:
+		if e.Data.Share > 0 {
+			return l.T("발표 참여: <@%s> (음성 채널 %d%%)", e.MemberID, e.Data.Share)
+		}
+		return l.T("발표 참여: <@%s>", e.MemberID)
+	case study.RoundEventReviewerSet:
+		return l.T("피드백: <@%s> → <@%s>", e.Data.ReviewerID, e.MemberID)
+	case study.RoundEventReflectionSent:
+		return l.T("회고 작성: <@%s>", e.MemberID)
+	default:
+		return string(e.Type)
+	}
+}
@@ -0,0 +1,317 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+)
+
+// messages are written in korean in the code and used as keys of catalogs of other locales
+var catalogs = map[study.Locale]map[string]string{
+	study.LocaleEnglish: en,
+}
+
+// names of commands and options, which should be lowercase without spaces
+var nameCatalogs = map[study.Locale]map[string]string{
+	study.LocaleEnglish: enNames,
+}
+
+// discord locales of each supported locale
+var discordLocales = map[study.Locale][]discordgo.Locale{
+	study.LocaleKorean:  {discordgo.Korean},
+	study.LocaleEnglish: {discordgo.EnglishUS, discordgo.EnglishGB},
+}
+
+// Localizer translates korean messages to the locale
+type Localizer struct {
+	locale study.Locale
+}
+
+func New(locale study.Locale) Localizer {
+	if _, ok := discordLocales[locale]; !ok {
+		locale = study.DefaultLocale
+	}
+	return Localizer{locale: locale}
+}
+
+// FromInteraction resolves locale from the user, then from the guild
+func FromInteraction(i *discordgo.InteractionCreate) Localizer {
+	if l, ok := fromDiscordLocale(i.Locale); ok {
+		return New(l)
+	}
+
+	if i.GuildLocale != nil {
+		if l, ok := fromDiscordLocale(*i.GuildLocale); ok {
+			return New(l)
+		}
+	}
+
+	return New(study.DefaultLocale)
+}
+
+func fromDiscordLocale(dl discordgo.Locale) (study.Locale, bool) {
+	for l, dls := range discordLocales {
+		for _, d := range dls {
+			if d == dl {
+				return l, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (l Localizer) Locale() study.Locale {
+	return l.locale
+}
+
+// T translates the message, args are formatted into the translated message
+func (l Localizer) T(msg string, args ...any) string {
+	if c, ok := catalogs[l.locale]; ok {
+		if translated, ok := c[msg]; ok {
+			msg = translated
+		}
+	}
+
+	if len(args) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf(msg, args...)
+}
+
+func (l Localizer) Stage(s study.Stage) string {
+	return l.T(s.String())
+}
+
+// names of member flags
+var memberFlags = map[study.MemberFlag]string{
+	study.MemberFlagContent:    "발표 자료",
+	study.MemberFlagAttendance: "발표 참여",
+	study.MemberFlagReviewers:  "피드백",
+	study.MemberFlagReflection: "회고",
+}
+
+func (l Localizer) MemberFlag(f study.MemberFlag) string {
+	name, ok := memberFlags[f]
+	if !ok {
+		return string(f)
+	}
+	return l.T(name)
+}
+
+// names of feedback modes
+var feedbackModes = map[study.FeedbackMode]string{
+	study.FeedbackModeNamed:     "실명",
+	study.FeedbackModeAnonymous: "익명",
+	study.FeedbackModeManagers:  "매니저 전용",
+}
+
+func (l Localizer) FeedbackMode(m study.FeedbackMode) string {
+	name, ok := feedbackModes[m]
+	if !ok {
+		return string(m)
+	}
+	return l.T(name)
+}
+
+// names of reminder targets
+var reminderTargets = map[study.ReminderTarget]string{
+	study.ReminderTargetContent:    "발표 자료 제출",
+	study.ReminderTargetFeedback:   "피드백",
+	study.ReminderTargetReflection: "회고",
+}
+
+func (l Localizer) ReminderTarget(t study.ReminderTarget) string {
+	name, ok := reminderTargets[t]
+	if !ok {
+		return string(t)
+	}
+	return l.T(name)
+}
+
+// Error translates messages joined in the error which are safe to show, false is returned if any of them is hidden
+func (l Localizer) Error(err error) (string, bool) {
+	msgs := []string{}
+	safe := true
+
+	for _, e := range study.Errors(err) {
+		var se *study.Error
+		if !errors.As(e, &se) || !se.Safe {
+			safe = false
+			continue
+		}
+
+		args := make([]any, 0, len(se.Args))
+		for _, arg := range se.Args {
+			if s, ok := arg.(string); ok {
+				arg = l.Text(s)
+			}
+			args = append(args, arg)
+		}
+
+		msgs = append(msgs, l.T(se.Message, args...))
+	}
+
+	return strings.Join(msgs, "\n"), safe
+}
+
+// Text translates a message which may have been formatted already, each line is translated separately
+func (l Localizer) Text(text string) string {
+	c, ok := catalogs[l.locale]
+	if !ok {
+		return text
+	}
+
+	if strings.Contains(text, "\n") {
+		lines := strings.Split(text, "\n")
+		for i := range lines {
+			lines[i] = l.Text(lines[i])
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	if translated, ok := c[text]; ok {
+		return translated
+	}
+
+	// find a format of the catalog which the text is formatted with
+	for _, p := range patterns(l.locale) {
+		m := p.re.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+
+		args := make([]any, 0, len(m)-1)
+		for _, arg := range m[1:] {
+			args = append(args, l.Text(arg))
+		}
+
+		return fmt.Sprintf(p.translated, args...)
+	}
+
+	return text
+}
+
+type pattern struct {
+	re         *regexp.Regexp
+	translated string
+	literal    int
+}
+
+var (
+	patternsOnce  sync.Once
+	patternsCache map[study.Locale][]pattern
+)
+
+var verb = regexp.MustCompile(`%[sdv]`)
+
+// formats of catalogs as regular expressions, formats are matched with every verb as a string
+func patterns(locale study.Locale) []pattern {
+	patternsOnce.Do(func() {
+		patternsCache = map[study.Locale][]pattern{}
+
+		for l, c := range catalogs {
+			for msg, translated := range c {
+				if !verb.MatchString(msg) {
+					continue
+				}
+
+				parts := verb.Split(msg, -1)
+				for i := range parts {
+					parts[i] = regexp.QuoteMeta(parts[i])
+				}
+
+				patternsCache[l] = append(patternsCache[l], pattern{
+					re:         regexp.MustCompile("^" + strings.Join(parts, "(.+?)") + "$"),
+					translated: verb.ReplaceAllString(translated, "%s"),
+					literal:    len(strings.Join(parts, "")),
+				})
+			}
+
+			// formats with more literal text are more specific, they are matched first
+			sort.SliceStable(patternsCache[l], func(i, j int) bool {
+				return patternsCache[l][i].literal > patternsCache[l][j].literal
+			})
+		}
+	})
+
+	return patternsCache[locale]
+}
+
+// LocalizeCommand sets localizations of the command, its options and choices
+func LocalizeCommand(cmd *discordgo.ApplicationCommand) {
+	cmd.NameLocalizations = nameLocalizations(cmd.Name)
+	cmd.DescriptionLocalizations = localizations(cmd.Description)
+
+	for _, o := range cmd.Options {
+		localizeOption(o)
+	}
+}
+
+func localizeOption(o *discordgo.ApplicationCommandOption) {
+	o.NameLocalizations = *nameLocalizations(o.Name)
+	o.DescriptionLocalizations = *localizations(o.Description)
+
+	for _, c := range o.Choices {
+		c.NameLocalizations = *localizations(c.Name)
+	}
+
+	for _, sub := range o.Options {
+		localizeOption(sub)
+	}
+}
+
+func localizations(msg string) *map[discordgo.Locale]string {
+	return localize(catalogs, msg)
+}
+
+func nameLocalizations(name string) *map[discordgo.Locale]string {
+	return localize(nameCatalogs, name)
+}
+
+func localize(cs map[study.Locale]map[string]string, msg string) *map[discordgo.Locale]string {
+	m := map[discordgo.Locale]string{}
+
+	for l, c := range cs {
+		translated, ok := c[msg]
+		if !ok {
+			continue
+		}
+
+		for _, dl := range discordLocales[l] {
+			m[dl] = translated
+		}
+	}
+
+	return &m
+}
+
+// Duration formats the duration in hours, minutes and seconds
+func (l Localizer) Duration(d time.Duration) string {
+	d = d.Truncate(time.Second)
+
+	h := int(d / time.Hour)
+	m := int(d % time.Hour / time.Minute)
+	s := int(d % time.Minute / time.Second)
+
+	parts := []string{}
+
+	if h > 0 {
+		parts = append(parts, l.T("%d시간", h))
+	}
+
+	if h > 0 || m > 0 {
+		parts = append(parts, l.T("%d분", m))
+	}
+
+	parts = append(parts, l.T("%d초", s))
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,103 @@
+package dedup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/piatoss3612/my-study-bot/internal/cache"
+)
+
+var defaultTTL = 7 * 24 * time.Hour
+
+// Store keeps ids of handled events to skip redelivered ones
+type Store interface {
+	Seen(ctx context.Context, id string) (bool, error)
+	Mark(ctx context.Context, id string) error
+}
+
+type cacheStore struct {
+	c   cache.Cache
+	ttl time.Duration
+}
+
+// NewCacheStore returns a store backed by cache (e.g. redis)
+func NewCacheStore(c cache.Cache, ttl ...time.Duration) Store {
+	s := &cacheStore{
+		c:   c,
+		ttl: defaultTTL,
+	}
+
+	if len(ttl) > 0 {
+		s.ttl = ttl[0]
+	}
+
+	return s
+}
+
+func (s *cacheStore) Seen(ctx context.Context, id string) (bool, error) {
+	return s.c.Exists(ctx, key(id)), nil
+}
+
+func (s *cacheStore) Mark(ctx context.Context, id string) error {
+	return s.c.Set(ctx, key(id), true, s.ttl)
+}
+
+type memoryStore struct {
+	ids map[string]time.Time
+	ttl time.Duration
+
+	mtx sync.Mutex
+}
+
+// NewMemoryStore returns a store which keeps ids in memory, ids are lost on restart
+func NewMemoryStore(ttl ...time.Duration) Store {
+	s := &memoryStore{
+		ids: make(map[string]time.Time),
+		ttl: defaultTTL,
+	}
+
+	if len(ttl) > 0 {
+		s.ttl = ttl[0]
+	}
+
+	return s
+}
+
+func (s *memoryStore) Seen(_ context.Context, id string) (bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	expiresAt, ok := s.ids[id]
+	if !ok {
+		return false, nil
+	}
+
+	if time.Now().After(expiresAt) {
+		delete(s.ids, id)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (s *memoryStore) Mark(_ context.Context, id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := time.Now()
+
+	// remove expired ids
+	for k, expiresAt := range s.ids {
+		if now.After(expiresAt) {
+			delete(s.ids, k)
+		}
+	}
+
+	s.ids[id] = now.Add(s.ttl)
+	return nil
+}
+
+func key(id string) string {
+	return "event:" + id
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/piatoss3612/my-study-bot/internal/logger/dedup"
 	"github.com/piatoss3612/my-study-bot/internal/pubsub"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -40,23 +42,54 @@ var duration = prometheus.NewHistogramVec(
 	[]string{"event"},
 )
 
+var duplicateEvents = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "listener_duplicate_events_total",
+		Help: "Total number of duplicate events skipped.",
+	},
+	[]string{"event"},
+)
+
+var nackedEvents = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "listener_nacked_events_total",
+		Help: "Total number of events rejected to be retried or dead-lettered.",
+	},
+	[]string{"event"},
+)
+
 var metricServerPort = "8080"
 
 type LoggerService struct {
 	sub    pubsub.Subscriber
 	mapper pubsub.Mapper
+	dedup  dedup.Store
 
 	srv *http.Server
 
 	sugar *zap.SugaredLogger
 }
 
-func New(sub pubsub.Subscriber, mapper pubsub.Mapper, sugar *zap.SugaredLogger) *LoggerService {
+type LoggerServiceOptsFunc func(*LoggerService)
+
+func WithDedupStore(store dedup.Store) LoggerServiceOptsFunc {
+	return func(l *LoggerService) {
+		l.dedup = store
+	}
+}
+
+func New(sub pubsub.Subscriber, mapper pubsub.Mapper, sugar *zap.SugaredLogger, opts ...LoggerServiceOptsFunc) *LoggerService {
 	svc := &LoggerService{
 		sub:    sub,
 		mapper: mapper,
+		dedup:  dedup.NewMemoryStore(),
 		sugar:  sugar,
 	}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
 	return svc.setup()
 }
 
@@ -66,6 +99,8 @@ func (l *LoggerService) setup() *LoggerService {
 	metrics.MustRegister(totalEvents)
 	metrics.MustRegister(totalErrors)
 	metrics.MustRegister(duration)
+	metrics.MustRegister(duplicateEvents)
+	metrics.MustRegister(nackedEvents)
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(metrics, promhttp.HandlerOpts{}))
@@ -124,28 +159,31 @@ func (l *LoggerService) Listen(stop <-chan bool, topics []string) {
 
 	for {
 		select {
-		case msg := <-msgs:
-			h, ok := l.mapper.Map(msg.Topic)
+		case msg, ok := <-msgs:
+			// subscription has been closed by the subscriber
 			if !ok {
-				l.sugar.Errorw("Unknown event name", "event", msg.Topic)
-				continue
+				l.sugar.Info("Subscription is closed")
+				return
 			}
 
-			totalEvents.WithLabelValues(msg.Topic).Inc()
+			// ack only when the event is handled, otherwise it will be retried
+			if err := l.handle(msg); err != nil {
+				nackedEvents.WithLabelValues(msg.Topic).Inc()
 
-			timer := prometheus.NewTimer(duration.WithLabelValues(msg.Topic))
-
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-
-			if err := h.Handle(ctx, msg.Body); err != nil {
-				totalErrors.WithLabelValues(msg.Topic).Inc()
-				l.sugar.Errorw("Failed to handle event", "event", msg.Topic, "error", err, "duration", timer.ObserveDuration().String())
+				if err := msg.Nack(); err != nil {
+					l.sugar.Errorw("Failed to nack event", "event", msg.Topic, "error", err)
+				}
 				continue
 			}
 
-			l.sugar.Infow("Successfully handled event", "event", msg.Topic, "duration", timer.ObserveDuration().String())
-		case err := <-errs:
+			if err := msg.Ack(); err != nil {
+				l.sugar.Errorw("Failed to ack event", "event", msg.Topic, "error", err)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
 			if err == nil {
 				continue
 			}
@@ -158,3 +196,72 @@ func (l *LoggerService) Listen(stop <-chan bool, topics []string) {
 		}
 	}
 }
+
+func (l *LoggerService) handle(msg pubsub.Message) error {
+	h, ok := l.mapper.Map(msg.Topic)
+	if !ok {
+		l.sugar.Errorw("Unknown event name", "event", msg.Topic)
+		return fmt.Errorf("unknown event: %s", msg.Topic)
+	}
+
+	totalEvents.WithLabelValues(msg.Topic).Inc()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	id := eventID(msg.Body)
+
+	// skip events already handled
+	if l.isDuplicate(ctx, id) {
+		duplicateEvents.WithLabelValues(msg.Topic).Inc()
+		l.sugar.Infow("Skip duplicate event", "event", msg.Topic, "id", id)
+		return nil
+	}
+
+	timer := prometheus.NewTimer(duration.WithLabelValues(msg.Topic))
+
+	if err := h.Handle(ctx, msg.Body); err != nil {
+		totalErrors.WithLabelValues(msg.Topic).Inc()
+		l.sugar.Errorw("Failed to handle event", "event", msg.Topic, "id", id, "error", err, "duration", timer.ObserveDuration().String())
+		return err
+	}
+
+	// mark event as handled only after it succeeded
+	if id != "" {
+		if err := l.dedup.Mark(ctx, id); err != nil {
+			l.sugar.Errorw("Failed to mark event as handled", "event", msg.Topic, "id", id, "error", err)
+		}
+	}
+
+	l.sugar.Infow("Successfully handled event", "event", msg.Topic, "id", id, "duration", timer.ObserveDuration().String())
+
+	return nil
+}
+
+// check if the event has been handled, events without id are always handled
+func (l *LoggerService) isDuplicate(ctx context.Context, id string) bool {
+	if id == "" {
+		return false
+	}
+
+	seen, err := l.dedup.Seen(ctx, id)
+	if err != nil {
+		l.sugar.Errorw("Failed to check duplicate event", "id", id, "error", err)
+		return false
+	}
+
+	return seen
+}
+
+// get id of the event from message body
+func eventID(body []byte) string {
+	var evt struct {
+		ID string `json:"id"`
+	}
+
+	if err := json.Unmarshal(body, &evt); err != nil {
+		return ""
+	}
+
+	return evt.ID
+}
@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/piatoss3612/my-study-bot/internal/pubsub"
+)
+
+var (
+	defaultMaxRetries = 3
+	defaultRetryDelay = 1 * time.Second
+)
+
+// Broker delivers messages from publishers to subscribers in the same process
+type Broker struct {
+	subs map[string][]chan pubsub.Message
+	mtx  sync.RWMutex
+
+	maxRetries int
+	retryDelay time.Duration
+
+	dead   []pubsub.Message
+	deadMu sync.Mutex
+}
+
+type BrokerOptsFunc func(*Broker)
+
+func WithMaxRetries(n int) BrokerOptsFunc {
+	return func(b *Broker) {
+		b.maxRetries = n
+	}
+}
+
+func WithRetryDelay(d time.Duration) BrokerOptsFunc {
+	return func(b *Broker) {
+		b.retryDelay = d
+	}
+}
+
+func NewBroker(opts ...BrokerOptsFunc) *Broker {
+	b := &Broker{
+		subs:       make(map[string][]chan pubsub.Message),
+		maxRetries: defaultMaxRetries,
+		retryDelay: defaultRetryDelay,
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// DeadLetters returns messages rejected after every retry
+func (b *Broker) DeadLetters() []pubsub.Message {
+	b.deadMu.Lock()
+	defer b.deadMu.Unlock()
+
+	letters := make([]pubsub.Message, len(b.dead))
+	copy(letters, b.dead)
+	return letters
+}
+
+func (b *Broker) subscribe(topics []string) (chan pubsub.Message, func()) {
+	ch := make(chan pubsub.Message, 100)
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	for _, topic := range topics {
+		b.subs[topic] = append(b.subs[topic], ch)
+	}
+
+	return ch, func() {
+		b.mtx.Lock()
+		defer b.mtx.Unlock()
+
+		for _, topic := range topics {
+			chs := b.subs[topic]
+			for i, c := range chs {
+				if c == ch {
+					b.subs[topic] = append(chs[:i], chs[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+}
+
+func (b *Broker) publish(topic string, body []byte) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	for _, ch := range b.subs[topic] {
+		b.deliver(ch, topic, body, 0)
+	}
+}
+
+// deliver message without blocking publisher, nacked message is redelivered with exponential backoff
+func (b *Broker) deliver(ch chan pubsub.Message, topic string, body []byte, retries int) {
+	msg := pubsub.NewMessage(topic, body,
+		func() error { return nil },
+		func() error {
+			if retries >= b.maxRetries {
+				b.deadMu.Lock()
+				b.dead = append(b.dead, pubsub.NewMessage(topic, body, nil, nil))
+				b.deadMu.Unlock()
+				return nil
+			}
+
+			delay := b.retryDelay * time.Duration(1<<retries)
+
+			time.AfterFunc(delay, func() {
+				b.mtx.RLock()
+				defer b.mtx.RUnlock()
+
+				b.deliver(ch, topic, body, retries+1)
+			})
+			return nil
+		},
+	)
+
+	send(ch, msg)
+}
+
+// send message in order while the buffer is not full, channel may be closed by unsubscribed subscriber
+func send(ch chan pubsub.Message, msg pubsub.Message) {
+	defer func() { _ = recover() }()
+
+	select {
+	case ch <- msg:
+	default:
+		go func() {
+			defer func() { _ = recover() }()
+			ch <- msg
+		}()
+	}
+}
+
+type publisher struct {
+	b *Broker
+}
+
+func NewPublisher(b *Broker) pubsub.Publisher {
+	return &publisher{b: b}
+}
+
+func (p *publisher) Publish(_ context.Context, k string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	p.b.publish(k, body)
+	return nil
+}
+
+type subscriber struct {
+	b *Broker
+}
+
+func NewSubscriber(b *Broker) pubsub.Subscriber {
+	return &subscriber{b: b}
+}
+
+func (s *subscriber) Subscribe(topics ...string) (<-chan pubsub.Message, <-chan error, func(), error) {
+	msgs, unsubscribe := s.b.subscribe(topics)
+	errs := make(chan error)
+
+	return msgs, errs, func() {
+		unsubscribe()
+		close(msgs)
+		close(errs)
+	}, nil
+}
@@ -24,6 +24,35 @@ type Handler interface {
 type Message struct {
 	Topic string
 	Body  []byte
+
+	ack  func() error
+	nack func() error
+}
+
+// NewMessage returns a message which is acknowledged by ack and rejected by nack
+func NewMessage(topic string, body []byte, ack, nack func() error) Message {
+	return Message{
+		Topic: topic,
+		Body:  body,
+		ack:   ack,
+		nack:  nack,
+	}
+}
+
+// Ack acknowledges the message has been handled
+func (m Message) Ack() error {
+	if m.ack == nil {
+		return nil
+	}
+	return m.ack()
+}
+
+// Nack rejects the message, it may be redelivered depending on the subscriber
+func (m Message) Nack() error {
+	if m.nack == nil {
+		return nil
+	}
+	return m.nack()
 }
 
 type mapper struct {
@@ -0,0 +1,127 @@
+package rabbitmq
+
+import (
+	"context"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type DeadLetter struct {
+	Topic      string
+	Body       []byte
+	Reason     string
+	RetryCount int
+	Timestamp  time.Time
+}
+
+// DeadLetters reads and replays messages in the dead letter queue of the queue
+type DeadLetters struct {
+	conn  *amqp.Connection
+	queue string
+}
+
+func NewDeadLetters(conn *amqp.Connection, queue string) *DeadLetters {
+	return &DeadLetters{
+		conn:  conn,
+		queue: queue,
+	}
+}
+
+// list dead letters without removing them from the queue
+func (dl *DeadLetters) List(limit int) ([]DeadLetter, error) {
+	ch, err := dl.conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	// unacked messages are requeued when the channel is closed
+	defer func() { _ = ch.Close() }()
+
+	letters := []DeadLetter{}
+
+	for limit <= 0 || len(letters) < limit {
+		d, ok, err := ch.Get(DeadLetterQueue(dl.queue), false)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			break
+		}
+
+		letters = append(letters, deadLetterFromDelivery(d))
+	}
+
+	return letters, nil
+}
+
+// publish dead letters back to the queue with retry count reset, returns the number of replayed letters
+func (dl *DeadLetters) Replay(ctx context.Context, limit int) (int, error) {
+	ch, err := dl.conn.Channel()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = ch.Close() }()
+
+	cnt := 0
+
+	for limit <= 0 || cnt < limit {
+		d, ok, err := ch.Get(DeadLetterQueue(dl.queue), false)
+		if err != nil {
+			return cnt, err
+		}
+
+		if !ok {
+			break
+		}
+
+		headers := amqp.Table{}
+		for k, v := range d.Headers {
+			// drop headers added by broker and retries
+			if k == XRetryCountHeader || k == XDeadLetterReasonHeader || k == "x-death" || k == "x-first-death-exchange" ||
+				k == "x-first-death-queue" || k == "x-first-death-reason" {
+				continue
+			}
+			headers[k] = v
+		}
+
+		err = ch.PublishWithContext(ctx, "", dl.queue, false, false, amqp.Publishing{
+			Headers:      headers,
+			ContentType:  d.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Body:         d.Body,
+		})
+		if err != nil {
+			_ = d.Nack(false, true)
+			return cnt, err
+		}
+
+		if err := d.Ack(false); err != nil {
+			return cnt, err
+		}
+
+		cnt++
+	}
+
+	return cnt, nil
+}
+
+func deadLetterFromDelivery(d amqp.Delivery) DeadLetter {
+	letter := DeadLetter{
+		Body:       d.Body,
+		RetryCount: retryCount(d.Headers),
+		Timestamp:  d.Timestamp,
+	}
+
+	if topic, ok := d.Headers[XEventTopicHeader].(string); ok {
+		letter.Topic = topic
+	}
+
+	if reason, ok := d.Headers[XDeadLetterReasonHeader].(string); ok {
+		letter.Reason = reason
+	} else if reason, ok := d.Headers["x-first-death-reason"].(string); ok {
+		letter.Reason = reason
+	}
+
+	return letter
+}
@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/piatoss3612/my-study-bot/internal/pubsub"
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -58,8 +59,10 @@ func (p *publisher) Publish(ctx context.Context, k string, v any) error {
 		Headers: amqp.Table{
 			XEventTopicHeader: k,
 		},
-		ContentType: ContentTypeJson,
-		Body:        body,
+		ContentType:  ContentTypeJson,
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now(),
+		Body:         body,
 	}
 
 	return ch.PublishWithContext(ctx, p.exchange, k, false, false, msg)
@@ -1,25 +1,64 @@
 package rabbitmq
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
 
 	"github.com/piatoss3612/my-study-bot/internal/pubsub"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-var ErrMissingXEventTopicHeader = errors.New("missing x-event-topic header")
+const (
+	XRetryCountHeader       = "x-retry-count"
+	XDeadLetterReasonHeader = "x-dead-letter-reason"
+)
+
+var (
+	ErrMissingXEventTopicHeader = errors.New("missing x-event-topic header")
+
+	defaultMaxRetries = 3
+	defaultRetryDelay = 1 * time.Second
+)
 
 type subscriber struct {
 	conn     *amqp.Connection
 	exchange string
 	queue    string
+
+	maxRetries int
+	retryDelay time.Duration
+}
+
+type SubscriberOptsFunc func(*subscriber)
+
+func WithMaxRetries(n int) SubscriberOptsFunc {
+	return func(s *subscriber) {
+		s.maxRetries = n
+	}
+}
+
+// first retry is delayed by d, then the delay doubles on each retry
+func WithRetryDelay(d time.Duration) SubscriberOptsFunc {
+	return func(s *subscriber) {
+		s.retryDelay = d
+	}
 }
 
-func NewSubscriber(conn *amqp.Connection, exchange, kind, queue string) (pubsub.Subscriber, error) {
+func NewSubscriber(conn *amqp.Connection, exchange, kind, queue string, opts ...SubscriberOptsFunc) (pubsub.Subscriber, error) {
 	sub := &subscriber{
-		conn:     conn,
-		exchange: exchange,
-		queue:    queue,
+		conn:       conn,
+		exchange:   exchange,
+		queue:      queue,
+		maxRetries: defaultMaxRetries,
+		retryDelay: defaultRetryDelay,
+	}
+
+	for _, opt := range opts {
+		opt(sub)
 	}
 
 	return sub.setup(exchange, kind, queue)
@@ -37,11 +76,41 @@ func (s *subscriber) setup(exchange, kind, queue string) (pubsub.Subscriber, err
 		return nil, err
 	}
 
+	// rejected messages are published to dead letter queue through dead letter exchange
+	err = ch.ExchangeDeclare(DeadLetterExchange(queue), amqp.ExchangeFanout, true, false, false, false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ch.QueueDeclare(DeadLetterQueue(queue), true, false, false, false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ch.QueueBind(DeadLetterQueue(queue), "", DeadLetterExchange(queue), false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// queue is declared with the same arguments as before, as redeclaring an existing queue
+	// with different arguments fails, rejected messages are published to dead letter exchange instead
 	_, err = ch.QueueDeclare(queue, true, false, false, false, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	// messages in delay queue expire after the delay and go back to the queue
+	for n := 1; n <= s.maxRetries; n++ {
+		_, err = ch.QueueDeclare(delayQueue(queue, n), true, false, false, false, amqp.Table{
+			"x-message-ttl":             s.delay(n).Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queue,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return s, nil
 }
 
@@ -64,31 +133,157 @@ func (s *subscriber) Subscribe(topics ...string) (<-chan pubsub.Message, <-chan
 
 	msgs := make(chan pubsub.Message)
 	errs := make(chan error)
+	done := make(chan struct{})
+
+	go s.handleMessage(delivery, msgs, errs, done)
 
-	go s.handleMessage(delivery, msgs, errs)
+	// channels of messages and errors are closed by handleMessage after delivery is drained
+	var once sync.Once
 
 	return msgs, errs, func() {
-		_ = ch.Close()
-		close(msgs)
-		close(errs)
+		once.Do(func() {
+			close(done)
+			_ = ch.Close()
+		})
 	}, nil
 }
 
-func (s *subscriber) handleMessage(delivery <-chan amqp.Delivery, msgs chan<- pubsub.Message, errs chan<- error) {
+// deliver messages to the listener, messages are acked or nacked by the listener
+func (s *subscriber) handleMessage(delivery <-chan amqp.Delivery, msgs chan<- pubsub.Message, errs chan<- error, done <-chan struct{}) {
+	defer func() {
+		close(msgs)
+		close(errs)
+	}()
+
 	for d := range delivery {
-		topic, ok := d.Headers[XEventTopicHeader]
+		topic, ok := d.Headers[XEventTopicHeader].(string)
 		if !ok {
-			errs <- ErrMissingXEventTopicHeader
-			_ = d.Nack(false, false)
+			_ = s.deadLetter(d, "missing-topic")
+
+			select {
+			case errs <- ErrMissingXEventTopicHeader:
+			case <-done:
+				return
+			}
 			continue
 		}
 
-		msg := pubsub.Message{
-			Topic: topic.(string),
-			Body:  d.Body,
+		d := d
+
+		msg := pubsub.NewMessage(topic, d.Body,
+			func() error { return d.Ack(false) },
+			func() error { return s.retry(d) },
+		)
+
+		select {
+		case msgs <- msg:
+		case <-done:
+			// unacked messages are requeued when the channel is closed
+			return
 		}
+	}
+}
+
+// send message to delay queue, or to dead letter queue if retries are exhausted
+func (s *subscriber) retry(d amqp.Delivery) error {
+	count := retryCount(d.Headers)
+
+	if count >= s.maxRetries {
+		return s.deadLetter(d, "rejected")
+	}
+
+	ch, err := s.conn.Channel()
+	if err != nil {
+		// requeue to avoid losing the message
+		_ = d.Nack(false, true)
+		return err
+	}
+	defer func() { _ = ch.Close() }()
+
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[XRetryCountHeader] = int32(count + 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = ch.PublishWithContext(ctx, "", delayQueue(s.queue, count+1), false, false, amqp.Publishing{
+		Headers:      headers,
+		ContentType:  d.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    d.Timestamp,
+		Body:         d.Body,
+	})
+	if err != nil {
+		// requeue to avoid losing the message
+		_ = d.Nack(false, true)
+		return err
+	}
+
+	return d.Ack(false)
+}
+
+// publish message to dead letter exchange with the reason and ack it
+func (s *subscriber) deadLetter(d amqp.Delivery, reason string) error {
+	ch, err := s.conn.Channel()
+	if err != nil {
+		_ = d.Nack(false, true)
+		return err
+	}
+	defer func() { _ = ch.Close() }()
+
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[XDeadLetterReasonHeader] = reason
 
-		msgs <- msg
-		_ = d.Ack(false)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = ch.PublishWithContext(ctx, DeadLetterExchange(s.queue), "", false, false, amqp.Publishing{
+		Headers:      headers,
+		ContentType:  d.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    d.Timestamp,
+		Body:         d.Body,
+	})
+	if err != nil {
+		// requeue to avoid losing the message
+		_ = d.Nack(false, true)
+		return err
 	}
+
+	return d.Ack(false)
+}
+
+func (s *subscriber) delay(n int) time.Duration {
+	return s.retryDelay * time.Duration(math.Pow(2, float64(n-1)))
+}
+
+func retryCount(headers amqp.Table) int {
+	switch v := headers[XRetryCountHeader].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
+func DeadLetterExchange(queue string) string {
+	return fmt.Sprintf("%s.dlx", queue)
+}
+
+func DeadLetterQueue(queue string) string {
+	return fmt.Sprintf("%s.dead", queue)
+}
+
+func delayQueue(queue string, n int) string {
+	return fmt.Sprintf("%s.delay.%d", queue, n)
 }
@@ -0,0 +1,60 @@
+package study
+
+import "time"
+
+// AuditLog is a record of an action taken by a manager
+type AuditLog struct {
+	ID        string `bson:"_id,omitempty"`
+	GuildID   string `bson:"guild_id"`
+	ActorID   string `bson:"actor_id"`
+	Action    string `bson:"action"`
+	Details   string `bson:"details"`
+	Succeeded bool   `bson:"succeeded"`
+	Error     string `bson:"error"`
+	ErrorCode Code   `bson:"error_code,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func NewAuditLog() AuditLog {
+	return AuditLog{
+		GuildID:   "",
+		ActorID:   "",
+		Action:    "",
+		Details:   "",
+		Succeeded: true,
+		Error:     "",
+		ErrorCode: "",
+		CreatedAt: time.Now(),
+	}
+}
+
+func (a *AuditLog) SetID(id string) {
+	a.ID = id
+}
+
+func (a *AuditLog) SetGuildID(guildID string) {
+	a.GuildID = guildID
+}
+
+func (a *AuditLog) SetActorID(actorID string) {
+	a.ActorID = actorID
+}
+
+func (a *AuditLog) SetAction(action string) {
+	a.Action = action
+}
+
+func (a *AuditLog) SetDetails(details string) {
+	a.Details = details
+}
+
+// mark the action as failed with the error
+func (a *AuditLog) SetError(err error) {
+	if err == nil {
+		return
+	}
+	a.Succeeded = false
+	a.Error = err.Error()
+	a.ErrorCode = CodeOf(err)
+}
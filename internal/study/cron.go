@@ -0,0 +1,161 @@
+package study
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression of 5 fields: minute hour day-of-month month day-of-week
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// day matches if either of day-of-month and day-of-week matches when both are restricted
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	min, max int
+}
+
+var (
+	cronMinute = cronField{0, 59}
+	cronHour   = cronField{0, 23}
+	cronDom    = cronField{1, 31}
+	cronMonth  = cronField{1, 12}
+	cronDow    = cronField{0, 7} // 0 and 7 are both sunday
+)
+
+// maximum period to search for the next time
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// ParseCron parses a cron expression like "0 14 * * 6"
+func ParseCron(expr string) (CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return CronSchedule{}, errors.Join(ErrInvalidCron, Errorf("일정은 분 시 일 월 요일의 5개 항목으로 입력해주세요"))
+	}
+
+	var cs CronSchedule
+	var err error
+
+	if cs.minute, err = parseCronField(fields[0], cronMinute); err != nil {
+		return CronSchedule{}, err
+	}
+	if cs.hour, err = parseCronField(fields[1], cronHour); err != nil {
+		return CronSchedule{}, err
+	}
+	if cs.dom, err = parseCronField(fields[2], cronDom); err != nil {
+		return CronSchedule{}, err
+	}
+	if cs.month, err = parseCronField(fields[3], cronMonth); err != nil {
+		return CronSchedule{}, err
+	}
+	if cs.dow, err = parseCronField(fields[4], cronDow); err != nil {
+		return CronSchedule{}, err
+	}
+
+	// sunday can be written as 7
+	if cs.dow&(1<<7) != 0 {
+		cs.dow |= 1
+	}
+
+	cs.domAny = fields[2] == "*"
+	cs.dowAny = fields[4] == "*"
+
+	return cs, nil
+}
+
+// parse a field into bitset, supports *, n, a-b, */s, a-b/s and comma separated list of them
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, errors.Join(ErrInvalidCron, Errorf("잘못된 간격입니다: %s", part))
+			}
+			rng, step = part[:i], s
+		}
+
+		lo, hi := f.min, f.max
+
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(bounds[0])
+			hi, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return 0, errors.Join(ErrInvalidCron, Errorf("잘못된 범위입니다: %s", part))
+			}
+		default:
+			n, err := strconv.Atoi(rng)
+			if err != nil {
+				return 0, errors.Join(ErrInvalidCron, Errorf("잘못된 값입니다: %s", part))
+			}
+			lo, hi = n, n
+		}
+
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, errors.Join(ErrInvalidCron, Errorf("허용 범위(%d-%d)를 벗어난 값입니다: %s", f.min, f.max, part))
+		}
+
+		for n := lo; n <= hi; n += step {
+			bits |= 1 << uint(n)
+		}
+	}
+
+	return bits, nil
+}
+
+// Next returns the first time after t that matches the schedule, zero time if there is none
+func (cs CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	limit := t.Add(cronSearchLimit)
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	for t.Before(limit) {
+		if cs.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !cs.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if cs.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if cs.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (cs CronSchedule) matchDay(t time.Time) bool {
+	domMatch := cs.dom&(1<<uint(t.Day())) != 0
+	dowMatch := cs.dow&(1<<uint(t.Weekday())) != 0
+
+	if cs.domAny || cs.dowAny {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
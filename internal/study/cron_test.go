@@ -0,0 +1,154 @@
+package study
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "every minute", expr: "* * * * *"},
+		{name: "weekly", expr: "0 14 * * 6"},
+		{name: "ranges, steps and lists", expr: "0,30 9-18/3 1-15 */2 1-5"},
+		{name: "sunday as 7", expr: "0 0 * * 7"},
+		{name: "missing field", expr: "0 14 * *", wantErr: true},
+		{name: "too many fields", expr: "0 14 * * 6 2024", wantErr: true},
+		{name: "minute out of range", expr: "60 * * * *", wantErr: true},
+		{name: "hour out of range", expr: "0 24 * * *", wantErr: true},
+		{name: "day of month out of range", expr: "0 0 0 * *", wantErr: true},
+		{name: "not a number", expr: "a * * * *", wantErr: true},
+		{name: "zero step", expr: "*/0 * * * *", wantErr: true},
+		{name: "reversed range", expr: "5-1 * * * *", wantErr: true},
+		{name: "broken range", expr: "1- * * * *", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCron) {
+					t.Fatalf("ParseCron(%q) error = %v, want %v", tt.expr, err, ErrInvalidCron)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.expr, err)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	at := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "later in the week",
+			expr: "0 14 * * 6",
+			from: at(2024, time.March, 1, 10, 0), // friday
+			want: at(2024, time.March, 2, 14, 0),
+		},
+		{
+			name: "strictly after the matching time",
+			expr: "0 14 * * 6",
+			from: at(2024, time.March, 2, 14, 0),
+			want: at(2024, time.March, 9, 14, 0),
+		},
+		{
+			name: "seconds are truncated",
+			expr: "* * * * *",
+			from: at(2024, time.March, 1, 10, 0).Add(30 * time.Second),
+			want: at(2024, time.March, 1, 10, 1),
+		},
+		{
+			name: "step of minutes",
+			expr: "*/15 * * * *",
+			from: at(2024, time.March, 1, 10, 7),
+			want: at(2024, time.March, 1, 10, 15),
+		},
+		{
+			name: "next month",
+			expr: "30 9 1 * *",
+			from: at(2024, time.January, 15, 0, 0),
+			want: at(2024, time.February, 1, 9, 30),
+		},
+		{
+			name: "next year",
+			expr: "0 0 1 1 *",
+			from: at(2024, time.March, 1, 0, 0),
+			want: at(2025, time.January, 1, 0, 0),
+		},
+		{
+			name: "sunday as 7",
+			expr: "0 0 * * 7",
+			from: at(2024, time.March, 1, 0, 0),
+			want: at(2024, time.March, 3, 0, 0),
+		},
+		{
+			name: "either day of month or day of week",
+			expr: "0 12 13 * 5",
+			from: at(2024, time.September, 1, 0, 0), // sunday
+			want: at(2024, time.September, 6, 12, 0),
+		},
+		{
+			name: "both day of month and any day of week",
+			expr: "0 12 13 * *",
+			from: at(2024, time.September, 1, 0, 0),
+			want: at(2024, time.September, 13, 12, 0),
+		},
+		{
+			name: "leap day",
+			expr: "0 0 29 2 *",
+			from: at(2025, time.January, 1, 0, 0),
+			want: at(2028, time.February, 29, 0, 0),
+		},
+		{
+			name: "never",
+			expr: "0 0 31 2 *",
+			from: at(2024, time.January, 1, 0, 0),
+			want: time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.expr, err)
+			}
+
+			if got := cs.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNextInLocation(t *testing.T) {
+	seoul := time.FixedZone("KST", 9*60*60)
+
+	cs, err := ParseCron("0 14 * * 6")
+	if err != nil {
+		t.Fatalf("ParseCron error = %v", err)
+	}
+
+	// 2024-03-02 06:00 UTC is saturday 15:00 in seoul, past 14:00 of that day
+	got := cs.Next(time.Date(2024, time.March, 2, 6, 0, 0, 0, time.UTC).In(seoul))
+	want := time.Date(2024, time.March, 9, 14, 0, 0, 0, seoul)
+
+	if !got.Equal(want) {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+}
@@ -1,34 +1,175 @@
 package study
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
+
+// Code is a stable identifier of an error, which does not change with the message
+type Code string
+
+const (
+	CodeInternal Code = "internal"
+)
+
+type Severity int
+
+const (
+	SeverityInfo    Severity = iota // mistakes of users
+	SeverityWarning                 // conflicts or permissions
+	SeverityError                   // bugs or failures of the system
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// Error is a domain error, the message is written in korean and used as a key of the translation catalogs
+type Error struct {
+	Code     Code
+	Message  string
+	Args     []any
+	Severity Severity
+	Safe     bool // whether the message can be shown to users
+}
+
+func newError(code Code, severity Severity, msg string) *Error {
+	return &Error{
+		Code:     code,
+		Message:  msg,
+		Severity: severity,
+		Safe:     true,
+	}
+}
+
+// Errorf returns a detail of an error joined with it, the format is used as a key of the translation catalogs
+func Errorf(format string, args ...any) error {
+	return &Error{
+		Message:  format,
+		Args:     args,
+		Severity: SeverityInfo,
+		Safe:     true,
+	}
+}
+
+func (e *Error) Error() string {
+	if len(e.Args) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf(e.Message, e.Args...)
+}
+
+// unsafe errors are hidden from users
+func (e *Error) unsafe() *Error {
+	e.Safe = false
+	return e
+}
 
 var (
-	ErrStudyExists           = errors.New("이미 진행중인 스터디가 있습니다")
-	ErrRoundExists           = errors.New("이미 진행중인 라운드가 있습니다")
-	ErrInvalidManager        = errors.New("매니저가 아닙니다")
-	ErrStudyNotFound         = errors.New("스터디 정보를 찾을 수 없습니다")
-	ErrRoundNotFound         = errors.New("라운드 정보를 찾을 수 없습니다")
-	ErrInvalidStage          = errors.New("잘못된 스터디 단계입니다")
-	ErrAlreadyRegistered     = errors.New("이미 등록된 발표자입니다")
-	ErrNotRegistered         = errors.New("등록된 발표자가 아닙니다")
-	ErrMemberNotRegistered   = errors.New("등록되지 않은 발표자입니다")
-	ErrMemberNotAttended     = errors.New("참석하지 않은 발표자입니다")
-	ErrMemberNotFound        = errors.New("등록된 사용자 정보를 찾을 수 없습니다")
-	ErrReviewByYourself      = errors.New("자기 자신을 리뷰할 수 없습니다")
-	ErrAlreadySentReflection = errors.New("이미 회고를 작성하셨습니다")
-	ErrNilParams             = errors.New("파라미터가 nil입니다")
-	ErrInvalidUpdateParams   = errors.New("잘못된 업데이트 파라미터입니다")
-	ErrAlreadySentReview     = errors.New("이미 리뷰를 작성하셨습니다")
-	ErrManagerNotFound       = errors.New("매니저 정보를 찾을 수 없습니다")
-	ErrNotManager            = errors.New("매니저만 사용할 수 있는 명령어입니다")
-	ErrUserNotFound          = errors.New("사용자 정보를 찾을 수 없습니다")
-	ErrChannelNotFound       = errors.New("채널 정보를 찾을 수 없습니다")
-	ErrRequiredArgs          = errors.New("필수 인자가 없습니다")
-	ErrInvalidArgs           = errors.New("인자가 올바르지 않습니다")
-	ErrInvalidCommand        = errors.New("올바르지 않은 명령어입니다")
-	ErrRoundAlreadySet       = errors.New("이미 진행중인 스터디 라운드가 있습니다")
-	ErrFeedbackYourself      = errors.New("자기 자신에게 피드백을 보낼 수 없습니다")
-	ErrNilFunc               = errors.New("함수가 nil입니다")
-	ErrUnknownEventTopic     = errors.New("알 수 없는 이벤트 토픽입니다")
-	ErrInvalidEventData      = errors.New("잘못된 이벤트 데이터입니다")
+	ErrStudyExists           = newError("study_exists", SeverityInfo, "이미 진행중인 스터디가 있습니다")
+	ErrRoundExists           = newError("round_exists", SeverityInfo, "이미 진행중인 라운드가 있습니다")
+	ErrInvalidManager        = newError("invalid_manager", SeverityWarning, "매니저가 아닙니다")
+	ErrStudyNotFound         = newError("study_not_found", SeverityInfo, "스터디 정보를 찾을 수 없습니다")
+	ErrRoundNotFound         = newError("round_not_found", SeverityInfo, "라운드 정보를 찾을 수 없습니다")
+	ErrInvalidStage          = newError("invalid_stage", SeverityInfo, "잘못된 스터디 단계입니다")
+	ErrAlreadyRegistered     = newError("already_registered", SeverityInfo, "이미 등록된 발표자입니다")
+	ErrNotRegistered         = newError("not_registered", SeverityInfo, "등록된 발표자가 아닙니다")
+	ErrMemberNotRegistered   = newError("member_not_registered", SeverityInfo, "등록되지 않은 발표자입니다")
+	ErrMemberNotAttended     = newError("member_not_attended", SeverityInfo, "참석하지 않은 발표자입니다")
+	ErrMemberNotFound        = newError("member_not_found", SeverityInfo, "등록된 사용자 정보를 찾을 수 없습니다")
+	ErrReviewByYourself      = newError("review_by_yourself", SeverityInfo, "자기 자신을 리뷰할 수 없습니다")
+	ErrAlreadySentReflection = newError("already_sent_reflection", SeverityInfo, "이미 회고를 작성하셨습니다")
+	ErrNilParams             = newError("nil_params", SeverityError, "파라미터가 nil입니다").unsafe()
+	ErrInvalidUpdateParams   = newError("invalid_update_params", SeverityError, "잘못된 업데이트 파라미터입니다")
+	ErrAlreadySentReview     = newError("already_sent_review", SeverityInfo, "이미 리뷰를 작성하셨습니다")
+	ErrManagerNotFound       = newError("manager_not_found", SeverityInfo, "매니저 정보를 찾을 수 없습니다")
+	ErrNotManager            = newError("not_manager", SeverityWarning, "매니저만 사용할 수 있는 명령어입니다")
+	ErrUserNotFound          = newError("user_not_found", SeverityInfo, "사용자 정보를 찾을 수 없습니다")
+	ErrChannelNotFound       = newError("channel_not_found", SeverityInfo, "채널 정보를 찾을 수 없습니다")
+	ErrRequiredArgs          = newError("required_args", SeverityInfo, "필수 인자가 없습니다")
+	ErrInvalidArgs           = newError("invalid_args", SeverityInfo, "인자가 올바르지 않습니다")
+	ErrInvalidCommand        = newError("invalid_command", SeverityInfo, "올바르지 않은 명령어입니다")
+	ErrRoundAlreadySet       = newError("round_already_set", SeverityInfo, "이미 진행중인 스터디 라운드가 있습니다")
+	ErrFeedbackYourself      = newError("feedback_yourself", SeverityInfo, "자기 자신에게 피드백을 보낼 수 없습니다")
+	ErrNilFunc               = newError("nil_func", SeverityError, "함수가 nil입니다").unsafe()
+	ErrUnknownEventTopic     = newError("unknown_event_topic", SeverityError, "알 수 없는 이벤트 토픽입니다").unsafe()
+	ErrInvalidEventData      = newError("invalid_event_data", SeverityError, "잘못된 이벤트 데이터입니다").unsafe()
+	ErrInvalidDeadline       = newError("invalid_deadline", SeverityInfo, "잘못된 마감 시간입니다")
+	ErrGuildNotFound         = newError("guild_not_found", SeverityInfo, "서버 정보를 찾을 수 없습니다")
+	ErrNotOwner              = newError("not_owner", SeverityWarning, "스터디 소유자만 사용할 수 있는 명령어입니다")
+	ErrAlreadyManager        = newError("already_manager", SeverityInfo, "이미 매니저입니다")
+	ErrVersionConflict       = newError("version_conflict", SeverityWarning, "다른 요청에 의해 정보가 변경되었습니다. 다시 시도해주세요")
+	ErrDeadlineNotPassed     = newError("deadline_not_passed", SeverityInfo, "진행 단계의 마감 시간이 지나지 않았습니다")
+	ErrPipelineNotFound      = newError("pipeline_not_found", SeverityInfo, "존재하지 않는 진행 방식입니다")
+	ErrRoundPaused           = newError("round_paused", SeverityInfo, "일시 중지된 라운드입니다")
+	ErrRoundNotPaused        = newError("round_not_paused", SeverityInfo, "일시 중지된 라운드가 아닙니다")
+	ErrInvalidCron           = newError("invalid_cron", SeverityInfo, "잘못된 반복 일정입니다")
+	ErrSeriesNotFound        = newError("series_not_found", SeverityInfo, "정기 라운드 설정을 찾을 수 없습니다")
+	ErrSeriesNotDue          = newError("series_not_due", SeverityInfo, "정기 라운드 일정이 아직 되지 않았습니다")
+	ErrInvalidTimeZone       = newError("invalid_time_zone", SeverityInfo, "잘못된 시간대입니다")
+	ErrUnsupportedLocale     = newError("unsupported_locale", SeverityInfo, "지원하지 않는 언어입니다")
+	ErrAlreadyWaitlisted     = newError("already_waitlisted", SeverityInfo, "이미 대기열에 등록되어 있습니다")
+	ErrNotWaitlisted         = newError("not_waitlisted", SeverityInfo, "대기열에 등록된 사용자가 아닙니다")
+	ErrNoSpeakers            = newError("no_speakers", SeverityInfo, "등록된 발표자가 없습니다")
+	ErrLineupNotFound        = newError("lineup_not_found", SeverityInfo, "발표 순서가 정해지지 않았습니다")
+	ErrLineupFinished        = newError("lineup_finished", SeverityInfo, "모든 발표가 끝났습니다")
+	ErrVoiceChannelNotSet    = newError("voice_channel_not_set", SeverityInfo, "음성 채널이 설정되지 않았습니다")
+	ErrFeedbackNotFound      = newError("feedback_not_found", SeverityInfo, "받은 피드백이 없습니다")
+	ErrNotAssignedReviewee   = newError("not_assigned_reviewee", SeverityInfo, "배정된 발표자에게만 피드백을 보낼 수 있습니다")
+	ErrReviewersNotAssigned  = newError("reviewers_not_assigned", SeverityInfo, "리뷰어가 배정되지 않았습니다")
+	ErrReminderNotFound      = newError("reminder_not_found", SeverityInfo, "리마인더 설정을 찾을 수 없습니다")
+	ErrReminderNotDue        = newError("reminder_not_due", SeverityInfo, "보낼 리마인더가 없습니다")
+	ErrFeedbackExpired       = newError("feedback_expired", SeverityInfo, "피드백 작성 시간이 만료되었습니다. 다시 시도해주세요")
 )
+
+// Errors returns every error joined in the error
+func Errors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var leaves []error
+		for _, e := range joined.Unwrap() {
+			leaves = append(leaves, Errors(e)...)
+		}
+		return leaves
+	}
+
+	return []error{err}
+}
+
+// CodeOf returns code of the first coded error joined in the error, errors from outside of the domain are internal
+func CodeOf(err error) Code {
+	for _, e := range Errors(err) {
+		var se *Error
+		if errors.As(e, &se) && se.Code != "" {
+			return se.Code
+		}
+	}
+	return CodeInternal
+}
+
+// SeverityOf returns the highest severity of errors joined in the error
+func SeverityOf(err error) Severity {
+	severity := SeverityInfo
+
+	for _, e := range Errors(err) {
+		var se *Error
+		if !errors.As(e, &se) {
+			return SeverityError
+		}
+		if se.Severity > severity {
+			severity = se.Severity
+		}
+	}
+
+	return severity
+}
@@ -1,18 +1,24 @@
 package study
 
-import "time"
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
 
 type EventTopic string
 
 var (
-	EventTopicStudyRoundCreated  EventTopic = "study.round.created"
-	EventTopicStudyRoundProgress EventTopic = "study.round.progress"
-	EventTopicStudyRoundFinished EventTopic = "study.round.finished"
+	EventTopicStudyRoundCreated   EventTopic = "study.round.created"
+	EventTopicStudyRoundProgress  EventTopic = "study.round.progress"
+	EventTopicStudyRoundFinished  EventTopic = "study.round.finished"
+	EventTopicStudyRoundCancelled EventTopic = "study.round.cancelled"
 )
 
 func (t EventTopic) Validate() error {
 	switch t {
-	case EventTopicStudyRoundCreated, EventTopicStudyRoundProgress, EventTopicStudyRoundFinished:
+	case EventTopicStudyRoundCreated, EventTopicStudyRoundProgress, EventTopicStudyRoundFinished,
+		EventTopicStudyRoundCancelled:
 	default:
 		return ErrUnknownEventTopic
 	}
@@ -25,18 +31,29 @@ func (t EventTopic) String() string {
 }
 
 type Event struct {
-	Topic       EventTopic `json:"topic"`
-	Description string     `json:"description"`
-	Timestamp   int64      `json:"timestamp"`
-	Data        []byte     `json:"data"`
+	ID          string     `bson:"id" json:"id"`
+	GuildID     string     `bson:"guild_id" json:"guild_id"`
+	Topic       EventTopic `bson:"topic" json:"topic"`
+	Description string     `bson:"description" json:"description"`
+	Timestamp   int64      `bson:"timestamp" json:"timestamp"`
+	Data        []byte     `bson:"data" json:"data"`
+	TimeZone    string     `bson:"time_zone" json:"time_zone,omitempty"`
+	Locale      Locale     `bson:"locale" json:"locale,omitempty"`
 }
 
-func NewEvent(topic EventTopic, description string, data ...[]byte) (Event, error) {
+func NewEvent(topic EventTopic, guildID, description string, data ...[]byte) (Event, error) {
 	if err := topic.Validate(); err != nil {
 		return Event{}, err
 	}
 
+	id, err := newEventID()
+	if err != nil {
+		return Event{}, err
+	}
+
 	evt := Event{
+		ID:          id,
+		GuildID:     guildID,
 		Topic:       topic,
 		Description: description,
 		Timestamp:   time.Now().Unix(),
@@ -48,3 +65,27 @@ func NewEvent(topic EventTopic, description string, data ...[]byte) (Event, erro
 
 	return evt, nil
 }
+
+// set the time zone and the locale of the study to render times of the event
+func (e *Event) SetClock(timeZone string, locale Locale) {
+	e.TimeZone = timeZone
+	e.Locale = locale
+}
+
+func (e Event) Clock() Clock {
+	return NewClock(e.TimeZone, e.Locale)
+}
+
+// time of the event in the time zone of the study
+func (e Event) Time() time.Time {
+	return e.Clock().In(time.Unix(e.Timestamp, 0))
+}
+
+// generate random id to identify the event across redeliveries
+func newEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,107 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/piatoss3612/my-study-bot/internal/config"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+// NewSinks creates sinks from the configs
+func NewSinks(ctx context.Context, cfgs []config.SinkConfig) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(cfgs))
+
+	for n, cfg := range cfgs {
+		sink, err := NewSink(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		name := cfg.Name
+		if name == "" {
+			name = fmt.Sprintf("%s.%d", cfg.Type, n)
+		}
+
+		sinks = append(sinks, Named(name, sink))
+	}
+
+	return sinks, nil
+}
+
+func NewSink(ctx context.Context, cfg config.SinkConfig) (Sink, error) {
+	switch cfg.Type {
+	case "sheets":
+		return newSheetsSinkFromConfig(ctx, cfg)
+	case "csv":
+		return NewFileSink(cfg.Dir, FileFormatCSV)
+	case "jsonl":
+		return NewFileSink(cfg.Dir, FileFormatJSONL)
+	case "markdown":
+		return NewMarkdownSink(cfg.Dir)
+	case "webhook":
+		return NewWebhookSink(cfg.URL)
+	default:
+		return nil, fmt.Errorf("unknown sink type: %s", cfg.Type)
+	}
+}
+
+// environment variables are used for the values not in the config
+func newSheetsSinkFromConfig(ctx context.Context, cfg config.SinkConfig) (Sink, error) {
+	credentials := cfg.Credentials
+	if credentials == "" {
+		credentials = os.Getenv("SHEETS_CREDENTIALS")
+	}
+
+	s, err := newSheetsService(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	spreadsheetID := cfg.SpreadsheetID
+	if spreadsheetID == "" {
+		spreadsheetID = os.Getenv("SPREADSHEET_ID")
+	}
+
+	progressSheetID := cfg.ProgressSheetID
+	if progressSheetID == 0 {
+		progressSheetID, _ = strconv.ParseInt(os.Getenv("PROGRESS_SHEET_ID"), 10, 64)
+	}
+
+	var opts []SheetsSinkOptsFunc
+
+	if progressSheetID != 0 {
+		opts = append(opts, WithProgressSheetID(progressSheetID))
+	}
+
+	return NewSheetsSink(ctx, s, spreadsheetID, opts...)
+}
+
+func newSheetsService(ctx context.Context, credentials string) (*sheets.Service, error) {
+	b, err := os.ReadFile(credentials)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read client secret file: %w", err)
+	}
+
+	jwt, err := google.JWTConfigFromJSON(b, "https://www.googleapis.com/auth/spreadsheets")
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse client secret file to config: %w", err)
+	}
+
+	return sheets.NewService(ctx, option.WithHTTPClient(jwt.Client(ctx)))
+}
+
+// Topics returns topics of study events recorded by sinks
+func Topics() []string {
+	return []string{
+		study.EventTopicStudyRoundCreated.String(),
+		study.EventTopicStudyRoundFinished.String(),
+		study.EventTopicStudyRoundProgress.String(),
+		study.EventTopicStudyRoundCancelled.String(),
+	}
+}
@@ -0,0 +1,129 @@
+package event
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/piatoss3612/my-study-bot/internal/study"
+)
+
+type FileFormat string
+
+var (
+	FileFormatCSV   FileFormat = "csv"
+	FileFormatJSONL FileFormat = "jsonl"
+)
+
+// fileSink appends events to csv or jsonl files in the directory
+type fileSink struct {
+	dir    string
+	format FileFormat
+
+	mtx sync.Mutex
+}
+
+func NewFileSink(dir string, format FileFormat) (Sink, error) {
+	switch format {
+	case FileFormatCSV, FileFormatJSONL:
+	default:
+		return nil, fmt.Errorf("unknown file format: %s", format)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &fileSink{
+		dir:    dir,
+		format: format,
+	}, nil
+}
+
+// append progress to progress.csv or progress.jsonl
+func (f *fileSink) RecordProgress(_ context.Context, evt study.Event) error {
+	if f.format == FileFormatJSONL {
+		return f.appendJSONL("progress", evt)
+	}
+
+	return f.appendCSV("progress", [][]string{
+		{evt.ID, evt.GuildID, evt.Topic.String(), evt.Description, evt.Time().Format(time.RFC3339)},
+	})
+}
+
+// append round to rounds.jsonl, or its members to rounds.csv
+func (f *fileSink) RecordRound(_ context.Context, r study.Round, c study.Clock) error {
+	r.CreatedAt = c.In(r.CreatedAt)
+	r.UpdatedAt = c.In(r.UpdatedAt)
+
+	if f.format == FileFormatJSONL {
+		return f.appendJSONL("rounds", r)
+	}
+
+	ids := sortedMemberIDs(r)
+	records := make([][]string, 0, len(ids))
+
+	for _, id := range ids {
+		m := r.Members[id]
+		records = append(records, []string{
+			r.GuildID, strconv.Itoa(int(r.Number)), r.Title, r.ContentURL,
+			id, m.Name, m.Subject, m.ContentURL, strconv.FormatBool(m.Attended),
+			r.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return f.appendCSV("rounds", records)
+}
+
+func (f *fileSink) appendJSONL(name string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return f.append(name, func(file *os.File) error {
+		_, err := file.Write(append(b, '\n'))
+		return err
+	})
+}
+
+func (f *fileSink) appendCSV(name string, records [][]string) error {
+	return f.append(name, func(file *os.File) error {
+		w := csv.NewWriter(file)
+		if err := w.WriteAll(records); err != nil {
+			return err
+		}
+		return w.Error()
+	})
+}
+
+func (f *fileSink) append(name string, write func(file *os.File) error) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	path := filepath.Join(f.dir, fmt.Sprintf("%s.%s", name, f.format))
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	return write(file)
+}
+
+func sortedMemberIDs(r study.Round) []string {
+	ids := make([]string, 0, len(r.Members))
+	for id := range r.Members {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
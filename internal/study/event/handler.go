@@ -5,96 +5,65 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
-	"time"
 
+	"github.com/piatoss3612/my-study-bot/internal/logger/dedup"
 	"github.com/piatoss3612/my-study-bot/internal/pubsub"
 	"github.com/piatoss3612/my-study-bot/internal/study"
-	"google.golang.org/api/sheets/v4"
 )
 
-var (
-	defaultProgressSheetID int64 = 1024
-	infoLabelFormat              = &sheets.CellFormat{
-		TextFormat: &sheets.TextFormat{
-			Bold: true,
-			ForegroundColor: &sheets.Color{
-				Red:   1.0,
-				Green: 1.0,
-				Blue:  1.0,
-			},
-		},
-		BackgroundColor: &sheets.Color{
-			Blue: 0.8,
-		},
-		HorizontalAlignment: "CENTER",
-	}
-)
-
-type handler struct {
-	s               *sheets.Service
-	spreadsheetID   string
-	progressSheetID int64
+// Sink records study events to somewhere, times are rendered in the time zone and the locale of the clock
+type Sink interface {
+	RecordProgress(ctx context.Context, evt study.Event) error
+	RecordRound(ctx context.Context, r study.Round, c study.Clock) error
 }
 
-type HandlerOptsFunc func(*handler)
-
-func WithDefaultProgressSheetID() HandlerOptsFunc {
-	return func(h *handler) {
-		h.progressSheetID = defaultProgressSheetID
-	}
+type namedSink struct {
+	Sink
+	name string
 }
 
-func WithProgressSheetID(id int64) HandlerOptsFunc {
-	return func(h *handler) {
-		h.progressSheetID = id
+// Named returns sink with the name, events recorded by the sink are tracked by the name
+func Named(name string, s Sink) Sink {
+	return &namedSink{
+		Sink: s,
+		name: name,
 	}
 }
 
-func New(ctx context.Context, s *sheets.Service, spreadSheetID string, opts ...HandlerOptsFunc) (pubsub.Handler, error) {
-	h := &handler{
-		s:               s,
-		spreadsheetID:   spreadSheetID,
-		progressSheetID: defaultProgressSheetID,
-	}
-
-	for _, opt := range opts {
-		opt(h)
+// name of the sink, unnamed sinks are named after their position
+func sinkName(s Sink, n int) string {
+	if ns, ok := s.(*namedSink); ok {
+		return ns.name
 	}
+	return fmt.Sprintf("sink.%d", n)
+}
 
-	return h.setup(ctx)
+type handler struct {
+	sinks []Sink
+	dedup dedup.Store
 }
 
-// setup progress sheet
-func (h *handler) setup(ctx context.Context) (pubsub.Handler, error) {
-	// get spreadsheet
-	resp, err := h.s.Spreadsheets.Get(h.spreadsheetID).Context(ctx).Do()
-	if err != nil {
-		return nil, err
-	}
+type HandlerOptsFunc func(*handler)
 
-	// check status code
-	if resp.HTTPStatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code while getting spreadsheet: %d", resp.HTTPStatusCode)
+// keep events recorded by each sink in the store, so that retries skip the sinks already succeeded
+func WithDedupStore(store dedup.Store) HandlerOptsFunc {
+	return func(h *handler) {
+		h.dedup = store
 	}
+}
 
-	// check event sheet exists
-	var progressSheetExists bool
-
-	for _, sheet := range resp.Sheets {
-		if sheet.Properties.SheetId == h.progressSheetID {
-			progressSheetExists = true
-		}
+// New returns handler which records events to every sink
+func New(sinks []Sink, opts ...HandlerOptsFunc) pubsub.Handler {
+	h := &handler{
+		sinks: sinks,
+		dedup: dedup.NewMemoryStore(),
 	}
 
-	if !progressSheetExists {
-		// create progress sheet
-		if err := h.createProgressSheet(ctx); err != nil {
-			return nil, err
-		}
+	for _, opt := range opts {
+		opt(h)
 	}
 
-	return h, nil
+	return h
 }
 
 func (h *handler) Handle(ctx context.Context, body []byte) error {
@@ -105,8 +74,10 @@ func (h *handler) Handle(ctx context.Context, body []byte) error {
 	}
 
 	switch evt.Topic {
-	case study.EventTopicStudyRoundCreated, study.EventTopicStudyRoundProgress:
-		return h.recordProgress(ctx, evt)
+	case study.EventTopicStudyRoundCreated, study.EventTopicStudyRoundProgress, study.EventTopicStudyRoundCancelled:
+		return h.each(ctx, evt.ID, func(s Sink) error {
+			return s.RecordProgress(ctx, evt)
+		})
 	case study.EventTopicStudyRoundFinished:
 		var r study.Round
 
@@ -114,384 +85,43 @@ func (h *handler) Handle(ctx context.Context, body []byte) error {
 			return err
 		}
 
-		return h.recordRound(ctx, r)
+		return h.each(ctx, evt.ID, func(s Sink) error {
+			return s.RecordRound(ctx, r, evt.Clock())
+		})
 	default:
 		return errors.Join(study.ErrUnknownEventTopic, fmt.Errorf("unknown event topic: %s", evt.Topic))
 	}
 }
 
-// record round data to spreadsheet
-func (h *handler) recordRound(ctx context.Context, r study.Round) error {
-	addSheetReq := &sheets.AddSheetRequest{
-		Properties: &sheets.SheetProperties{
-			Title:     fmt.Sprintf("%d 라운드: %s", r.Number, r.Title),
-			SheetId:   int64(r.Number),
-			SheetType: "GRID",
-			TabColor: &sheets.Color{
-				Blue: 1.0,
-			},
-		},
-	}
-
-	rows := rowsFromRoundData(r)
-
-	appendCellsReq := &sheets.AppendCellsRequest{
-		SheetId: int64(r.Number),
-		Fields:  "*",
-		Rows:    rows,
-	}
-
-	resp, err := h.s.Spreadsheets.BatchUpdate(h.spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
-		Requests: []*sheets.Request{
-			{
-				AddSheet: addSheetReq, // create sheet
-			},
-			{
-				AppendCells: appendCellsReq, // then add rows
-			},
-		},
-	}).Context(ctx).Do()
-	if err != nil {
-		return err
-	}
-
-	// check status code
-	if resp.HTTPStatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code while adding sheet: %d", resp.HTTPStatusCode)
-	}
-
-	return nil
-}
-
-func (h *handler) recordProgress(ctx context.Context, evt study.Event) error {
-	resp, err := h.s.Spreadsheets.BatchUpdate(h.spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
-		Requests: []*sheets.Request{
-			{
-				AppendCells: &sheets.AppendCellsRequest{
-					SheetId: h.progressSheetID,
-					Fields:  "*",
-					Rows: []*sheets.RowData{
-						{
-							Values: []*sheets.CellData{
-								{
-									UserEnteredValue: &sheets.ExtendedValue{
-										StringValue: func() *string {
-											s := evt.Topic.String()
-											return &s
-										}(),
-									},
-								},
-								{
-									UserEnteredValue: &sheets.ExtendedValue{
-										StringValue: func() *string {
-											s := evt.Description
-											return &s
-										}(),
-									},
-								},
-								{
-									UserEnteredValue: &sheets.ExtendedValue{
-										StringValue: func() *string {
-											s := time.Unix(evt.Timestamp, 0).Format(time.RFC3339)
-											return &s
-										}(),
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}).Context(ctx).Do()
-	if err != nil {
-		return err
-	}
-
-	// check status code
-	if resp.HTTPStatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.HTTPStatusCode)
-	}
-
-	return nil
-}
+// run fn for every sink, a failure of a sink does not stop the others
+// and sinks which have recorded the event are skipped when it is retried
+func (h *handler) each(ctx context.Context, id string, fn func(s Sink) error) error {
+	var errs []error
 
-func (h *handler) createProgressSheet(ctx context.Context) error {
-	addSheetReq := &sheets.AddSheetRequest{
-		Properties: &sheets.SheetProperties{
-			Title:     "진행 로그",
-			SheetId:   h.progressSheetID,
-			SheetType: "GRID",
-		},
-	}
-
-	appendCellsReq := &sheets.AppendCellsRequest{
-		SheetId: h.progressSheetID,
-		Fields:  "*",
-		Rows: []*sheets.RowData{
-			{
-				Values: []*sheets.CellData{
-					{
-						UserEnteredFormat: infoLabelFormat,
-						UserEnteredValue: &sheets.ExtendedValue{
-							StringValue: func() *string {
-								s := "진행 상태"
-								return &s
-							}(),
-						},
-					},
-					{
-						UserEnteredFormat: infoLabelFormat,
-						UserEnteredValue: &sheets.ExtendedValue{
-							StringValue: func() *string {
-								s := "설명"
-								return &s
-							}(),
-						},
-					},
-					{
-						UserEnteredFormat: infoLabelFormat,
-						UserEnteredValue: &sheets.ExtendedValue{
-							StringValue: func() *string {
-								s := "시간"
-								return &s
-							}(),
-						},
-					},
-				},
-			},
-		},
-	}
-
-	resp, err := h.s.Spreadsheets.BatchUpdate(h.spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
-		Requests: []*sheets.Request{
-			{
-				AddSheet: addSheetReq,
-			},
-			{
-				AppendCells: appendCellsReq,
-			},
-		},
-	}).Context(ctx).Do()
-	if err != nil {
-		return err
-	}
-
-	// check status code
-	if resp.HTTPStatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code while adding sheet: %d", resp.HTTPStatusCode)
-	}
-
-	return nil
-}
+	for n, s := range h.sinks {
+		// events without id are always recorded
+		key := ""
+		if id != "" {
+			key = id + "/" + sinkName(s, n)
+		}
 
-func rowsFromRoundData(r study.Round) []*sheets.RowData {
-	rows := []*sheets.RowData{
-		{
-			Values: []*sheets.CellData{
-				{
-					UserEnteredFormat: infoLabelFormat,
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := "제목"
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := r.Title
-							return &s
-						}(),
-					},
-				},
-			},
-		},
-		{
-			Values: []*sheets.CellData{
-				{
-					UserEnteredFormat: infoLabelFormat,
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := "진행 단계"
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := r.Stage.String()
-							return &s
-						}(),
-					},
-				},
-			},
-		},
-		{
-			Values: []*sheets.CellData{
-				{
-					UserEnteredFormat: infoLabelFormat,
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := "녹화 영상"
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredValue: &sheets.ExtendedValue{
-						FormulaValue: func() *string {
-							s := fmt.Sprintf(`=HYPERLINK("%s")`, r.ContentURL)
-							return &s
-						}(),
-					},
-				},
-			},
-		},
-		{
-			Values: []*sheets.CellData{
-				{
-					UserEnteredFormat: infoLabelFormat,
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := "생성"
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := r.CreatedAt.Format(time.RFC3339)
-							return &s
-						}(),
-					},
-				},
-			},
-		},
-		{
-			Values: []*sheets.CellData{
-				{
-					UserEnteredFormat: infoLabelFormat,
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := "최종 수정"
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := r.UpdatedAt.Format(time.RFC3339)
-							return &s
-						}(),
-					},
-				},
-			},
-		},
-		{}, // empty row
-		{
-			Values: []*sheets.CellData{
-				{
-					UserEnteredFormat: infoLabelFormat,
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := "ID"
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredFormat: infoLabelFormat,
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := "이름"
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredFormat: infoLabelFormat,
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := "발표 주제"
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredFormat: infoLabelFormat,
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := "발표 자료"
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredFormat: infoLabelFormat,
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := "발표 참여"
-							return &s
-						}(),
-					},
-				},
-			},
-		},
-	}
+		if key != "" {
+			if seen, err := h.dedup.Seen(ctx, key); err == nil && seen {
+				continue
+			}
+		}
 
-	for id, m := range r.Members {
-		row := &sheets.RowData{
-			Values: []*sheets.CellData{
-				{
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := id
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := m.Name
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredValue: &sheets.ExtendedValue{
-						StringValue: func() *string {
-							s := m.Subject
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredValue: &sheets.ExtendedValue{
-						FormulaValue: func() *string {
-							s := fmt.Sprintf(`=HYPERLINK("%s")`, m.ContentURL)
-							return &s
-						}(),
-					},
-				},
-				{
-					UserEnteredValue: &sheets.ExtendedValue{
-						BoolValue: func() *bool {
-							b := m.Attended
-							return &b
-						}(),
-					},
-				},
-			},
+		if err := fn(s); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sinkName(s, n), err))
+			continue
 		}
 
-		rows = append(rows, row)
+		if key != "" {
+			if err := h.dedup.Mark(ctx, key); err != nil {
+				errs = append(errs, err)
+			}
+		}
 	}
 
-	return rows
+	return errors.Join(errs...)
 }
@@ -0,0 +1,120 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/piatoss3612/my-study-bot/internal/i18n"
+	"github.com/piatoss3612/my-study-bot/internal/study"
+)
+
+// markdownSink writes round reports and progress log as markdown files, e.g. for git wiki
+type markdownSink struct {
+	dir string
+
+	mtx sync.Mutex
+}
+
+func NewMarkdownSink(dir string) (Sink, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &markdownSink{dir: dir}, nil
+}
+
+// append progress to <guild>/progress.md
+func (m *markdownSink) RecordProgress(_ context.Context, evt study.Event) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	dir, err := m.guildDir(evt.GuildID)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, "progress.md")
+
+	// write header for a new file
+	header := ""
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		l := i18n.New(evt.Clock().Locale())
+		header = fmt.Sprintf("# %s\n\n| %s | %s | %s |\n| --- | --- | --- |\n", l.T("진행 로그"), l.T("진행 상태"), l.T("설명"), l.T("시간"))
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	_, err = fmt.Fprintf(file, "%s| %s | %s | %s |\n", header,
+		evt.Topic.String(), escapeMarkdown(evt.Description), evt.Clock().FormatDateTime(evt.Time()))
+	return err
+}
+
+// write report of the round to <guild>/round-<number>.md, overwriting the previous one
+func (m *markdownSink) RecordRound(_ context.Context, r study.Round, c study.Clock) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	dir, err := m.guildDir(r.GuildID)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("round-%d.md", r.Number))
+
+	return os.WriteFile(path, []byte(roundReport(r, c)), 0644)
+}
+
+func (m *markdownSink) guildDir(guildID string) (string, error) {
+	if guildID == "" {
+		guildID = "default"
+	}
+
+	dir := filepath.Join(m.dir, guildID)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func roundReport(r study.Round, c study.Clock) string {
+	sb := strings.Builder{}
+	l := i18n.New(c.Locale())
+
+	sb.WriteString(fmt.Sprintf("# %s\n\n", l.T("%d 라운드: %s", r.Number, escapeMarkdown(r.Title))))
+	sb.WriteString(fmt.Sprintf("- %s: %s\n", l.T("진행 단계"), l.Stage(r.Stage)))
+	sb.WriteString(fmt.Sprintf("- %s: %s\n", l.T("녹화 영상"), r.ContentURL))
+	sb.WriteString(fmt.Sprintf("- %s: %s\n", l.T("생성"), c.FormatDateTime(r.CreatedAt)))
+	sb.WriteString(fmt.Sprintf("- %s: %s\n\n", l.T("최종 수정"), c.FormatDateTime(r.UpdatedAt)))
+
+	sb.WriteString(fmt.Sprintf("| ID | %s | %s | %s | %s |\n", l.T("이름"), l.T("발표 주제"), l.T("발표 자료"), l.T("발표 참여")))
+	sb.WriteString("| --- | --- | --- | --- | --- |\n")
+
+	for _, id := range sortedMemberIDs(r) {
+		m := r.Members[id]
+
+		attended := "X"
+		if m.Attended {
+			attended = "O"
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+			id, escapeMarkdown(m.Name), escapeMarkdown(m.Subject), m.ContentURL, attended))
+	}
+
+	return sb.String()
+}
+
+// escape characters breaking markdown table
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
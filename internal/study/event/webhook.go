@@ -0,0 +1,74 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/piatoss3612/my-study-bot/internal/study"
+)
+
+type webhookPayload struct {
+	Type     string       `json:"type"`
+	Event    *study.Event `json:"event,omitempty"`
+	Round    *study.Round `json:"round,omitempty"`
+	TimeZone string       `json:"time_zone,omitempty"`
+}
+
+// webhookSink posts events as json to the url
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string) (Sink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("webhook url is empty")
+	}
+
+	return &webhookSink{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+func (w *webhookSink) RecordProgress(ctx context.Context, evt study.Event) error {
+	return w.post(ctx, webhookPayload{Type: "progress", Event: &evt})
+}
+
+func (w *webhookSink) RecordRound(ctx context.Context, r study.Round, c study.Clock) error {
+	r.CreatedAt = c.In(r.CreatedAt)
+	r.UpdatedAt = c.In(r.UpdatedAt)
+
+	return w.post(ctx, webhookPayload{Type: "round", Round: &r, TimeZone: c.Location().String()})
+}
+
+func (w *webhookSink) post(ctx context.Context, payload webhookPayload) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// check status code
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code from webhook: %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package study
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MinScore = 1
+	MaxScore = 5
+)
+
+// Rubric is scores of a presentation from MinScore to MaxScore, zero means not scored
+type Rubric struct {
+	Clarity  int `bson:"clarity,omitempty" json:"clarity,omitempty"`
+	Depth    int `bson:"depth,omitempty" json:"depth,omitempty"`
+	Delivery int `bson:"delivery,omitempty" json:"delivery,omitempty"`
+}
+
+func (r Rubric) IsZero() bool {
+	return r.Clarity == 0 && r.Depth == 0 && r.Delivery == 0
+}
+
+func (r Rubric) Validate() error {
+	for _, score := range []int{r.Clarity, r.Depth, r.Delivery} {
+		if score != 0 && (score < MinScore || score > MaxScore) {
+			return errors.Join(ErrInvalidArgs, Errorf("점수는 %d부터 %d 사이여야 합니다", MinScore, MaxScore))
+		}
+	}
+	return nil
+}
+
+// ParseScore parses the score typed by reviewers, empty value means not scored
+func ParseScore(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	score, err := strconv.Atoi(value)
+	if err != nil || score < MinScore || score > MaxScore {
+		return 0, errors.Join(ErrInvalidArgs, Errorf("점수는 %d부터 %d 사이의 숫자로 입력해주세요", MinScore, MaxScore))
+	}
+
+	return score, nil
+}
+
+// FeedbackMode decides who can see the content and the reviewer of feedbacks
+type FeedbackMode string
+
+const (
+	FeedbackModeNamed     FeedbackMode = "named"     // speaker sees the content with the reviewer
+	FeedbackModeAnonymous FeedbackMode = "anonymous" // speaker sees the content without the reviewer
+	FeedbackModeManagers  FeedbackMode = "managers"  // only managers see the content with the reviewer
+
+	DefaultFeedbackMode = FeedbackModeAnonymous
+)
+
+// FeedbackModes returns supported feedback modes in fixed order
+func FeedbackModes() []FeedbackMode {
+	return []FeedbackMode{FeedbackModeNamed, FeedbackModeAnonymous, FeedbackModeManagers}
+}
+
+func ParseFeedbackMode(s string) (FeedbackMode, error) {
+	for _, m := range FeedbackModes() {
+		if FeedbackMode(s) == m {
+			return m, nil
+		}
+	}
+	return "", errors.Join(ErrInvalidArgs, Errorf("지원하는 피드백 방식: %s, %s, %s", FeedbackModeNamed, FeedbackModeAnonymous, FeedbackModeManagers))
+}
+
+func (m FeedbackMode) String() string {
+	return string(m)
+}
+
+// check if the speaker can read the feedback
+func (m FeedbackMode) VisibleToSpeaker() bool {
+	return m != FeedbackModeManagers
+}
+
+// check if the reviewer is shown to whom can read the feedback
+func (m FeedbackMode) RevealsReviewer() bool {
+	return m != FeedbackModeAnonymous
+}
+
+// Feedback is a feedback written by a reviewer to the speaker of the round
+type Feedback struct {
+	ID         string `bson:"_id,omitempty"`
+	GuildID    string `bson:"guild_id"`
+	RoundID    string `bson:"round_id"`
+	ReviewerID string `bson:"reviewer_id"`
+	RevieweeID string `bson:"reviewee_id"`
+	Content    string `bson:"content"`
+	Rubric     Rubric `bson:"rubric"`
+	// mode of the study when the feedback was written, changing the mode later does not reveal reviewers
+	Mode FeedbackMode `bson:"mode"`
+
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func NewFeedback(reviewerID, revieweeID, content string, rubric Rubric, mode FeedbackMode) Feedback {
+	return Feedback{
+		ReviewerID: reviewerID,
+		RevieweeID: revieweeID,
+		Content:    content,
+		Rubric:     rubric,
+		Mode:       mode,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func (f *Feedback) SetID(id string) {
+	f.ID = id
+}
+
+// feedbacks stored before modes were introduced are anonymous
+func (f *Feedback) GetMode() FeedbackMode {
+	if f.Mode == "" {
+		return FeedbackModeAnonymous
+	}
+	return f.Mode
+}
+
+// RubricSummary is aggregate scores of feedbacks a speaker received, averages are zero if nobody scored
+type RubricSummary struct {
+	RevieweeID string
+	Count      int // number of feedbacks
+	Scored     int // number of feedbacks with rubric
+	Clarity    float64
+	Depth      float64
+	Delivery   float64
+}
+
+// SummarizeFeedbacks aggregates scores of feedbacks per speaker, summaries are sorted by number of feedbacks
+func SummarizeFeedbacks(feedbacks []*Feedback) []RubricSummary {
+	// sum of scores of clarity, depth and delivery with number of feedbacks scoring each
+	type sum struct {
+		count, total int
+	}
+
+	totals := map[string]*RubricSummary{}
+	scores := map[string]*[3]sum{}
+
+	for _, f := range feedbacks {
+		s, ok := totals[f.RevieweeID]
+		if !ok {
+			s = &RubricSummary{RevieweeID: f.RevieweeID}
+			totals[f.RevieweeID] = s
+			scores[f.RevieweeID] = &[3]sum{}
+		}
+
+		s.Count++
+		if f.Rubric.IsZero() {
+			continue
+		}
+		s.Scored++
+
+		// each criterion is averaged over the feedbacks scoring it
+		sc := scores[f.RevieweeID]
+		for i, score := range []int{f.Rubric.Clarity, f.Rubric.Depth, f.Rubric.Delivery} {
+			if score > 0 {
+				sc[i].count++
+				sc[i].total += score
+			}
+		}
+	}
+
+	summaries := make([]RubricSummary, 0, len(totals))
+	for id, s := range totals {
+		avg := func(v sum) float64 {
+			if v.count == 0 {
+				return 0
+			}
+			return float64(v.total) / float64(v.count)
+		}
+
+		sc := scores[id]
+		s.Clarity, s.Depth, s.Delivery = avg(sc[0]), avg(sc[1]), avg(sc[2])
+		summaries = append(summaries, *s)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		return summaries[i].RevieweeID < summaries[j].RevieweeID
+	})
+
+	return summaries
+}
+
+// record the feedback to be stored with the round
+func (r *Round) AddFeedback(f Feedback) {
+	r.feedbacks = append(r.feedbacks, f)
+}
+
+// feedbacks written by updates and not stored yet
+func (r *Round) Feedbacks() []Feedback {
+	return r.feedbacks
+}
+
+func (r *Round) ClearFeedbacks() {
+	r.feedbacks = nil
+}
@@ -0,0 +1,79 @@
+package study
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeFeedbacks(t *testing.T) {
+	feedback := func(revieweeID string, rubric Rubric) *Feedback {
+		return &Feedback{RevieweeID: revieweeID, Rubric: rubric}
+	}
+
+	tests := []struct {
+		name      string
+		feedbacks []*Feedback
+		want      []RubricSummary
+	}{
+		{
+			name:      "no feedbacks",
+			feedbacks: nil,
+			want:      []RubricSummary{},
+		},
+		{
+			name: "feedbacks without rubric",
+			feedbacks: []*Feedback{
+				feedback("a", Rubric{}),
+				feedback("a", Rubric{}),
+			},
+			want: []RubricSummary{
+				{RevieweeID: "a", Count: 2},
+			},
+		},
+		{
+			name: "criteria are averaged over the feedbacks scoring them",
+			feedbacks: []*Feedback{
+				feedback("a", Rubric{Clarity: 5, Depth: 4}),
+				feedback("a", Rubric{Clarity: 3}),
+				feedback("a", Rubric{}),
+			},
+			want: []RubricSummary{
+				{RevieweeID: "a", Count: 3, Scored: 2, Clarity: 4, Depth: 4},
+			},
+		},
+		{
+			name: "sorted by number of feedbacks",
+			feedbacks: []*Feedback{
+				feedback("b", Rubric{Clarity: 2, Depth: 2, Delivery: 2}),
+				feedback("a", Rubric{Clarity: 1, Depth: 2, Delivery: 3}),
+				feedback("a", Rubric{Clarity: 2, Depth: 3, Delivery: 4}),
+			},
+			want: []RubricSummary{
+				{RevieweeID: "a", Count: 2, Scored: 2, Clarity: 1.5, Depth: 2.5, Delivery: 3.5},
+				{RevieweeID: "b", Count: 1, Scored: 1, Clarity: 2, Depth: 2, Delivery: 2},
+			},
+		},
+		{
+			name: "ties are sorted by reviewee",
+			feedbacks: []*Feedback{
+				feedback("c", Rubric{}),
+				feedback("a", Rubric{Delivery: 5}),
+				feedback("b", Rubric{}),
+			},
+			want: []RubricSummary{
+				{RevieweeID: "a", Count: 1, Scored: 1, Delivery: 5},
+				{RevieweeID: "b", Count: 1},
+				{RevieweeID: "c", Count: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SummarizeFeedbacks(tt.feedbacks)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SummarizeFeedbacks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package study
+
+import "time"
+
+type Guild struct {
+	ID      string `bson:"_id,omitempty"`
+	GuildID string `bson:"guild_id"`
+	Name    string `bson:"name"`
+	OwnerID string `bson:"owner_id"`
+	Active  bool   `bson:"active"`
+
+	JoinedAt  time.Time `bson:"joined_at"`
+	LeftAt    time.Time `bson:"left_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+func NewGuild() Guild {
+	return Guild{
+		GuildID:   "",
+		Name:      "",
+		OwnerID:   "",
+		Active:    true,
+		JoinedAt:  time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (g *Guild) SetID(id string) {
+	g.ID = id
+}
+
+func (g *Guild) SetGuildID(guildID string) {
+	g.GuildID = guildID
+}
+
+func (g *Guild) SetName(name string) {
+	g.Name = name
+}
+
+func (g *Guild) SetOwnerID(ownerID string) {
+	g.OwnerID = ownerID
+}
+
+func (g *Guild) IsOwner(userID string) bool {
+	return g.OwnerID == userID
+}
+
+// mark guild as joined
+func (g *Guild) Join() {
+	if !g.Active {
+		g.JoinedAt = time.Now()
+	}
+	g.Active = true
+}
+
+// mark guild as left
+func (g *Guild) Leave() {
+	g.Active = false
+	g.LeftAt = time.Now()
+}
+
+func (g *Guild) SetUpdatedAt(t time.Time) {
+	g.UpdatedAt = t
+}
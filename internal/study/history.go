@@ -0,0 +1,358 @@
+package study
+
+import (
+	"fmt"
+	"time"
+)
+
+type RoundEventType string
+
+var (
+	RoundEventCreated                RoundEventType = "round.created"
+	RoundEventStageMoved             RoundEventType = "round.stage_moved"
+	RoundEventContentSubmitted       RoundEventType = "round.content_submitted"
+	RoundEventDeadlineSet            RoundEventType = "round.deadline_set"
+	RoundEventDeadlineRemoved        RoundEventType = "round.deadline_removed"
+	RoundEventStageRolledBack        RoundEventType = "round.stage_rolled_back"
+	RoundEventPaused                 RoundEventType = "round.paused"
+	RoundEventResumed                RoundEventType = "round.resumed"
+	RoundEventCancelled              RoundEventType = "round.cancelled"
+	RoundEventSpeakerCapSet          RoundEventType = "round.speaker_cap_set"
+	RoundEventWaitlistReordered      RoundEventType = "round.waitlist_reordered"
+	RoundEventLineupSet              RoundEventType = "round.lineup_set"
+	RoundEventAttendanceReviewed     RoundEventType = "round.attendance_reviewed"
+	RoundEventReviewersAssigned      RoundEventType = "round.reviewers_assigned"
+	RoundEventReminderSent           RoundEventType = "round.reminder_sent"
+	RoundEventMemberRegistered       RoundEventType = "member.registered"
+	RoundEventMemberWaitlisted       RoundEventType = "member.waitlisted"
+	RoundEventMemberPromoted         RoundEventType = "member.promoted"
+	RoundEventMemberWithdrawn        RoundEventType = "member.withdrawn"
+	RoundEventMemberEdited           RoundEventType = "member.edited"
+	RoundEventMemberReset            RoundEventType = "member.reset"
+	RoundEventTalkStarted            RoundEventType = "member.talk_started"
+	RoundEventTalkEnded              RoundEventType = "member.talk_ended"
+	RoundEventVoiceJoined            RoundEventType = "member.voice_joined"
+	RoundEventVoiceLeft              RoundEventType = "member.voice_left"
+	RoundEventMemberContentSubmitted RoundEventType = "member.content_submitted"
+	RoundEventMemberAttended         RoundEventType = "member.attended"
+	RoundEventReviewerSet            RoundEventType = "member.reviewer_set"
+	RoundEventReflectionSent         RoundEventType = "member.reflection_sent"
+)
+
+// RoundEventData holds the values changed by the event, only the fields related to the type are set
+type RoundEventData struct {
+	Number     int8        `bson:"number,omitempty" json:"number,omitempty"`
+	Title      string      `bson:"title,omitempty" json:"title,omitempty"`
+	MemberIDs  []string    `bson:"member_ids,omitempty" json:"member_ids,omitempty"`
+	Stage      Stage       `bson:"stage,omitempty" json:"stage,omitempty"`
+	Name       string      `bson:"name,omitempty" json:"name,omitempty"`
+	Subject    string      `bson:"subject,omitempty" json:"subject,omitempty"`
+	ContentURL string      `bson:"content_url,omitempty" json:"content_url,omitempty"`
+	ReviewerID string      `bson:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+	Deadline   time.Time   `bson:"deadline,omitempty" json:"deadline,omitempty"`
+	Pipeline   *Pipeline   `bson:"pipeline,omitempty" json:"pipeline,omitempty"`
+	Reason     string      `bson:"reason,omitempty" json:"reason,omitempty"`
+	SpeakerCap int         `bson:"speaker_cap,omitempty" json:"speaker_cap,omitempty"`
+	Flag       MemberFlag  `bson:"flag,omitempty" json:"flag,omitempty"`
+	Order      LineupOrder `bson:"order,omitempty" json:"order,omitempty"`
+	Share      int         `bson:"share,omitempty" json:"share,omitempty"` // share of voice presence in percent, set if attended by voice
+
+	// ids of speakers assigned to each reviewer
+	Assignments map[string][]string `bson:"assignments,omitempty" json:"assignments,omitempty"`
+	// reminder sent to the members of MemberIDs before the deadline
+	Reminder *ReminderRule `bson:"reminder,omitempty" json:"reminder,omitempty"`
+}
+
+// RoundEvent is an immutable record of a change of the round
+type RoundEvent struct {
+	ID       string         `bson:"_id,omitempty" json:"id,omitempty"`
+	RoundID  string         `bson:"round_id" json:"round_id"`
+	GuildID  string         `bson:"guild_id" json:"guild_id"`
+	Version  int64          `bson:"version" json:"version"` // version of the round made by the event
+	Index    int            `bson:"index" json:"index"`     // order of the event in the same version
+	Type     RoundEventType `bson:"type" json:"type"`
+	MemberID string         `bson:"member_id,omitempty" json:"member_id,omitempty"`
+	Data     RoundEventData `bson:"data" json:"data"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+func NewRoundEvent(typ RoundEventType, memberID string, data RoundEventData) RoundEvent {
+	return RoundEvent{
+		Type:      typ,
+		MemberID:  memberID,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (e *RoundEvent) SetID(id string) {
+	e.ID = id
+}
+
+// description of the event for timeline
+func (e RoundEvent) String() string {
+	return e.Describe(NewClock("", DefaultLocale))
+}
+
+// description of the event with times rendered by the clock
+func (e RoundEvent) Describe(c Clock) string {
+	switch e.Type {
+	case RoundEventCreated:
+		return fmt.Sprintf("라운드 생성: %s (참여자 %d명)", e.Data.Title, len(e.Data.MemberIDs))
+	case RoundEventStageMoved:
+		return fmt.Sprintf("단계 이동: %s", e.Data.Stage.String())
+	case RoundEventContentSubmitted:
+		return "발표 영상 등록"
+	case RoundEventDeadlineSet:
+		return fmt.Sprintf("마감 설정: %s (%s)", e.Data.Stage.String(), c.FormatDateTime(e.Data.Deadline))
+	case RoundEventDeadlineRemoved:
+		return fmt.Sprintf("마감 해제: %s", e.Data.Stage.String())
+	case RoundEventStageRolledBack:
+		return fmt.Sprintf("단계 되돌림: %s", e.Data.Stage.String())
+	case RoundEventPaused:
+		return "라운드 일시 중지"
+	case RoundEventResumed:
+		return "라운드 재개"
+	case RoundEventCancelled:
+		if e.Data.Reason != "" {
+			return fmt.Sprintf("라운드 취소: %s", e.Data.Reason)
+		}
+		return "라운드 취소"
+	case RoundEventSpeakerCapSet:
+		if e.Data.SpeakerCap == 0 {
+			return "발표자 정원 해제"
+		}
+		return fmt.Sprintf("발표자 정원: %d명", e.Data.SpeakerCap)
+	case RoundEventWaitlistReordered:
+		return "대기열 순서 변경"
+	case RoundEventLineupSet:
+		return fmt.Sprintf("발표 순서 결정 (%d명)", len(e.Data.MemberIDs))
+	case RoundEventAttendanceReviewed:
+		return fmt.Sprintf("출석 검토 완료 (%d명 참여)", len(e.Data.MemberIDs))
+	case RoundEventReviewersAssigned:
+		return fmt.Sprintf("리뷰어 배정 (%d명)", len(e.Data.Assignments))
+	case RoundEventReminderSent:
+		return fmt.Sprintf("리마인더 발송 (%d명)", len(e.Data.MemberIDs))
+	case RoundEventMemberRegistered:
+		return fmt.Sprintf("발표자 등록: %s", e.Data.Name)
+	case RoundEventMemberWaitlisted:
+		return fmt.Sprintf("대기열 등록: %s", e.Data.Name)
+	case RoundEventMemberPromoted:
+		return fmt.Sprintf("대기열에서 등록: <@%s>", e.MemberID)
+	case RoundEventMemberWithdrawn:
+		return fmt.Sprintf("발표자 등록 취소: <@%s>", e.MemberID)
+	case RoundEventMemberEdited:
+		return fmt.Sprintf("발표자 정보 수정: <@%s>", e.MemberID)
+	case RoundEventMemberReset:
+		return fmt.Sprintf("발표자 상태 초기화: <@%s> (%s)", e.MemberID, e.Data.Flag)
+	case RoundEventTalkStarted:
+		return fmt.Sprintf("발표 시작: <@%s>", e.MemberID)
+	case RoundEventTalkEnded:
+		return fmt.Sprintf("발표 종료: <@%s>", e.MemberID)
+	case RoundEventVoiceJoined:
+		return fmt.Sprintf("음성 채널 입장: <@%s>", e.MemberID)
+	case RoundEventVoiceLeft:
+		return fmt.Sprintf("음성 채널 퇴장: <@%s>", e.MemberID)
+	case RoundEventMemberContentSubmitted:
+		return fmt.Sprintf("발표 자료 제출: <@%s>", e.MemberID)
+	case RoundEventMemberAttended:
+		if e.Data.Share > 0 {
+			return fmt.Sprintf("발표 참여: <@%s> (음성 채널 %d%%)", e.MemberID, e.Data.Share)
+		}
+		return fmt.Sprintf("발표 참여: <@%s>", e.MemberID)
+	case RoundEventReviewerSet:
+		return fmt.Sprintf("피드백: <@%s> → <@%s>", e.Data.ReviewerID, e.MemberID)
+	case RoundEventReflectionSent:
+		return fmt.Sprintf("회고 작성: <@%s>", e.MemberID)
+	default:
+		return string(e.Type)
+	}
+}
+
+// record the change of the round, the event is applied and stored with the round
+func (r *Round) Record(evt RoundEvent) {
+	r.Apply(evt)
+	r.history = append(r.history, evt)
+}
+
+// apply the change of the event to the round
+func (r *Round) Apply(evt RoundEvent) {
+	switch evt.Type {
+	case RoundEventCreated:
+		r.SetNumber(evt.Data.Number)
+		r.SetTitle(evt.Data.Title)
+		r.SetStage(evt.Data.Stage)
+		if evt.Data.Pipeline != nil {
+			r.SetPipeline(*evt.Data.Pipeline)
+		}
+		for _, id := range evt.Data.MemberIDs {
+			if _, ok := r.GetMember(id); !ok {
+				r.SetMember(id, NewMember())
+			}
+		}
+	case RoundEventStageMoved:
+		r.movePresentationSession(r.Stage, evt.Data.Stage, evt.CreatedAt)
+		r.SetStage(evt.Data.Stage)
+	case RoundEventContentSubmitted:
+		r.SetContentURL(evt.Data.ContentURL)
+	case RoundEventDeadlineSet:
+		r.SetDeadline(evt.Data.Stage, evt.Data.Deadline)
+	case RoundEventDeadlineRemoved:
+		r.RemoveDeadline(evt.Data.Stage)
+	case RoundEventStageRolledBack:
+		// deadline of the stage rolled back to has passed, it is removed not to move forward right away
+		r.movePresentationSession(r.Stage, evt.Data.Stage, evt.CreatedAt)
+		r.SetStage(evt.Data.Stage)
+		r.RemoveDeadline(evt.Data.Stage)
+	case RoundEventPaused:
+		r.SetPaused(true)
+	case RoundEventResumed:
+		r.SetPaused(false)
+	case RoundEventCancelled:
+		r.SetStage(StageCancelled)
+		r.SetPaused(false)
+	case RoundEventSpeakerCapSet:
+		r.SetSpeakerCap(evt.Data.SpeakerCap)
+	case RoundEventWaitlistReordered:
+		r.Waitlist = append([]string{}, evt.Data.MemberIDs...)
+	case RoundEventLineupSet:
+		r.Lineup = append([]string{}, evt.Data.MemberIDs...)
+	case RoundEventAttendanceReviewed:
+		r.AttendanceReviewed = true
+	case RoundEventReviewersAssigned:
+		r.Assignments = map[string][]string{}
+		for id, reviewees := range evt.Data.Assignments {
+			r.Assignments[id] = append([]string{}, reviewees...)
+		}
+	case RoundEventReminderSent:
+		// reminders are marked by the deadline when they were sent
+		if evt.Data.Reminder != nil {
+			if r.RemindersSent == nil {
+				r.RemindersSent = map[string]bool{}
+			}
+			r.RemindersSent[evt.Data.Reminder.Key(evt.Data.Deadline)] = true
+		}
+	case RoundEventMemberRegistered:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.SetName(evt.Data.Name)
+			m.SetSubject(evt.Data.Subject)
+			if !m.IsRegistered() {
+				m.SetRegisteredAt(evt.CreatedAt)
+			}
+			m.SetRegistered(true)
+		})
+		r.removeFromWaitlist(evt.MemberID)
+	case RoundEventMemberWaitlisted:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.SetName(evt.Data.Name)
+			m.SetSubject(evt.Data.Subject)
+		})
+		r.addToWaitlist(evt.MemberID)
+	case RoundEventMemberPromoted:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.SetRegisteredAt(evt.CreatedAt)
+			m.SetRegistered(true)
+		})
+		r.removeFromWaitlist(evt.MemberID)
+	case RoundEventMemberWithdrawn:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.SetRegistered(false)
+		})
+		r.removeFromWaitlist(evt.MemberID)
+	case RoundEventMemberEdited:
+		// only the values given are changed
+		r.updateMember(evt.MemberID, func(m *Member) {
+			if evt.Data.Name != "" {
+				m.SetName(evt.Data.Name)
+			}
+			if evt.Data.Subject != "" {
+				m.SetSubject(evt.Data.Subject)
+			}
+			if evt.Data.ContentURL != "" {
+				m.SetContentURL(evt.Data.ContentURL)
+			}
+		})
+	case RoundEventMemberReset:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.Reset(evt.Data.Flag)
+		})
+	case RoundEventTalkStarted:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.SetTalkStartedAt(evt.CreatedAt)
+		})
+		r.CurrentSpeaker = evt.MemberID
+	case RoundEventTalkEnded:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.SetTalkEndedAt(evt.CreatedAt)
+		})
+		r.CurrentSpeaker = ""
+	case RoundEventVoiceJoined:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.joinVoice(evt.CreatedAt)
+		})
+	case RoundEventVoiceLeft:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.leaveVoice(evt.CreatedAt)
+		})
+	case RoundEventMemberContentSubmitted:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.SetContentURL(evt.Data.ContentURL)
+		})
+	case RoundEventMemberAttended:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.SetAttended(true)
+		})
+	case RoundEventReviewerSet:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.SetReviewer(evt.Data.ReviewerID)
+		})
+	case RoundEventReflectionSent:
+		r.updateMember(evt.MemberID, func(m *Member) {
+			m.SetSentReflection(true)
+		})
+	}
+}
+
+func (r *Round) updateMember(memberID string, fn func(m *Member)) {
+	member, ok := r.GetMember(memberID)
+	if !ok {
+		member = NewMember()
+	}
+
+	if member.Reviewers == nil {
+		member.Reviewers = map[string]bool{}
+	}
+
+	fn(&member)
+
+	r.SetMember(memberID, member)
+}
+
+// events recorded by updates and not stored yet
+func (r *Round) History() []RoundEvent {
+	return r.history
+}
+
+func (r *Round) ClearHistory() {
+	r.history = nil
+}
+
+// ProjectRound rebuilds the round from its events in order
+func ProjectRound(events []RoundEvent) Round {
+	r := NewRound()
+
+	for i, evt := range events {
+		if i == 0 {
+			r.SetID(evt.RoundID)
+			r.SetGuildID(evt.GuildID)
+			r.CreatedAt = evt.CreatedAt
+		}
+
+		r.Apply(evt)
+		r.Version = evt.Version
+		r.SetUpdatedAt(evt.CreatedAt)
+	}
+
+	return r
+}
@@ -0,0 +1,101 @@
+package study
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// LineupOrder is how the presentation order of speakers is decided
+type LineupOrder string
+
+var (
+	LineupOrderRandom       LineupOrder = "random"
+	LineupOrderRegistration LineupOrder = "registration"
+	LineupOrderManual       LineupOrder = "manual"
+)
+
+func (o LineupOrder) IsValid() bool {
+	switch o {
+	case LineupOrderRandom, LineupOrderRegistration, LineupOrderManual:
+		return true
+	default:
+		return false
+	}
+}
+
+// Speakers returns ids of registered speakers in order of registration
+func (r *Round) Speakers() []string {
+	ids := []string{}
+	for id, m := range r.Members {
+		if m.IsRegistered() {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		mi, mj := r.Members[ids[i]], r.Members[ids[j]]
+		if !mi.RegisteredAt.Equal(mj.RegisteredAt) {
+			return mi.RegisteredAt.Before(mj.RegisteredAt)
+		}
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
+
+// BuildLineup returns presentation order of registered speakers,
+// speakers given in manual order come first and the others follow in order of registration
+func (r *Round) BuildLineup(order LineupOrder, memberIDs []string) []string {
+	speakers := r.Speakers()
+
+	switch order {
+	case LineupOrderRandom:
+		rand.Shuffle(len(speakers), func(i, j int) { speakers[i], speakers[j] = speakers[j], speakers[i] })
+		return speakers
+	case LineupOrderManual:
+		lineup := []string{}
+		listed := map[string]bool{}
+
+		for _, id := range memberIDs {
+			if m, ok := r.GetMember(id); ok && m.IsRegistered() && !listed[id] {
+				lineup = append(lineup, id)
+				listed[id] = true
+			}
+		}
+
+		for _, id := range speakers {
+			if !listed[id] {
+				lineup = append(lineup, id)
+			}
+		}
+
+		return lineup
+	default:
+		return speakers
+	}
+}
+
+func (r *Round) HasLineup() bool {
+	return len(r.Lineup) > 0
+}
+
+// speaker who has not started the talk first in the lineup, withdrawn members are skipped
+func (r *Round) NextSpeaker() (string, bool) {
+	for _, id := range r.Lineup {
+		m, ok := r.GetMember(id)
+		if !ok || !m.IsRegistered() || id == r.CurrentSpeaker {
+			continue
+		}
+
+		if m.TalkStartedAt.IsZero() {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// check if every speaker of the lineup has finished the talk
+func (r *Round) IsLineupFinished() bool {
+	_, ok := r.NextSpeaker()
+	return r.HasLineup() && !ok && r.CurrentSpeaker == ""
+}
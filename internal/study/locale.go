@@ -0,0 +1,127 @@
+package study
+
+import (
+	"errors"
+	"time"
+)
+
+type Locale string
+
+var (
+	LocaleKorean  Locale = "ko"
+	LocaleEnglish Locale = "en"
+
+	DefaultLocale = LocaleKorean
+)
+
+// layout of date and time typed by managers, parsed in the time zone of the study
+const DateTimeInputLayout = "2006-01-02 15:04"
+
+type localeLayouts struct {
+	dateTime string
+	date     string
+	short    string
+}
+
+var layouts = map[Locale]localeLayouts{
+	LocaleKorean: {
+		dateTime: "2006-01-02 15:04 (MST)",
+		date:     "2006-01-02",
+		short:    "01-02 15:04",
+	},
+	LocaleEnglish: {
+		dateTime: "Jan 2, 2006 3:04 PM (MST)",
+		date:     "Jan 2, 2006",
+		short:    "Jan 2 3:04 PM",
+	},
+}
+
+// Locales returns supported locales in fixed order
+func Locales() []Locale {
+	return []Locale{LocaleKorean, LocaleEnglish}
+}
+
+func ParseLocale(s string) (Locale, error) {
+	l := Locale(s)
+	if _, ok := layouts[l]; !ok {
+		return "", errors.Join(ErrUnsupportedLocale, Errorf("지원하는 언어: %s, %s", LocaleKorean, LocaleEnglish))
+	}
+	return l, nil
+}
+
+func (l Locale) String() string {
+	return string(l)
+}
+
+// ValidateTimeZone checks if the name is a time zone of IANA database like Asia/Seoul
+func ValidateTimeZone(name string) error {
+	if name == "" {
+		return errors.Join(ErrInvalidTimeZone, Errorf("시간대를 입력해주세요"))
+	}
+
+	if _, err := time.LoadLocation(name); err != nil {
+		return errors.Join(ErrInvalidTimeZone, Errorf("알 수 없는 시간대입니다: %s", name))
+	}
+
+	return nil
+}
+
+// Clock formats and parses times in the time zone and the locale of a study
+type Clock struct {
+	loc    *time.Location
+	locale Locale
+}
+
+// NewClock returns clock of the time zone and the locale, local time zone and default locale are used if they are empty or invalid
+func NewClock(timeZone string, locale Locale) Clock {
+	c := Clock{
+		loc:    time.Local,
+		locale: DefaultLocale,
+	}
+
+	if timeZone != "" {
+		if loc, err := time.LoadLocation(timeZone); err == nil {
+			c.loc = loc
+		}
+	}
+
+	if _, ok := layouts[locale]; ok {
+		c.locale = locale
+	}
+
+	return c
+}
+
+func (c Clock) Location() *time.Location {
+	return c.loc
+}
+
+func (c Clock) Locale() Locale {
+	return c.locale
+}
+
+func (c Clock) Now() time.Time {
+	return time.Now().In(c.loc)
+}
+
+func (c Clock) In(t time.Time) time.Time {
+	return t.In(c.loc)
+}
+
+func (c Clock) FormatDateTime(t time.Time) string {
+	return t.In(c.loc).Format(layouts[c.locale].dateTime)
+}
+
+func (c Clock) FormatDate(t time.Time) string {
+	return t.In(c.loc).Format(layouts[c.locale].date)
+}
+
+// short form without year for timelines
+func (c Clock) FormatShort(t time.Time) string {
+	return t.In(c.loc).Format(layouts[c.locale].short)
+}
+
+// parse date and time typed as DateTimeInputLayout in the time zone of the clock
+func (c Clock) ParseDateTime(value string) (time.Time, error) {
+	return time.ParseInLocation(DateTimeInputLayout, value, c.loc)
+}
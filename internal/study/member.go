@@ -1,5 +1,26 @@
 package study
 
+import "time"
+
+// MemberFlag is a state of the member which managers can reset
+type MemberFlag string
+
+var (
+	MemberFlagContent    MemberFlag = "content"
+	MemberFlagAttendance MemberFlag = "attendance"
+	MemberFlagReviewers  MemberFlag = "reviewers"
+	MemberFlagReflection MemberFlag = "reflection"
+)
+
+func (f MemberFlag) IsValid() bool {
+	switch f {
+	case MemberFlagContent, MemberFlagAttendance, MemberFlagReviewers, MemberFlagReflection:
+		return true
+	default:
+		return false
+	}
+}
+
 type Member struct {
 	Name           string          `bson:"name" json:"name"`
 	Subject        string          `bson:"subject" json:"subject"`
@@ -8,6 +29,10 @@ type Member struct {
 	Attended       bool            `bson:"attended" json:"attended"`
 	SentReflection bool            `bson:"sent_reflection" json:"sent_reflection,omitempty"`
 	Reviewers      map[string]bool `bson:"reviewers" json:"reviewers,omitempty"`
+	RegisteredAt   time.Time       `bson:"registered_at,omitempty" json:"registered_at,omitempty"`
+	TalkStartedAt  time.Time       `bson:"talk_started_at,omitempty" json:"talk_started_at,omitempty"`
+	TalkEndedAt    time.Time       `bson:"talk_ended_at,omitempty" json:"talk_ended_at,omitempty"`
+	Presences      []Presence      `bson:"presences,omitempty" json:"presences,omitempty"`
 }
 
 func NewMember() Member {
@@ -41,6 +66,26 @@ func (m Member) IsRegistered() bool {
 	return m.Registered
 }
 
+func (m *Member) SetRegisteredAt(t time.Time) {
+	m.RegisteredAt = t
+}
+
+func (m *Member) SetTalkStartedAt(t time.Time) {
+	m.TalkStartedAt = t
+}
+
+func (m *Member) SetTalkEndedAt(t time.Time) {
+	m.TalkEndedAt = t
+}
+
+// duration of the talk, zero if the talk has not ended
+func (m Member) TalkDuration() time.Duration {
+	if m.TalkStartedAt.IsZero() || m.TalkEndedAt.IsZero() {
+		return 0
+	}
+	return m.TalkEndedAt.Sub(m.TalkStartedAt)
+}
+
 func (m *Member) SetAttended(Attended bool) {
 	m.Attended = Attended
 }
@@ -64,3 +109,17 @@ func (m *Member) SetReviewer(userID string) {
 func (m Member) IsReviewer(userID string) bool {
 	return m.Reviewers[userID]
 }
+
+// reset the state of the flag to the initial value
+func (m *Member) Reset(flag MemberFlag) {
+	switch flag {
+	case MemberFlagContent:
+		m.SetContentURL("")
+	case MemberFlagAttendance:
+		m.SetAttended(false)
+	case MemberFlagReviewers:
+		m.Reviewers = map[string]bool{}
+	case MemberFlagReflection:
+		m.SetSentReflection(false)
+	}
+}
@@ -0,0 +1,71 @@
+package study
+
+import "time"
+
+// OutboxEvent is an event stored with the state change, waiting to be published
+type OutboxEvent struct {
+	ID        string `bson:"_id,omitempty"`
+	Event     Event  `bson:"event"`
+	Sent      bool   `bson:"sent"`
+	Attempts  int    `bson:"attempts"`
+	LastError string `bson:"last_error"`
+	Parked    bool   `bson:"parked"` // events failed too many times are not published anymore
+
+	// relay claiming the event publishes it until the lease expires
+	LockedBy    string    `bson:"locked_by"`
+	LockedUntil time.Time `bson:"locked_until"`
+
+	CreatedAt time.Time `bson:"created_at"`
+	SentAt    time.Time `bson:"sent_at"`
+}
+
+func NewOutboxEvent(evt Event) OutboxEvent {
+	return OutboxEvent{
+		Event:     evt,
+		Sent:      false,
+		Attempts:  0,
+		LastError: "",
+		CreatedAt: time.Now(),
+	}
+}
+
+func (o *OutboxEvent) SetID(id string) {
+	o.ID = id
+}
+
+func (o *OutboxEvent) MarkSent() {
+	o.Attempts++
+	o.Sent = true
+	o.LastError = ""
+	o.SentAt = time.Now()
+	o.Release()
+}
+
+func (o *OutboxEvent) MarkFailed(err error) {
+	o.Attempts++
+	if err != nil {
+		o.LastError = err.Error()
+	}
+	o.Release()
+}
+
+// park the event, so that it does not block the events after it
+func (o *OutboxEvent) Park() {
+	o.Parked = true
+}
+
+// release the lease of the relay
+func (o *OutboxEvent) Release() {
+	o.LockedBy = ""
+	o.LockedUntil = time.Time{}
+}
+
+// check if the event can be claimed by the relay
+func (o *OutboxEvent) IsClaimable(owner string, now time.Time) bool {
+	return !o.Sent && !o.Parked && (o.LockedBy == owner || !now.Before(o.LockedUntil))
+}
+
+// time elapsed since the event was stored
+func (o *OutboxEvent) Lag(now time.Time) time.Duration {
+	return now.Sub(o.CreatedAt)
+}
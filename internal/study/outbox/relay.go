@@ -0,0 +1,235 @@
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/piatoss3612/my-study-bot/internal/pubsub"
+	"github.com/piatoss3612/my-study-bot/internal/study/repository"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+var outboxLag = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "outbox_lag_seconds",
+		Help: "Age of the oldest pending event in outbox in seconds.",
+	},
+)
+
+var pendingEvents = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "outbox_pending_events",
+		Help: "Number of pending events fetched from outbox.",
+	},
+)
+
+var publishedEvents = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "outbox_published_events_total",
+		Help: "Total number of events published from outbox.",
+	},
+	[]string{"event"},
+)
+
+var publishErrors = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "outbox_publish_errors_total",
+		Help: "Total number of errors while publishing events from outbox.",
+	},
+	[]string{"event"},
+)
+
+var (
+	defaultRelayInterval    = 1 * time.Second
+	defaultRelayBatchSize   = int64(100)
+	defaultRelayMaxAttempts = 10
+	defaultRelayLease       = 30 * time.Second
+)
+
+var parkedEvents = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "outbox_parked_events_total",
+		Help: "Total number of events parked after failing too many times.",
+	},
+	[]string{"event"},
+)
+
+// Metrics returns the collectors of outbox relay
+func Metrics() []prometheus.Collector {
+	return []prometheus.Collector{outboxLag, pendingEvents, publishedEvents, publishErrors, parkedEvents}
+}
+
+type Relay interface {
+	Run(ctx context.Context)
+}
+
+type relay struct {
+	tx  repository.Tx
+	pub pubsub.Publisher
+
+	interval    time.Duration
+	batchSize   int64
+	maxAttempts int
+	lease       time.Duration
+
+	// relays of replicas claim events with their own id
+	owner string
+
+	sugar *zap.SugaredLogger
+}
+
+type RelayOptsFunc func(*relay)
+
+func WithInterval(interval time.Duration) RelayOptsFunc {
+	return func(r *relay) {
+		r.interval = interval
+	}
+}
+
+func WithBatchSize(size int64) RelayOptsFunc {
+	return func(r *relay) {
+		r.batchSize = size
+	}
+}
+
+// events failed n times are parked and not published anymore
+func WithMaxAttempts(n int) RelayOptsFunc {
+	return func(r *relay) {
+		r.maxAttempts = n
+	}
+}
+
+// events claimed by the relay are not published by other relays for the duration
+func WithLease(d time.Duration) RelayOptsFunc {
+	return func(r *relay) {
+		r.lease = d
+	}
+}
+
+func NewRelay(tx repository.Tx, pub pubsub.Publisher, sugar *zap.SugaredLogger, opts ...RelayOptsFunc) Relay {
+	r := &relay{
+		tx:          tx,
+		pub:         pub,
+		interval:    defaultRelayInterval,
+		batchSize:   defaultRelayBatchSize,
+		maxAttempts: defaultRelayMaxAttempts,
+		lease:       defaultRelayLease,
+		owner:       newOwnerID(),
+		sugar:       sugar,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// run relay until the context is done
+func (r *relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.relay(ctx)
+		}
+	}
+}
+
+// publish pending events in order of each guild, events are marked as sent only after being published
+func (r *relay) relay(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	events, err := r.tx.FindPendingOutboxEvents(ctx, r.batchSize)
+	if err != nil {
+		r.sugar.Errorw("failed to find pending outbox events", "error", err)
+		return
+	}
+
+	pendingEvents.Set(float64(len(events)))
+
+	if len(events) == 0 {
+		outboxLag.Set(0)
+		return
+	}
+
+	now := time.Now()
+
+	outboxLag.Set(events[0].Lag(now).Seconds())
+
+	// events of a guild are published in order, so later events of the guild
+	// wait while an earlier one is not published
+	blocked := map[string]bool{}
+
+	for _, o := range events {
+		guildID := o.Event.GuildID
+		if blocked[guildID] {
+			continue
+		}
+
+		topic := o.Event.Topic.String()
+
+		// events claimed by another relay are published by it
+		claimed, err := r.tx.ClaimOutboxEvent(ctx, o.ID, r.owner, now, now.Add(r.lease))
+		if err != nil || !claimed {
+			if err != nil {
+				r.sugar.Errorw("failed to claim outbox event", "error", err, "id", o.ID)
+			}
+			blocked[guildID] = true
+			continue
+		}
+
+		if err := r.pub.Publish(ctx, topic, o.Event); err != nil {
+			publishErrors.WithLabelValues(topic).Inc()
+			r.sugar.Errorw("failed to publish outbox event", "error", err, "topic", topic, "id", o.ID, "attempts", o.Attempts+1)
+
+			o.MarkFailed(err)
+
+			// park the event failed too many times, so that it does not block the guild forever
+			if o.Attempts >= r.maxAttempts {
+				o.Park()
+				parkedEvents.WithLabelValues(topic).Inc()
+				r.sugar.Errorw("outbox event parked", "topic", topic, "id", o.ID, "guild", guildID, "attempts", o.Attempts)
+			} else {
+				blocked[guildID] = true
+			}
+
+			if _, err := r.tx.UpdateOutboxEvent(ctx, *o); err != nil {
+				r.sugar.Errorw("failed to update outbox event", "error", err, "id", o.ID)
+				blocked[guildID] = true
+			}
+			continue
+		}
+
+		publishedEvents.WithLabelValues(topic).Inc()
+
+		// event may be published again if marking fails, so subscribers should be idempotent
+		o.MarkSent()
+		if _, err := r.tx.UpdateOutboxEvent(ctx, *o); err != nil {
+			r.sugar.Errorw("failed to mark outbox event as sent", "error", err, "id", o.ID)
+			blocked[guildID] = true
+			continue
+		}
+
+		r.sugar.Infow("event published", "topic", topic, "id", o.ID, "attempts", o.Attempts)
+	}
+}
+
+// id of the relay, host name is added to find the replica easily
+func newOwnerID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%s", host, hex.EncodeToString(b))
+}
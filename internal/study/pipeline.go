@@ -0,0 +1,165 @@
+package study
+
+// Action is what members or managers can do during a stage
+type Action string
+
+var (
+	ActionRegister           Action = "register"
+	ActionSubmitContent      Action = "submit-content"
+	ActionCheckAttendance    Action = "check-attendance"
+	ActionSubmitRoundContent Action = "submit-round-content"
+	ActionReview             Action = "review"
+	ActionReflect            Action = "reflect"
+)
+
+type PipelineStep struct {
+	Stage   Stage    `bson:"stage" json:"stage"`
+	Actions []Action `bson:"actions" json:"actions"`
+}
+
+// Pipeline is the ordered stages of a round, the round finishes after the last stage
+type Pipeline struct {
+	Name  string         `bson:"name" json:"name"`
+	Steps []PipelineStep `bson:"steps" json:"steps"`
+}
+
+var (
+	DefaultPipelineName   = "default"
+	LightningPipelineName = "lightning"
+	NoReviewPipelineName  = "no-review"
+)
+
+// named pipelines which can be chosen when a round is created
+var pipelineTemplates = map[string]Pipeline{
+	DefaultPipelineName: {
+		Name: DefaultPipelineName,
+		Steps: []PipelineStep{
+			{Stage: StageRegistrationOpened, Actions: []Action{ActionRegister}},
+			{Stage: StageRegistrationClosed},
+			{Stage: StageSubmissionOpened, Actions: []Action{ActionSubmitContent}},
+			{Stage: StageSubmissionClosed},
+			{Stage: StagePresentationStarted, Actions: []Action{ActionCheckAttendance}},
+			{Stage: StagePresentationFinished, Actions: []Action{ActionCheckAttendance, ActionSubmitRoundContent, ActionReflect}},
+			{Stage: StageReviewOpened, Actions: []Action{ActionCheckAttendance, ActionSubmitRoundContent, ActionReview, ActionReflect}},
+			{Stage: StageReviewClosed, Actions: []Action{ActionCheckAttendance, ActionSubmitRoundContent, ActionReflect}},
+		},
+	},
+	LightningPipelineName: {
+		Name: LightningPipelineName,
+		Steps: []PipelineStep{
+			{Stage: StageRegistrationOpened, Actions: []Action{ActionRegister}},
+			{Stage: StageRegistrationClosed},
+			{Stage: StagePresentationStarted, Actions: []Action{ActionCheckAttendance}},
+			{Stage: StagePresentationFinished, Actions: []Action{ActionCheckAttendance, ActionSubmitRoundContent, ActionReflect}},
+		},
+	},
+	NoReviewPipelineName: {
+		Name: NoReviewPipelineName,
+		Steps: []PipelineStep{
+			{Stage: StageRegistrationOpened, Actions: []Action{ActionRegister}},
+			{Stage: StageRegistrationClosed},
+			{Stage: StageSubmissionOpened, Actions: []Action{ActionSubmitContent}},
+			{Stage: StageSubmissionClosed},
+			{Stage: StagePresentationStarted, Actions: []Action{ActionCheckAttendance}},
+			{Stage: StagePresentationFinished, Actions: []Action{ActionCheckAttendance, ActionSubmitRoundContent, ActionReflect}},
+		},
+	},
+}
+
+// PipelineTemplate returns the pipeline of the name, default pipeline is used if the name is empty
+func PipelineTemplate(name string) (Pipeline, error) {
+	if name == "" {
+		name = DefaultPipelineName
+	}
+
+	p, ok := pipelineTemplates[name]
+	if !ok {
+		return Pipeline{}, ErrPipelineNotFound
+	}
+
+	return p.clone(), nil
+}
+
+// PipelineTemplateNames returns names of the templates in fixed order
+func PipelineTemplateNames() []string {
+	return []string{DefaultPipelineName, LightningPipelineName, NoReviewPipelineName}
+}
+
+func (p Pipeline) IsZero() bool {
+	return len(p.Steps) == 0
+}
+
+func (p Pipeline) First() Stage {
+	if p.IsZero() {
+		return StageNone
+	}
+	return p.Steps[0].Stage
+}
+
+// index of the stage in the pipeline, -1 if the stage is not in the pipeline
+func (p Pipeline) Index(stage Stage) int {
+	for i, step := range p.Steps {
+		if step.Stage == stage {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p Pipeline) Contains(stage Stage) bool {
+	return p.Index(stage) >= 0
+}
+
+// Next returns the stage after the given stage, StageFinished after the last stage
+func (p Pipeline) Next(stage Stage) Stage {
+	if stage.IsNone() || stage.IsWait() {
+		return p.First()
+	}
+
+	i := p.Index(stage)
+	if i < 0 {
+		return StageNone
+	}
+
+	if i == len(p.Steps)-1 {
+		return StageFinished
+	}
+
+	return p.Steps[i+1].Stage
+}
+
+// Prev returns the stage before the given stage, StageNone if there is no stage to go back
+func (p Pipeline) Prev(stage Stage) Stage {
+	i := p.Index(stage)
+	if i <= 0 {
+		return StageNone
+	}
+
+	return p.Steps[i-1].Stage
+}
+
+// Allows reports whether the action is allowed during the stage
+func (p Pipeline) Allows(stage Stage, action Action) bool {
+	i := p.Index(stage)
+	if i < 0 {
+		return false
+	}
+
+	for _, a := range p.Steps[i].Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Pipeline) clone() Pipeline {
+	steps := make([]PipelineStep, len(p.Steps))
+	for i, step := range p.Steps {
+		steps[i] = PipelineStep{
+			Stage:   step.Stage,
+			Actions: append([]Action{}, step.Actions...),
+		}
+	}
+	return Pipeline{Name: p.Name, Steps: steps}
+}
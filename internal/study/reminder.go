@@ -0,0 +1,149 @@
+package study
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxReminders is the largest number of reminder rules of a study
+const MaxReminders = 10
+
+// ReminderTarget is the outstanding work members are reminded of
+type ReminderTarget string
+
+const (
+	ReminderTargetContent    ReminderTarget = "content"    // registered speakers who have not submitted content
+	ReminderTargetFeedback   ReminderTarget = "feedback"   // attended members who have not sent feedback
+	ReminderTargetReflection ReminderTarget = "reflection" // attended speakers who have not written reflection
+)
+
+// ReminderTargets returns supported targets in fixed order
+func ReminderTargets() []ReminderTarget {
+	return []ReminderTarget{ReminderTargetContent, ReminderTargetFeedback, ReminderTargetReflection}
+}
+
+func (t ReminderTarget) IsValid() bool {
+	for _, target := range ReminderTargets() {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+func (t ReminderTarget) String() string {
+	return string(t)
+}
+
+// ReminderRule sends DMs to members with outstanding work before the deadline of the stage
+type ReminderRule struct {
+	Stage  Stage          `bson:"stage" json:"stage"`
+	Before time.Duration  `bson:"before" json:"before"` // reminders are sent this long before the deadline
+	Target ReminderTarget `bson:"target" json:"target"`
+}
+
+func NewReminderRule(stage Stage, before time.Duration, target ReminderTarget) ReminderRule {
+	return ReminderRule{
+		Stage:  stage,
+		Before: before,
+		Target: target,
+	}
+}
+
+func (rr ReminderRule) Validate() error {
+	if rr.Stage.IsNone() {
+		return errors.Join(ErrInvalidStage, Errorf("리마인더를 보낼 진행 단계를 선택해주세요"))
+	}
+
+	if !rr.Target.IsValid() {
+		return errors.Join(ErrInvalidArgs, Errorf("지원하는 리마인더 대상: %s, %s, %s", ReminderTargetContent, ReminderTargetFeedback, ReminderTargetReflection))
+	}
+
+	if rr.Before <= 0 {
+		return errors.Join(ErrInvalidArgs, Errorf("리마인더는 마감 시간 이전이어야 합니다"))
+	}
+
+	return nil
+}
+
+// key of the reminder for the deadline, a reminder of the same key is sent once
+// and changing the deadline makes a new one
+func (rr ReminderRule) Key(deadline time.Time) string {
+	return fmt.Sprintf("%d/%s/%d/%d", rr.Stage, rr.Target, int64(rr.Before/time.Second), deadline.Unix())
+}
+
+// check if the reminder of the round is due, reminders of paused rounds are not due
+func (r *Round) IsReminderDue(rr ReminderRule, now time.Time) bool {
+	if r.IsPaused() || r.Stage != rr.Stage {
+		return false
+	}
+
+	deadline, ok := r.GetDeadline(rr.Stage)
+	if !ok || !now.Before(deadline) || now.Before(deadline.Add(-rr.Before)) {
+		return false
+	}
+
+	return !r.RemindersSent[rr.Key(deadline)]
+}
+
+// due reminders of the rules which have not been sent
+func (r *Round) DueReminders(rules []ReminderRule, now time.Time) []ReminderRule {
+	due := []ReminderRule{}
+	for _, rr := range rules {
+		if r.IsReminderDue(rr, now) {
+			due = append(due, rr)
+		}
+	}
+	return due
+}
+
+// ids of members who have outstanding work of the target in order of id, members opted out are excluded
+func (r *Round) ReminderRecipients(target ReminderTarget, optOuts map[string]bool) []string {
+	ids := []string{}
+
+	for id, m := range r.Members {
+		if optOuts[id] {
+			continue
+		}
+
+		var outstanding bool
+
+		switch target {
+		case ReminderTargetContent:
+			outstanding = m.IsRegistered() && m.ContentURL == ""
+		case ReminderTargetFeedback:
+			outstanding = m.IsAttended() && r.hasOutstandingReviews(id)
+		case ReminderTargetReflection:
+			outstanding = m.IsRegistered() && m.IsAttended() && !m.HasSentReflection()
+		}
+
+		if outstanding {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+// reviewers with assignments should review all of them, the others should review any attended speaker
+func (r *Round) hasOutstandingReviews(reviewerID string) bool {
+	if r.HasAssignments() {
+		done, total := r.ReviewProgress(reviewerID)
+		return done < total
+	}
+
+	reviewable := false
+	for id, m := range r.Members {
+		if id == reviewerID || !m.IsRegistered() || !m.IsAttended() {
+			continue
+		}
+		if m.IsReviewer(reviewerID) {
+			return false
+		}
+		reviewable = true
+	}
+	return reviewable
+}
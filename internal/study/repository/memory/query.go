@@ -0,0 +1,255 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (tx *memoryTx) FindGuild(_ context.Context, guildID string) (*study.Guild, error) {
+	var found *study.Guild
+
+	err := tx.read("guild", func(c collection) error {
+		for _, b := range c {
+			g := study.NewGuild()
+			if err := bson.Unmarshal(b, &g); err != nil {
+				return err
+			}
+
+			if g.GuildID == guildID {
+				found = &g
+				return nil
+			}
+		}
+		return nil
+	})
+
+	return found, err
+}
+
+func (tx *memoryTx) FindStudy(_ context.Context, guildID string) (*study.Study, error) {
+	var found *study.Study
+
+	err := tx.read("study", func(c collection) error {
+		for _, b := range c {
+			s := study.New()
+			if err := bson.Unmarshal(b, &s); err != nil {
+				return err
+			}
+
+			if s.GuildID == guildID {
+				found = &s
+				return nil
+			}
+		}
+		return nil
+	})
+
+	return found, err
+}
+
+func (tx *memoryTx) FindRound(_ context.Context, roundID string) (*study.Round, error) {
+	var found *study.Round
+
+	err := tx.read("round", func(c collection) error {
+		b, ok := c[roundID]
+		if !ok {
+			return nil
+		}
+
+		r := study.NewRound()
+		if err := bson.Unmarshal(b, &r); err != nil {
+			return err
+		}
+
+		found = &r
+		return nil
+	})
+
+	return found, err
+}
+
+func (tx *memoryTx) FindRounds(_ context.Context, guildID string) ([]*study.Round, error) {
+	rounds, err := tx.findRounds(func(r *study.Round) bool {
+		return r.GuildID == guildID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(rounds, func(i, j int) bool {
+		return rounds[i].CreatedAt.After(rounds[j].CreatedAt)
+	})
+
+	return rounds, nil
+}
+
+func (tx *memoryTx) FindOngoingRounds(_ context.Context) ([]*study.Round, error) {
+	return tx.findRounds(func(r *study.Round) bool {
+		return r.Stage > study.StageWait && r.Stage < study.StageFinished
+	})
+}
+
+func (tx *memoryTx) FindStudiesWithDueSeries(_ context.Context, now time.Time) ([]*study.Study, error) {
+	var studies []*study.Study
+
+	err := tx.read("study", func(c collection) error {
+		for _, b := range c {
+			s := study.New()
+			if err := bson.Unmarshal(b, &s); err != nil {
+				return err
+			}
+
+			if s.HasSeries() && s.Series.IsDue(now) {
+				studies = append(studies, &s)
+			}
+		}
+		return nil
+	})
+
+	return studies, err
+}
+
+func (tx *memoryTx) findRounds(match func(r *study.Round) bool) ([]*study.Round, error) {
+	var rounds []*study.Round
+
+	err := tx.read("round", func(c collection) error {
+		for _, b := range c {
+			r := study.NewRound()
+			if err := bson.Unmarshal(b, &r); err != nil {
+				return err
+			}
+
+			if match(&r) {
+				rounds = append(rounds, &r)
+			}
+		}
+		return nil
+	})
+
+	return rounds, err
+}
+
+func (tx *memoryTx) FindAuditLogs(_ context.Context, guildID string, limit int64) ([]*study.AuditLog, error) {
+	var logs []*study.AuditLog
+
+	err := tx.read("audit", func(c collection) error {
+		for _, b := range c {
+			a := study.NewAuditLog()
+			if err := bson.Unmarshal(b, &a); err != nil {
+				return err
+			}
+
+			if a.GuildID == guildID {
+				logs = append(logs, &a)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		return logs[i].CreatedAt.After(logs[j].CreatedAt)
+	})
+
+	return limitSlice(logs, limit), nil
+}
+
+func (tx *memoryTx) FindPendingOutboxEvents(_ context.Context, limit int64) ([]*study.OutboxEvent, error) {
+	var events []*study.OutboxEvent
+
+	err := tx.read("outbox", func(c collection) error {
+		for _, b := range c {
+			var o study.OutboxEvent
+			if err := bson.Unmarshal(b, &o); err != nil {
+				return err
+			}
+
+			if !o.Sent && !o.Parked {
+				events = append(events, &o)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// ids are increasing, so sort by id to keep the order of events created at the same time
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+
+	return limitSlice(events, limit), nil
+}
+
+func (tx *memoryTx) FindRoundEvents(_ context.Context, roundID string) ([]*study.RoundEvent, error) {
+	var events []*study.RoundEvent
+
+	err := tx.read("round_event", func(c collection) error {
+		for _, b := range c {
+			var e study.RoundEvent
+			if err := bson.Unmarshal(b, &e); err != nil {
+				return err
+			}
+
+			if e.RoundID == roundID {
+				events = append(events, &e)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Version != events[j].Version {
+			return events[i].Version < events[j].Version
+		}
+		return events[i].Index < events[j].Index
+	})
+
+	return events, nil
+}
+
+// feedbacks of the round in order of creation, feedbacks to every speaker are found if reviewee id is empty
+func (tx *memoryTx) FindFeedbacks(_ context.Context, roundID, revieweeID string) ([]*study.Feedback, error) {
+	var feedbacks []*study.Feedback
+
+	err := tx.read("feedback", func(c collection) error {
+		for _, b := range c {
+			var f study.Feedback
+			if err := bson.Unmarshal(b, &f); err != nil {
+				return err
+			}
+
+			if f.RoundID == roundID && (revieweeID == "" || f.RevieweeID == revieweeID) {
+				feedbacks = append(feedbacks, &f)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// ids are increasing, so sort by id to keep the order of feedbacks created at the same time
+	sort.Slice(feedbacks, func(i, j int) bool {
+		return feedbacks[i].ID < feedbacks[j].ID
+	})
+
+	return feedbacks, nil
+}
+
+func limitSlice[T any](s []T, limit int64) []T {
+	if limit > 0 && int64(len(s)) > limit {
+		return s[:limit]
+	}
+	return s
+}
@@ -0,0 +1,223 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrDocumentNotFound = errors.New("document not found")
+
+func (tx *memoryTx) CreateGuild(ctx context.Context, g study.Guild) (*study.Guild, error) {
+	g.SetID(newID())
+
+	if err := tx.put(ctx, "guild", g.ID, g, false); err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+func (tx *memoryTx) UpdateGuild(ctx context.Context, g study.Guild) (*study.Guild, error) {
+	g.SetUpdatedAt(time.Now())
+
+	if err := tx.put(ctx, "guild", g.ID, g, true); err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+func (tx *memoryTx) CreateStudy(ctx context.Context, s study.Study) (*study.Study, error) {
+	s.SetID(newID())
+
+	if err := tx.put(ctx, "study", s.ID, s, false); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (tx *memoryTx) UpdateStudy(ctx context.Context, s study.Study) (*study.Study, error) {
+	err := tx.write(ctx, "study", func(c collection) error {
+		b, ok := c[s.ID]
+		if !ok {
+			return ErrDocumentNotFound
+		}
+
+		stored := study.New()
+		if err := bson.Unmarshal(b, &stored); err != nil {
+			return err
+		}
+
+		// study has been updated by another request
+		if stored.Version != s.Version {
+			return study.ErrVersionConflict
+		}
+
+		s.SetUpdatedAt(time.Now())
+		s.IncrementVersion()
+
+		return encodeTo(c, s.ID, s)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (tx *memoryTx) CreateRound(ctx context.Context, r study.Round) (*study.Round, error) {
+	r.SetID(newID())
+
+	if err := tx.put(ctx, "round", r.ID, r, false); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+func (tx *memoryTx) UpdateRound(ctx context.Context, r study.Round) (*study.Round, error) {
+	err := tx.write(ctx, "round", func(c collection) error {
+		b, ok := c[r.ID]
+		if !ok {
+			return ErrDocumentNotFound
+		}
+
+		stored := study.NewRound()
+		if err := bson.Unmarshal(b, &stored); err != nil {
+			return err
+		}
+
+		// round has been updated by another request
+		if stored.Version != r.Version {
+			return study.ErrVersionConflict
+		}
+
+		r.SetUpdatedAt(time.Now())
+		r.IncrementVersion()
+
+		return encodeTo(c, r.ID, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+func (tx *memoryTx) CreateAuditLog(ctx context.Context, a study.AuditLog) (*study.AuditLog, error) {
+	a.SetID(newID())
+
+	if err := tx.put(ctx, "audit", a.ID, a, false); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+func (tx *memoryTx) CreateOutboxEvent(ctx context.Context, o study.OutboxEvent) (*study.OutboxEvent, error) {
+	o.SetID(newID())
+
+	if err := tx.put(ctx, "outbox", o.ID, o, false); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (tx *memoryTx) UpdateOutboxEvent(ctx context.Context, o study.OutboxEvent) (*study.OutboxEvent, error) {
+	if err := tx.put(ctx, "outbox", o.ID, o, true); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// claim pending event until the time, events claimed by another relay are not claimed until the lease expires
+func (tx *memoryTx) ClaimOutboxEvent(ctx context.Context, id, owner string, now, until time.Time) (bool, error) {
+	claimed := false
+
+	err := tx.write(ctx, "outbox", func(c collection) error {
+		b, ok := c[id]
+		if !ok {
+			return nil
+		}
+
+		var o study.OutboxEvent
+		if err := bson.Unmarshal(b, &o); err != nil {
+			return err
+		}
+
+		if !o.IsClaimable(owner, now) {
+			return nil
+		}
+
+		o.LockedBy = owner
+		o.LockedUntil = until
+
+		b, err := bson.Marshal(o)
+		if err != nil {
+			return err
+		}
+
+		c[id] = b
+		claimed = true
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return claimed, nil
+}
+
+func (tx *memoryTx) CreateRoundEvent(ctx context.Context, e study.RoundEvent) (*study.RoundEvent, error) {
+	e.SetID(newID())
+
+	if err := tx.put(ctx, "round_event", e.ID, e, false); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+func (tx *memoryTx) CreateFeedback(ctx context.Context, f study.Feedback) (*study.Feedback, error) {
+	f.SetID(newID())
+
+	if err := tx.put(ctx, "feedback", f.ID, f, false); err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+// put document to collection, the document should exist if replace is true
+func (tx *memoryTx) put(ctx context.Context, name, id string, v any, replace bool) error {
+	return tx.write(ctx, name, func(c collection) error {
+		if _, ok := c[id]; replace && !ok {
+			return ErrDocumentNotFound
+		}
+		return encodeTo(c, id, v)
+	})
+}
+
+func encodeTo(c collection, id string, v any) error {
+	b, err := bson.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	c[id] = b
+	return nil
+}
+
+// ids have the same format as mongodb
+func newID() string {
+	return primitive.NewObjectID().Hex()
+}
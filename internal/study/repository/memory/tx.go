@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/piatoss3612/my-study-bot/internal/study/repository"
+)
+
+type txKey struct{}
+
+// documents are kept as encoded bytes, so that stored values can not be changed by reference
+type collection map[string][]byte
+
+func (c collection) clone() collection {
+	cc := make(collection, len(c))
+	for k, v := range c {
+		cc[k] = v
+	}
+	return cc
+}
+
+type database struct {
+	collections map[string]collection
+}
+
+func newDatabase() *database {
+	return &database{
+		collections: map[string]collection{},
+	}
+}
+
+// collection of the name, missing collection is created so that db should be locked for writing
+func (db *database) collection(name string) collection {
+	c, ok := db.collections[name]
+	if !ok {
+		c = collection{}
+		db.collections[name] = c
+	}
+	return c
+}
+
+func (db *database) snapshot() map[string]collection {
+	snapshot := make(map[string]collection, len(db.collections))
+	for name, c := range db.collections {
+		snapshot[name] = c.clone()
+	}
+	return snapshot
+}
+
+type memoryTx struct {
+	db *database
+
+	mtx   sync.RWMutex // guards db
+	txMtx sync.Mutex   // serializes transactions and writes
+}
+
+// NewMemoryTx returns repository which keeps every data in memory, data are lost on restart
+func NewMemoryTx() repository.Tx {
+	return &memoryTx{
+		db: newDatabase(),
+	}
+}
+
+// execute fn in transaction, every change made in fn is rolled back if fn returns error
+func (tx *memoryTx) ExecTx(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	// nested transaction joins the outer one
+	if inTx(ctx) {
+		return fn(ctx)
+	}
+
+	tx.txMtx.Lock()
+	defer tx.txMtx.Unlock()
+
+	tx.mtx.RLock()
+	snapshot := tx.db.snapshot()
+	tx.mtx.RUnlock()
+
+	res, err := fn(context.WithValue(ctx, txKey{}, true))
+	if err != nil {
+		// rollback
+		tx.mtx.Lock()
+		tx.db.collections = snapshot
+		tx.mtx.Unlock()
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// read from collection, missing collections are read as empty ones without being created
+// as the read lock does not guard creating them
+func (tx *memoryTx) read(name string, fn func(c collection) error) error {
+	tx.mtx.RLock()
+	defer tx.mtx.RUnlock()
+
+	c, ok := tx.db.collections[name]
+	if !ok {
+		c = collection{}
+	}
+
+	return fn(c)
+}
+
+// write to collection, writes out of transaction wait for the running transaction
+func (tx *memoryTx) write(ctx context.Context, name string, fn func(c collection) error) error {
+	if !inTx(ctx) {
+		tx.txMtx.Lock()
+		defer tx.txMtx.Unlock()
+	}
+
+	tx.mtx.Lock()
+	defer tx.mtx.Unlock()
+
+	return fn(tx.db.collection(name))
+}
+
+func inTx(ctx context.Context) bool {
+	v, _ := ctx.Value(txKey{}).(bool)
+	return v
+}
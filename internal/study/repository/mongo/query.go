@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"time"
 
 	"github.com/piatoss3612/my-study-bot/internal/study"
 	"github.com/piatoss3612/my-study-bot/internal/study/repository"
@@ -34,6 +35,24 @@ func NewMongoQuery(client *mongo.Client, opts ...QueryOptsFn) repository.Query {
 	return q
 }
 
+func (q *mongoQuery) FindGuild(ctx context.Context, guildID string) (*study.Guild, error) {
+	collection := q.client.Database(q.dbname).Collection("guild")
+
+	filter := bson.M{"guild_id": guildID}
+
+	g := study.NewGuild()
+
+	err := collection.FindOne(ctx, filter).Decode(&g)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &g, nil
+}
+
 func (q *mongoQuery) FindStudy(ctx context.Context, guildID string) (*study.Study, error) {
 	collection := q.client.Database(q.dbname).Collection("study")
 
@@ -104,3 +123,169 @@ func (q *mongoQuery) FindRounds(ctx context.Context, guildID string) ([]*study.R
 
 	return rounds, nil
 }
+
+func (q *mongoQuery) FindOngoingRounds(ctx context.Context) ([]*study.Round, error) {
+	collection := q.client.Database(q.dbname).Collection("round")
+
+	filter := bson.M{"stage": bson.M{"$gt": study.StageWait, "$lt": study.StageFinished}}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var rounds []*study.Round
+
+	for cursor.Next(ctx) {
+		r := study.NewRound()
+
+		err := cursor.Decode(&r)
+		if err != nil {
+			return nil, err
+		}
+
+		rounds = append(rounds, &r)
+	}
+
+	return rounds, nil
+}
+
+func (q *mongoQuery) FindStudiesWithDueSeries(ctx context.Context, now time.Time) ([]*study.Study, error) {
+	collection := q.client.Database(q.dbname).Collection("study")
+
+	filter := bson.M{"series.next_run_at": bson.M{"$gt": time.Time{}, "$lte": now}}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var studies []*study.Study
+
+	for cursor.Next(ctx) {
+		s := study.New()
+
+		err := cursor.Decode(&s)
+		if err != nil {
+			return nil, err
+		}
+
+		studies = append(studies, &s)
+	}
+
+	return studies, nil
+}
+
+func (q *mongoQuery) FindAuditLogs(ctx context.Context, guildID string, limit int64) ([]*study.AuditLog, error) {
+	collection := q.client.Database(q.dbname).Collection("audit")
+
+	filter := bson.M{"guild_id": guildID}
+	opts := options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(limit)
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []*study.AuditLog
+
+	for cursor.Next(ctx) {
+		a := study.NewAuditLog()
+
+		err := cursor.Decode(&a)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, &a)
+	}
+
+	return logs, nil
+}
+
+func (q *mongoQuery) FindPendingOutboxEvents(ctx context.Context, limit int64) ([]*study.OutboxEvent, error) {
+	collection := q.client.Database(q.dbname).Collection("outbox")
+
+	filter := bson.M{"sent": false, "parked": bson.M{"$ne": true}}
+	// events created at the same millisecond are ordered by id, which increases in order of creation
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).SetLimit(limit)
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*study.OutboxEvent
+
+	for cursor.Next(ctx) {
+		var o study.OutboxEvent
+
+		err := cursor.Decode(&o)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, &o)
+	}
+
+	return events, nil
+}
+
+func (q *mongoQuery) FindRoundEvents(ctx context.Context, roundID string) ([]*study.RoundEvent, error) {
+	collection := q.client.Database(q.dbname).Collection("round_event")
+
+	filter := bson.M{"round_id": roundID}
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: 1}, {Key: "index", Value: 1}})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*study.RoundEvent
+
+	for cursor.Next(ctx) {
+		var e study.RoundEvent
+
+		err := cursor.Decode(&e)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, &e)
+	}
+
+	return events, nil
+}
+
+// feedbacks of the round in order of creation, feedbacks to every speaker are found if reviewee id is empty
+func (q *mongoQuery) FindFeedbacks(ctx context.Context, roundID, revieweeID string) ([]*study.Feedback, error) {
+	collection := q.client.Database(q.dbname).Collection("feedback")
+
+	filter := bson.M{"round_id": roundID}
+	if revieweeID != "" {
+		filter["reviewee_id"] = revieweeID
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var feedbacks []*study.Feedback
+
+	for cursor.Next(ctx) {
+		var f study.Feedback
+
+		err := cursor.Decode(&f)
+		if err != nil {
+			return nil, err
+		}
+
+		feedbacks = append(feedbacks, &f)
+	}
+
+	return feedbacks, nil
+}
@@ -34,6 +34,51 @@ func NewMongoStore(client *mongo.Client, opts ...StoreOptsFn) repository.Store {
 	return s
 }
 
+func (si *mongoStore) CreateGuild(ctx context.Context, g study.Guild) (*study.Guild, error) {
+	collection := si.client.Database(si.dbname).Collection("guild")
+
+	res, err := collection.InsertOne(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+
+	g.SetID(res.InsertedID.(primitive.ObjectID).Hex())
+	return &g, nil
+}
+
+func (si *mongoStore) UpdateGuild(ctx context.Context, g study.Guild) (*study.Guild, error) {
+	collection := si.client.Database(si.dbname).Collection("guild")
+
+	objID, err := primitive.ObjectIDFromHex(g.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": objID}
+
+	g.SetUpdatedAt(time.Now())
+
+	update := bson.D{
+		{
+			Key: "$set", Value: bson.D{
+				{Key: "name", Value: g.Name},
+				{Key: "owner_id", Value: g.OwnerID},
+				{Key: "active", Value: g.Active},
+				{Key: "joined_at", Value: g.JoinedAt},
+				{Key: "left_at", Value: g.LeftAt},
+				{Key: "updated_at", Value: g.UpdatedAt},
+			},
+		},
+	}
+
+	_, err = collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return &g, err
+}
+
 func (si *mongoStore) CreateStudy(ctx context.Context, s study.Study) (*study.Study, error) {
 	collection := si.client.Database(si.dbname).Collection("study")
 
@@ -67,9 +112,10 @@ func (si *mongoStore) UpdateStudy(ctx context.Context, s study.Study) (*study.St
 		return nil, err
 	}
 
-	filter := bson.M{"_id": objID}
+	filter := versionFilter(objID, s.Version)
 
 	s.SetUpdatedAt(time.Now())
+	s.IncrementVersion()
 
 	update := bson.D{
 		{
@@ -78,20 +124,38 @@ func (si *mongoStore) UpdateStudy(ctx context.Context, s study.Study) (*study.St
 				{Key: "notice_channel_id", Value: s.NoticeChannelID},
 				{Key: "reflection_channel_id", Value: s.ReflectionChannelID},
 				{Key: "manager_id", Value: s.ManagerID},
+				{Key: "managers", Value: s.Managers},
+				{Key: "manager_role_id", Value: s.ManagerRoleID},
 				{Key: "ongoing_round_id", Value: s.OngoingRoundID},
 				{Key: "spreadsheet_url", Value: s.SpreadsheetURL},
 				{Key: "current_stage", Value: s.CurrentStage},
 				{Key: "total_round", Value: s.TotalRound},
+				{Key: "series", Value: s.Series},
+				{Key: "time_zone", Value: s.TimeZone},
+				{Key: "locale", Value: s.Locale},
+				{Key: "voice_channel_id", Value: s.VoiceChannelID},
+				{Key: "attendance_share", Value: s.AttendanceShare},
+				{Key: "feedback_mode", Value: s.FeedbackMode},
+				{Key: "reviews_per_member", Value: s.ReviewsPerMember},
+				{Key: "assigned_reviews_only", Value: s.AssignedReviewsOnly},
+				{Key: "reminders", Value: s.Reminders},
+				{Key: "reminder_opt_outs", Value: s.ReminderOptOuts},
+				{Key: "version", Value: s.Version},
 				{Key: "updated_at", Value: s.UpdatedAt},
 			},
 		},
 	}
 
-	_, err = collection.UpdateOne(ctx, filter, update)
+	res, err := collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return nil, err
 	}
 
+	// study has been updated by another request
+	if res.MatchedCount == 0 {
+		return nil, study.ErrVersionConflict
+	}
+
 	return &s, err
 }
 
@@ -103,9 +167,10 @@ func (si mongoStore) UpdateRound(ctx context.Context, r study.Round) (*study.Rou
 		return nil, err
 	}
 
-	filter := bson.M{"_id": objID}
+	filter := versionFilter(objID, r.Version)
 
 	r.SetUpdatedAt(time.Now())
+	r.IncrementVersion()
 
 	update := bson.D{
 		{
@@ -115,15 +180,169 @@ func (si mongoStore) UpdateRound(ctx context.Context, r study.Round) (*study.Rou
 				{Key: "content_url", Value: r.ContentURL},
 				{Key: "stage", Value: r.Stage},
 				{Key: "members", Value: r.Members},
+				{Key: "deadlines", Value: r.Deadlines},
+				{Key: "paused", Value: r.Paused},
+				{Key: "speaker_cap", Value: r.SpeakerCap},
+				{Key: "waitlist", Value: r.Waitlist},
+				{Key: "lineup", Value: r.Lineup},
+				{Key: "current_speaker", Value: r.CurrentSpeaker},
+				{Key: "assignments", Value: r.Assignments},
+				{Key: "reminders_sent", Value: r.RemindersSent},
+				{Key: "presentation_started_at", Value: r.PresentationStartedAt},
+				{Key: "presentation_ended_at", Value: r.PresentationEndedAt},
+				{Key: "attendance_reviewed", Value: r.AttendanceReviewed},
+				{Key: "version", Value: r.Version},
 				{Key: "updated_at", Value: r.UpdatedAt},
 			},
 		},
 	}
 
-	_, err = collection.UpdateOne(ctx, filter, update)
+	res, err := collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return nil, err
 	}
 
+	// round has been updated by another request
+	if res.MatchedCount == 0 {
+		return nil, study.ErrVersionConflict
+	}
+
 	return &r, err
 }
+
+func (si *mongoStore) CreateAuditLog(ctx context.Context, a study.AuditLog) (*study.AuditLog, error) {
+	collection := si.client.Database(si.dbname).Collection("audit")
+
+	res, err := collection.InsertOne(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	a.SetID(res.InsertedID.(primitive.ObjectID).Hex())
+
+	return &a, nil
+}
+
+func (si *mongoStore) CreateOutboxEvent(ctx context.Context, o study.OutboxEvent) (*study.OutboxEvent, error) {
+	collection := si.client.Database(si.dbname).Collection("outbox")
+
+	res, err := collection.InsertOne(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	o.SetID(res.InsertedID.(primitive.ObjectID).Hex())
+
+	return &o, nil
+}
+
+func (si *mongoStore) UpdateOutboxEvent(ctx context.Context, o study.OutboxEvent) (*study.OutboxEvent, error) {
+	collection := si.client.Database(si.dbname).Collection("outbox")
+
+	objID, err := primitive.ObjectIDFromHex(o.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": objID}
+
+	update := bson.D{
+		{
+			Key: "$set", Value: bson.D{
+				{Key: "sent", Value: o.Sent},
+				{Key: "attempts", Value: o.Attempts},
+				{Key: "last_error", Value: o.LastError},
+				{Key: "sent_at", Value: o.SentAt},
+				{Key: "parked", Value: o.Parked},
+				{Key: "locked_by", Value: o.LockedBy},
+				{Key: "locked_until", Value: o.LockedUntil},
+			},
+		},
+	}
+
+	_, err = collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// claim pending event until the time, events claimed by another relay are not claimed until the lease expires
+func (si *mongoStore) ClaimOutboxEvent(ctx context.Context, id, owner string, now, until time.Time) (bool, error) {
+	collection := si.client.Database(si.dbname).Collection("outbox")
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{
+		"_id":    objID,
+		"sent":   false,
+		"parked": bson.M{"$ne": true},
+		"$or": bson.A{
+			bson.M{"locked_by": owner},
+			bson.M{"locked_until": bson.M{"$lte": now}},
+			bson.M{"locked_until": bson.M{"$exists": false}},
+		},
+	}
+
+	update := bson.D{
+		{
+			Key: "$set", Value: bson.D{
+				{Key: "locked_by", Value: owner},
+				{Key: "locked_until", Value: until},
+			},
+		},
+	}
+
+	res, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return res.MatchedCount == 1, nil
+}
+
+func (si *mongoStore) CreateRoundEvent(ctx context.Context, e study.RoundEvent) (*study.RoundEvent, error) {
+	collection := si.client.Database(si.dbname).Collection("round_event")
+
+	res, err := collection.InsertOne(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	e.SetID(res.InsertedID.(primitive.ObjectID).Hex())
+
+	return &e, nil
+}
+
+func (si *mongoStore) CreateFeedback(ctx context.Context, f study.Feedback) (*study.Feedback, error) {
+	collection := si.client.Database(si.dbname).Collection("feedback")
+
+	res, err := collection.InsertOne(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	f.SetID(res.InsertedID.(primitive.ObjectID).Hex())
+
+	return &f, nil
+}
+
+// filter to update the document only if the version has not been changed
+func versionFilter(objID primitive.ObjectID, version int64) bson.M {
+	// documents created before versioning have no version field
+	if version == 0 {
+		return bson.M{
+			"_id": objID,
+			"$or": bson.A{
+				bson.M{"version": 0},
+				bson.M{"version": bson.M{"$exists": false}},
+			},
+		}
+	}
+
+	return bson.M{"_id": objID, "version": version}
+}
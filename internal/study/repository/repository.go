@@ -2,21 +2,37 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/piatoss3612/my-study-bot/internal/study"
 )
 
 type Query interface {
+	FindGuild(ctx context.Context, guildID string) (*study.Guild, error)
 	FindStudy(ctx context.Context, guildID string) (*study.Study, error)
 	FindRound(ctx context.Context, roundID string) (*study.Round, error)
 	FindRounds(ctx context.Context, guildID string) ([]*study.Round, error)
+	FindOngoingRounds(ctx context.Context) ([]*study.Round, error)
+	FindStudiesWithDueSeries(ctx context.Context, now time.Time) ([]*study.Study, error)
+	FindAuditLogs(ctx context.Context, guildID string, limit int64) ([]*study.AuditLog, error)
+	FindPendingOutboxEvents(ctx context.Context, limit int64) ([]*study.OutboxEvent, error)
+	FindRoundEvents(ctx context.Context, roundID string) ([]*study.RoundEvent, error)
+	FindFeedbacks(ctx context.Context, roundID, revieweeID string) ([]*study.Feedback, error)
 }
 
 type Store interface {
+	CreateGuild(ctx context.Context, g study.Guild) (*study.Guild, error)
+	UpdateGuild(ctx context.Context, g study.Guild) (*study.Guild, error)
 	CreateStudy(ctx context.Context, s study.Study) (*study.Study, error)
 	UpdateStudy(ctx context.Context, s study.Study) (*study.Study, error)
 	CreateRound(ctx context.Context, r study.Round) (*study.Round, error)
 	UpdateRound(ctx context.Context, r study.Round) (*study.Round, error)
+	CreateAuditLog(ctx context.Context, a study.AuditLog) (*study.AuditLog, error)
+	CreateOutboxEvent(ctx context.Context, o study.OutboxEvent) (*study.OutboxEvent, error)
+	UpdateOutboxEvent(ctx context.Context, o study.OutboxEvent) (*study.OutboxEvent, error)
+	ClaimOutboxEvent(ctx context.Context, id, owner string, now, until time.Time) (bool, error)
+	CreateRoundEvent(ctx context.Context, e study.RoundEvent) (*study.RoundEvent, error)
+	CreateFeedback(ctx context.Context, f study.Feedback) (*study.Feedback, error)
 }
 
 type Tx interface {
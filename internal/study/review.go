@@ -0,0 +1,106 @@
+package study
+
+import "sort"
+
+// MaxReviewsPerMember is the largest number of speakers assigned to a reviewer
+const MaxReviewsPerMember = 10
+
+// AssignReviewees assigns n speakers to each reviewer in round-robin order,
+// speakers with fewer reviewers are assigned first and nobody reviews themselves
+func AssignReviewees(reviewerIDs, speakerIDs []string, n int) map[string][]string {
+	assignments := map[string][]string{}
+	if len(speakerIDs) == 0 || n <= 0 {
+		return assignments
+	}
+
+	load := map[string]int{}
+	cursor := 0
+
+	for _, reviewer := range reviewerIDs {
+		assigned := map[string]bool{reviewer: true}
+
+		for k := 0; k < n; k++ {
+			// least loaded speaker from the cursor, the first one found wins ties
+			best := -1
+			for j := 0; j < len(speakerIDs); j++ {
+				idx := (cursor + j) % len(speakerIDs)
+				if assigned[speakerIDs[idx]] {
+					continue
+				}
+				if best == -1 || load[speakerIDs[idx]] < load[speakerIDs[best]] {
+					best = idx
+				}
+			}
+
+			// no more speakers to review
+			if best == -1 {
+				break
+			}
+
+			id := speakerIDs[best]
+			assigned[id] = true
+			load[id]++
+			assignments[reviewer] = append(assignments[reviewer], id)
+			cursor = (best + 1) % len(speakerIDs)
+		}
+	}
+
+	return assignments
+}
+
+// assignments of n attended speakers to each attended member
+func (r *Round) ReviewAssignments(n int) map[string][]string {
+	speakers := []string{}
+	for _, id := range r.Speakers() {
+		if m := r.Members[id]; m.IsAttended() {
+			speakers = append(speakers, id)
+		}
+	}
+
+	reviewers := []string{}
+	for id, m := range r.Members {
+		if m.IsAttended() {
+			reviewers = append(reviewers, id)
+		}
+	}
+	sort.Strings(reviewers)
+
+	return AssignReviewees(reviewers, speakers, n)
+}
+
+func (r *Round) HasAssignments() bool {
+	return len(r.Assignments) > 0
+}
+
+// ids of reviewers having assignments in order of id
+func (r *Round) AssignedReviewers() []string {
+	ids := make([]string, 0, len(r.Assignments))
+	for id := range r.Assignments {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (r *Round) AssignedReviewees(reviewerID string) []string {
+	return r.Assignments[reviewerID]
+}
+
+func (r *Round) IsAssignedReviewee(reviewerID, revieweeID string) bool {
+	for _, id := range r.Assignments[reviewerID] {
+		if id == revieweeID {
+			return true
+		}
+	}
+	return false
+}
+
+// number of assigned speakers the reviewer has reviewed and number of assigned speakers
+func (r *Round) ReviewProgress(reviewerID string) (done, total int) {
+	for _, id := range r.Assignments[reviewerID] {
+		if m, ok := r.GetMember(id); ok && m.IsReviewer(reviewerID) {
+			done++
+		}
+	}
+	return done, len(r.Assignments[reviewerID])
+}
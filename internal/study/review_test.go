@@ -0,0 +1,111 @@
+package study
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssignReviewees(t *testing.T) {
+	tests := []struct {
+		name      string
+		reviewers []string
+		speakers  []string
+		n         int
+		want      map[string][]string
+	}{
+		{
+			name:      "no speakers",
+			reviewers: []string{"a", "b"},
+			speakers:  nil,
+			n:         1,
+			want:      map[string][]string{},
+		},
+		{
+			name:      "no reviews",
+			reviewers: []string{"a", "b"},
+			speakers:  []string{"a", "b"},
+			n:         0,
+			want:      map[string][]string{},
+		},
+		{
+			name:      "round robin without reviewing themselves",
+			reviewers: []string{"a", "b", "c"},
+			speakers:  []string{"a", "b", "c"},
+			n:         1,
+			want: map[string][]string{
+				"a": {"b"},
+				"b": {"c"},
+				"c": {"a"},
+			},
+		},
+		{
+			name:      "least reviewed speakers first",
+			reviewers: []string{"x", "y", "z"},
+			speakers:  []string{"a", "b"},
+			n:         1,
+			want: map[string][]string{
+				"x": {"a"},
+				"y": {"b"},
+				"z": {"a"},
+			},
+		},
+		{
+			name:      "more reviews than speakers",
+			reviewers: []string{"a", "x"},
+			speakers:  []string{"a", "b"},
+			n:         3,
+			want: map[string][]string{
+				"a": {"b"},
+				"x": {"a", "b"},
+			},
+		},
+		{
+			name:      "only speaker reviews nobody",
+			reviewers: []string{"a"},
+			speakers:  []string{"a"},
+			n:         1,
+			want:      map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AssignReviewees(tt.reviewers, tt.speakers, tt.n)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AssignReviewees() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignRevieweesBalance(t *testing.T) {
+	reviewers := []string{"a", "b", "c", "d", "e", "f"}
+	speakers := []string{"a", "b", "c", "d"}
+	n := 2
+
+	load := map[string]int{}
+	for reviewer, assigned := range AssignReviewees(reviewers, speakers, n) {
+		if len(assigned) != n {
+			t.Errorf("%s is assigned %d speakers, want %d", reviewer, len(assigned), n)
+		}
+
+		seen := map[string]bool{}
+		for _, id := range assigned {
+			if id == reviewer {
+				t.Errorf("%s is assigned to review themselves", reviewer)
+			}
+			if seen[id] {
+				t.Errorf("%s is assigned %s twice", reviewer, id)
+			}
+			seen[id] = true
+			load[id]++
+		}
+	}
+
+	// 12 reviews are spread over 4 speakers
+	for _, id := range speakers {
+		if load[id] != 3 {
+			t.Errorf("%s is reviewed %d times, want 3", id, load[id])
+		}
+	}
+}
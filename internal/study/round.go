@@ -1,6 +1,7 @@
 package study
 
 import (
+	"errors"
 	"time"
 )
 
@@ -8,14 +9,38 @@ type Round struct {
 	ID      string `bson:"_id,omitempty" json:"id,omitempty"`
 	GuildID string `bson:"guild_id" json:"guild_id,omitempty"`
 
-	Number     int8              `bson:"number" json:"number"`
-	Stage      Stage             `bson:"stage" json:"stage"`
-	Title      string            `bson:"title" json:"title"`
-	ContentURL string            `bson:"content_url" json:"content_url"`
-	Members    map[string]Member `bson:"members" json:"members"`
+	Number         int8                `bson:"number" json:"number"`
+	Stage          Stage               `bson:"stage" json:"stage"`
+	Title          string              `bson:"title" json:"title"`
+	ContentURL     string              `bson:"content_url" json:"content_url"`
+	Members        map[string]Member   `bson:"members" json:"members"`
+	Deadlines      map[Stage]time.Time `bson:"deadlines" json:"deadlines,omitempty"`
+	Pipeline       Pipeline            `bson:"pipeline" json:"pipeline,omitempty"`
+	Paused         bool                `bson:"paused" json:"paused,omitempty"`
+	SpeakerCap     int                 `bson:"speaker_cap" json:"speaker_cap,omitempty"` // 0 means no limit
+	Waitlist       []string            `bson:"waitlist" json:"waitlist,omitempty"`       // ids of members waiting for a seat in order
+	Lineup         []string            `bson:"lineup" json:"lineup,omitempty"`           // ids of speakers in presentation order
+	CurrentSpeaker string              `bson:"current_speaker" json:"current_speaker,omitempty"`
+	Assignments    map[string][]string `bson:"assignments" json:"assignments,omitempty"`       // ids of speakers assigned to each reviewer
+	RemindersSent  map[string]bool     `bson:"reminders_sent" json:"reminders_sent,omitempty"` // keys of reminders sent
+	Version        int64               `bson:"version" json:"version"`
+
+	// presentation session during which presence in the voice channel is tracked
+	PresentationStartedAt time.Time `bson:"presentation_started_at,omitempty" json:"presentation_started_at,omitempty"`
+	PresentationEndedAt   time.Time `bson:"presentation_ended_at,omitempty" json:"presentation_ended_at,omitempty"`
+	AttendanceReviewed    bool      `bson:"attendance_reviewed" json:"attendance_reviewed,omitempty"`
 
 	CreatedAt time.Time `bson:"created_at" json:"created_at"`
 	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+
+	// events raised by updates, stored to outbox with the round
+	events []Event
+	// errors of events which could not be raised, updates fail with them
+	eventErr error
+	// changes recorded by updates, stored to history with the round
+	history []RoundEvent
+	// feedbacks written by updates, stored with the round
+	feedbacks []Feedback
 }
 
 func NewRound() Round {
@@ -25,6 +50,8 @@ func NewRound() Round {
 		Number:    0,
 		Title:     "",
 		Members:   map[string]Member{},
+		Deadlines: map[Stage]time.Time{},
+		Waitlist:  []string{},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -54,6 +81,27 @@ func (r *Round) SetStage(stage Stage) {
 	r.Stage = stage
 }
 
+func (r *Round) SetPipeline(p Pipeline) {
+	r.Pipeline = p
+}
+
+// rounds created before pipelines were introduced follow the default pipeline
+func (r *Round) GetPipeline() Pipeline {
+	if r.Pipeline.IsZero() {
+		p, _ := PipelineTemplate(DefaultPipelineName)
+		return p
+	}
+	return r.Pipeline
+}
+
+func (r *Round) SetPaused(paused bool) {
+	r.Paused = paused
+}
+
+func (r *Round) IsPaused() bool {
+	return r.Paused
+}
+
 func (r *Round) SetMember(memberID string, member Member) {
 	r.Members[memberID] = member
 }
@@ -71,6 +119,138 @@ func (r *Round) GetMembers() []Member {
 	return members
 }
 
+func (r *Round) SetSpeakerCap(speakerCap int) {
+	r.SpeakerCap = speakerCap
+}
+
+func (r *Round) HasSpeakerCap() bool {
+	return r.SpeakerCap > 0
+}
+
+// number of registered speakers, waitlisted members are not counted
+func (r *Round) RegisteredCount() int {
+	count := 0
+	for _, m := range r.Members {
+		if m.IsRegistered() {
+			count++
+		}
+	}
+	return count
+}
+
+// check if no more speakers can be registered without waiting
+func (r *Round) IsFull() bool {
+	return r.HasSpeakerCap() && r.RegisteredCount() >= r.SpeakerCap
+}
+
+// position of the member in the waitlist starting from 1, 0 if the member is not waiting
+func (r *Round) WaitlistPosition(memberID string) int {
+	for i, id := range r.Waitlist {
+		if id == memberID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (r *Round) IsWaitlisted(memberID string) bool {
+	return r.WaitlistPosition(memberID) > 0
+}
+
+func (r *Round) addToWaitlist(memberID string) {
+	if !r.IsWaitlisted(memberID) {
+		r.Waitlist = append(r.Waitlist, memberID)
+	}
+}
+
+func (r *Round) removeFromWaitlist(memberID string) {
+	waitlist := make([]string, 0, len(r.Waitlist))
+	for _, id := range r.Waitlist {
+		if id != memberID {
+			waitlist = append(waitlist, id)
+		}
+	}
+	r.Waitlist = waitlist
+}
+
+// waitlist with the member moved to the position starting from 1, the last position is used if it is out of range
+func (r *Round) MovedWaitlist(memberID string, position int) []string {
+	waitlist := make([]string, 0, len(r.Waitlist))
+	for _, id := range r.Waitlist {
+		if id != memberID {
+			waitlist = append(waitlist, id)
+		}
+	}
+
+	if position < 1 || position > len(waitlist) {
+		position = len(waitlist) + 1
+	}
+
+	waitlist = append(waitlist[:position-1], append([]string{memberID}, waitlist[position-1:]...)...)
+	return waitlist
+}
+
+func (r *Round) SetDeadline(stage Stage, deadline time.Time) {
+	if r.Deadlines == nil {
+		r.Deadlines = map[Stage]time.Time{}
+	}
+	r.Deadlines[stage] = deadline
+}
+
+func (r *Round) RemoveDeadline(stage Stage) {
+	delete(r.Deadlines, stage)
+}
+
+func (r *Round) GetDeadline(stage Stage) (time.Time, bool) {
+	deadline, ok := r.Deadlines[stage]
+	return deadline, ok
+}
+
+// check if the deadline of the current stage has passed, deadlines of paused round do not pass
+func (r *Round) IsDeadlinePassed(now time.Time) bool {
+	if r.IsPaused() {
+		return false
+	}
+
+	deadline, ok := r.GetDeadline(r.Stage)
+	if !ok {
+		return false
+	}
+	return !now.Before(deadline)
+}
+
+func (r *Round) IncrementVersion() {
+	r.Version++
+}
+
 func (r *Round) SetUpdatedAt(updatedAt time.Time) {
 	r.UpdatedAt = updatedAt
 }
+
+// raise an event to be published after the round is stored
+func (r *Round) RaiseEvent(topic EventTopic, description string, data ...[]byte) {
+	evt, err := NewEvent(topic, r.GuildID, description, data...)
+	if err != nil {
+		r.AddEventError(err)
+		return
+	}
+	r.events = append(r.events, evt)
+}
+
+// record the error of an event which could not be raised, the round should not be stored without the event
+func (r *Round) AddEventError(err error) {
+	r.eventErr = errors.Join(r.eventErr, err)
+}
+
+func (r *Round) EventError() error {
+	return r.eventErr
+}
+
+func (r *Round) Events() []Event {
+	return r.events
+}
+
+func (r *Round) ClearEvents() {
+	r.events = nil
+	r.eventErr = nil
+}
@@ -0,0 +1,136 @@
+package study
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultSeriesTitleTemplate = "{n}회차 발표"
+	HolidayLayout              = "2006-01-02"
+)
+
+// Series creates rounds of the study on the cron schedule
+type Series struct {
+	Cron          string                  `bson:"cron" json:"cron"`
+	TitleTemplate string                  `bson:"title_template" json:"title_template"` // {n} is replaced with the round number
+	Pipeline      string                  `bson:"pipeline" json:"pipeline,omitempty"`
+	StageOffsets  map[Stage]time.Duration `bson:"stage_offsets" json:"stage_offsets,omitempty"` // deadline of the stage from the scheduled time
+	Holidays      []string                `bson:"holidays" json:"holidays,omitempty"`           // dates to skip, formatted as 2006-01-02
+	NextRunAt     time.Time               `bson:"next_run_at" json:"next_run_at"`
+	LastRunAt     time.Time               `bson:"last_run_at" json:"last_run_at,omitempty"`
+}
+
+func NewSeries(cron, titleTemplate, pipeline string) Series {
+	if titleTemplate == "" {
+		titleTemplate = DefaultSeriesTitleTemplate
+	}
+
+	return Series{
+		Cron:          cron,
+		TitleTemplate: titleTemplate,
+		Pipeline:      pipeline,
+		StageOffsets:  map[Stage]time.Duration{},
+		Holidays:      []string{},
+	}
+}
+
+// Validate checks the schedule, the pipeline and the stages of offsets
+func (sr *Series) Validate() error {
+	if _, err := ParseCron(sr.Cron); err != nil {
+		return err
+	}
+
+	p, err := PipelineTemplate(sr.Pipeline)
+	if err != nil {
+		return err
+	}
+
+	for stage, offset := range sr.StageOffsets {
+		if !p.Contains(stage) {
+			return errors.Join(ErrInvalidArgs, Errorf("진행 방식에 포함되지 않은 단계입니다: %s", stage.String()))
+		}
+
+		if offset <= 0 {
+			return errors.Join(ErrInvalidArgs, Errorf("마감 시간은 라운드 시작 이후여야 합니다: %s", stage.String()))
+		}
+	}
+
+	for _, h := range sr.Holidays {
+		if _, err := time.Parse(HolidayLayout, h); err != nil {
+			return errors.Join(ErrInvalidArgs, Errorf("휴일은 %s 형식으로 입력해주세요: %s", HolidayLayout, h))
+		}
+	}
+
+	return nil
+}
+
+func (sr *Series) SetStageOffset(stage Stage, offset time.Duration) {
+	if sr.StageOffsets == nil {
+		sr.StageOffsets = map[Stage]time.Duration{}
+	}
+	sr.StageOffsets[stage] = offset
+}
+
+// stages with offsets in order of the stage
+func (sr *Series) OffsetStages() []Stage {
+	stages := make([]Stage, 0, len(sr.StageOffsets))
+	for stage := range sr.StageOffsets {
+		stages = append(stages, stage)
+	}
+
+	sort.Slice(stages, func(i, j int) bool { return stages[i] < stages[j] })
+
+	return stages
+}
+
+func (sr *Series) AddHoliday(date string) {
+	for _, h := range sr.Holidays {
+		if h == date {
+			return
+		}
+	}
+	sr.Holidays = append(sr.Holidays, date)
+	sort.Strings(sr.Holidays)
+}
+
+// check if the date of t is one of holidays
+func (sr *Series) IsHoliday(t time.Time) bool {
+	date := t.Format(HolidayLayout)
+	for _, h := range sr.Holidays {
+		if h == date {
+			return true
+		}
+	}
+	return false
+}
+
+// title of the round with the number
+func (sr *Series) Title(number int8) string {
+	return strings.ReplaceAll(sr.TitleTemplate, "{n}", strconv.Itoa(int(number)))
+}
+
+// schedule the next run after t
+func (sr *Series) Schedule(t time.Time) error {
+	cs, err := ParseCron(sr.Cron)
+	if err != nil {
+		return err
+	}
+
+	next := cs.Next(t)
+	if next.IsZero() {
+		return errors.Join(ErrInvalidCron, Errorf("다음 일정을 찾을 수 없습니다"))
+	}
+
+	sr.NextRunAt = next
+
+	return nil
+}
+
+// check if the scheduled run is due at t
+func (sr *Series) IsDue(t time.Time) bool {
+	return !sr.NextRunAt.IsZero() && !t.Before(sr.NextRunAt)
+}
@@ -1,16 +1,31 @@
 package service
 
 import (
+	"bytes"
 	"context"
-	"sync"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/piatoss3612/my-study-bot/internal/study"
 	"github.com/piatoss3612/my-study-bot/internal/study/repository"
 )
 
 type Service interface {
+	GetGuild(ctx context.Context, guildID string) (*study.Guild, error)
+	JoinGuild(ctx context.Context, params *JoinGuildParams) (*study.Guild, error)
+	LeaveGuild(ctx context.Context, guildID string) (*study.Guild, error)
 	GetRound(ctx context.Context, roundID string) (*study.Round, error)
 	GetRounds(ctx context.Context, guildID string) ([]*study.Round, error)
+	GetOngoingRounds(ctx context.Context) ([]*study.Round, error)
+	GetStudiesWithDueSeries(ctx context.Context, now time.Time) ([]*study.Study, error)
+	GetRoundTimeline(ctx context.Context, roundID string) ([]*study.RoundEvent, error)
+	GetFeedbacks(ctx context.Context, roundID, revieweeID string) ([]*study.Feedback, error)
+	ProjectRound(ctx context.Context, roundID string) (*study.Round, error)
+	GetAuditLogs(ctx context.Context, guildID string, limit int64) ([]*study.AuditLog, error)
+	RecordAuditLog(ctx context.Context, params *AuditLogParams) (*study.AuditLog, error)
 	GetStudy(ctx context.Context, guildID string) (*study.Study, error)
 	NewRound(ctx context.Context, params *NewRoundParams) (*study.Study, error)
 	NewStudy(ctx context.Context, params *NewStudyParams) (*study.Study, error)
@@ -18,26 +33,63 @@ type Service interface {
 	UpdateStudy(ctx context.Context, params *UpdateParams, update UpdateFunc, validators ...UpdateValidator) (*study.Study, error)
 }
 
+var (
+	defaultMaxRetries = 5
+	defaultRetryDelay = 50 * time.Millisecond
+)
+
 type studyService struct {
 	tx repository.Tx
 
-	mtx *sync.Mutex
+	maxRetries int
+	retryDelay time.Duration
+}
+
+type ServiceOptsFunc func(*studyService)
+
+func WithMaxRetries(n int) ServiceOptsFunc {
+	return func(svc *studyService) {
+		svc.maxRetries = n
+	}
+}
+
+func WithRetryDelay(d time.Duration) ServiceOptsFunc {
+	return func(svc *studyService) {
+		svc.retryDelay = d
+	}
 }
 
 // create new service
-func New(tx repository.Tx) Service {
+func New(tx repository.Tx, opts ...ServiceOptsFunc) Service {
 	svc := &studyService{
-		tx:  tx,
-		mtx: &sync.Mutex{},
+		tx:         tx,
+		maxRetries: defaultMaxRetries,
+		retryDelay: defaultRetryDelay,
+	}
+
+	for _, opt := range opts {
+		opt(svc)
 	}
+
 	return svc
 }
 
+type JoinGuildParams struct {
+	GuildID string
+	Name    string
+	OwnerID string
+}
+
 type NewRoundParams struct {
-	GuildID   string
-	ManagerID string
-	Title     string
-	MemberIDs []string
+	GuildID        string
+	ManagerID      string
+	ManagerRoleIDs []string
+	Title          string
+	MemberIDs      []string
+	Pipeline       string // name of pipeline template, default if empty
+
+	// deadlines of the stages set with the round
+	Deadlines map[study.Stage]time.Time
 }
 
 type NewStudyParams struct {
@@ -47,26 +99,143 @@ type NewStudyParams struct {
 	ReflectionChannelID string
 }
 
+type AuditLogParams struct {
+	GuildID string
+	ActorID string
+	Action  string
+	Details string
+	Err     error
+}
+
 type UpdateParams struct {
-	GuildID    string
-	ManagerID  string
-	ChannelID  string
-	MemberID   string
-	MemberName string
-	Subject    string
-	ContentURL string
-	ReviewerID string
-	RevieweeID string
+	GuildID        string
+	ManagerID      string
+	ManagerRoleIDs []string
+	RoleID         string
+	ChannelID      string
+	MemberID       string
+	MemberName     string
+	Subject        string
+	ContentURL     string
+	ReviewerID     string
+	RevieweeID     string
+	Stage          study.Stage
+	Deadline       time.Time
+	Reason         string
+	Series         *study.Series
+	TimeZone       string
+	Locale         study.Locale
+	SpeakerCap     int
+	Flag           study.MemberFlag
+	Order          study.LineupOrder
+	MemberIDs      []string
+	Position       int
+	Share          int
+	Content        string
+	Rubric         study.Rubric
+	FeedbackMode   study.FeedbackMode
+	Reviews        int
+	AssignedOnly   bool
+	Reminder       study.ReminderRule
+	OptOut         bool
+
+	// ids of members promoted from the waitlist, set by the update
+	Promoted []string
+	// whether reviewers are assigned, set by the update
+	Assigned bool
+	// ids of members to be reminded, set by the update
+	Reminded []string
 }
 
 type UpdateFunc func(*study.Study, *study.Round, *UpdateParams)
 type UpdateValidator func(*study.Study, *study.Round, *UpdateParams) error
 
+// get guild settings by guild id
+func (svc *studyService) GetGuild(ctx context.Context, guildID string) (*study.Guild, error) {
+	g, err := svc.tx.FindGuild(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if g == nil {
+		return nil, study.ErrGuildNotFound
+	}
+
+	return g, nil
+}
+
+// create or reactivate guild settings when the bot joins a guild
+func (svc *studyService) JoinGuild(ctx context.Context, params *JoinGuildParams) (*study.Guild, error) {
+	if params == nil {
+		return nil, study.ErrNilParams
+	}
+
+	txFn := func(sc context.Context) (interface{}, error) {
+		// find guild
+		g, err := svc.tx.FindGuild(sc, params.GuildID)
+		if err != nil {
+			return nil, err
+		}
+
+		// if there is no guild, create new one
+		if g == nil {
+			ng := study.NewGuild()
+			ng.SetGuildID(params.GuildID)
+			ng.SetName(params.Name)
+			ng.SetOwnerID(params.OwnerID)
+
+			return svc.tx.CreateGuild(sc, ng)
+		}
+
+		// update guild
+		g.SetName(params.Name)
+		g.SetOwnerID(params.OwnerID)
+		g.Join()
+
+		return svc.tx.UpdateGuild(sc, *g)
+	}
+
+	// execute transaction
+	g, err := svc.tx.ExecTx(ctx, txFn)
+	if err != nil {
+		return nil, err
+	}
+
+	// return joined guild
+	return g.(*study.Guild), nil
+}
+
+// deactivate guild settings when the bot leaves a guild
+func (svc *studyService) LeaveGuild(ctx context.Context, guildID string) (*study.Guild, error) {
+	txFn := func(sc context.Context) (interface{}, error) {
+		// find guild
+		g, err := svc.tx.FindGuild(sc, guildID)
+		if err != nil {
+			return nil, err
+		}
+
+		// if there is no guild, return error
+		if g == nil {
+			return nil, study.ErrGuildNotFound
+		}
+
+		g.Leave()
+
+		return svc.tx.UpdateGuild(sc, *g)
+	}
+
+	// execute transaction
+	g, err := svc.tx.ExecTx(ctx, txFn)
+	if err != nil {
+		return nil, err
+	}
+
+	// return left guild
+	return g.(*study.Guild), nil
+}
+
 // get round by id
 func (svc *studyService) GetRound(ctx context.Context, roundID string) (*study.Round, error) {
-	defer svc.mtx.Unlock()
-	svc.mtx.Lock()
-
 	txFn := func(sc context.Context) (interface{}, error) {
 		// find ongoing round
 		r, err := svc.tx.FindRound(sc, roundID)
@@ -93,9 +262,6 @@ func (svc *studyService) GetRound(ctx context.Context, roundID string) (*study.R
 
 // get all rounds of study by guild id
 func (svc *studyService) GetRounds(ctx context.Context, guildID string) ([]*study.Round, error) {
-	defer svc.mtx.Unlock()
-	svc.mtx.Lock()
-
 	r, err := svc.tx.FindRounds(ctx, guildID)
 	if err != nil {
 		return nil, err
@@ -108,11 +274,70 @@ func (svc *studyService) GetRounds(ctx context.Context, guildID string) ([]*stud
 	return r, nil
 }
 
+// get all ongoing rounds of every study
+func (svc *studyService) GetOngoingRounds(ctx context.Context) ([]*study.Round, error) {
+	return svc.tx.FindOngoingRounds(ctx)
+}
+
+// get studies whose series should create a round at now
+func (svc *studyService) GetStudiesWithDueSeries(ctx context.Context, now time.Time) ([]*study.Study, error) {
+	return svc.tx.FindStudiesWithDueSeries(ctx, now)
+}
+
+// get every change of the round in order
+func (svc *studyService) GetRoundTimeline(ctx context.Context, roundID string) ([]*study.RoundEvent, error) {
+	return svc.tx.FindRoundEvents(ctx, roundID)
+}
+
+// get feedbacks of the round, feedbacks to every speaker are returned if reviewee id is empty
+func (svc *studyService) GetFeedbacks(ctx context.Context, roundID, revieweeID string) ([]*study.Feedback, error) {
+	return svc.tx.FindFeedbacks(ctx, roundID, revieweeID)
+}
+
+// rebuild the round from its history, rounds created before history was recorded can not be rebuilt
+func (svc *studyService) ProjectRound(ctx context.Context, roundID string) (*study.Round, error) {
+	events, err := svc.tx.FindRoundEvents(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 || events[0].Type != study.RoundEventCreated {
+		return nil, study.ErrRoundNotFound
+	}
+
+	history := make([]study.RoundEvent, 0, len(events))
+	for _, e := range events {
+		history = append(history, *e)
+	}
+
+	r := study.ProjectRound(history)
+
+	return &r, nil
+}
+
+// get recent audit logs of study by guild id
+func (svc *studyService) GetAuditLogs(ctx context.Context, guildID string, limit int64) ([]*study.AuditLog, error) {
+	return svc.tx.FindAuditLogs(ctx, guildID, limit)
+}
+
+// record an action taken by a manager
+func (svc *studyService) RecordAuditLog(ctx context.Context, params *AuditLogParams) (*study.AuditLog, error) {
+	if params == nil {
+		return nil, study.ErrNilParams
+	}
+
+	a := study.NewAuditLog()
+	a.SetGuildID(params.GuildID)
+	a.SetActorID(params.ActorID)
+	a.SetAction(params.Action)
+	a.SetDetails(params.Details)
+	a.SetError(params.Err)
+
+	return svc.tx.CreateAuditLog(ctx, a)
+}
+
 // get study by guild id
 func (svc *studyService) GetStudy(ctx context.Context, guildID string) (*study.Study, error) {
-	defer svc.mtx.Unlock()
-	svc.mtx.Lock()
-
 	s, err := svc.tx.FindStudy(ctx, guildID)
 	if err != nil {
 		return nil, err
@@ -127,9 +352,6 @@ func (svc *studyService) GetStudy(ctx context.Context, guildID string) (*study.S
 
 // initialize new study round
 func (svc *studyService) NewRound(ctx context.Context, params *NewRoundParams) (*study.Study, error) {
-	defer svc.mtx.Unlock()
-	svc.mtx.Lock()
-
 	if params == nil {
 		return nil, study.ErrNilParams
 	}
@@ -147,7 +369,7 @@ func (svc *studyService) NewRound(ctx context.Context, params *NewRoundParams) (
 		}
 
 		// check if manager is the one who requested
-		if !s.IsManager(params.ManagerID) {
+		if !s.IsManager(params.ManagerID, params.ManagerRoleIDs...) {
 			return nil, study.ErrInvalidManager
 		}
 
@@ -156,20 +378,49 @@ func (svc *studyService) NewRound(ctx context.Context, params *NewRoundParams) (
 			return nil, study.ErrRoundExists
 		}
 
+		// stages of new round
+		p, err := study.PipelineTemplate(params.Pipeline)
+		if err != nil {
+			return nil, err
+		}
+
 		// increase total round count
 		s.IncrementTotalRound()
 
-		// create new round
+		// create new round with initial members
 		r := study.NewRound()
 		r.SetGuildID(s.GuildID)
-		r.SetNumber(s.TotalRound)
-		r.SetTitle(params.Title)
-		r.SetStage(study.StageRegistrationOpened)
+		r.Record(study.NewRoundEvent(study.RoundEventCreated, "", study.RoundEventData{
+			Number:    s.TotalRound,
+			Title:     params.Title,
+			MemberIDs: params.MemberIDs,
+			Stage:     p.First(),
+			Pipeline:  &p,
+		}))
+
+		// deadlines are set in the same transaction, so that the round is not left without them
+		for _, stage := range deadlineStages(params.Deadlines) {
+			deadline := params.Deadlines[stage]
+
+			if !p.Contains(stage) {
+				return nil, errors.Join(study.ErrInvalidStage, study.Errorf("마감 시간을 설정할 수 없는 단계입니다"))
+			}
+
+			if !deadline.After(time.Now()) {
+				return nil, errors.Join(study.ErrInvalidDeadline, study.Errorf("마감 시간은 현재 시간 이후여야 합니다"))
+			}
 
-		// set initial members
-		for _, id := range params.MemberIDs {
-			member := study.NewMember()
-			r.SetMember(id, member)
+			r.Record(study.NewRoundEvent(study.RoundEventDeadlineSet, "", study.RoundEventData{
+				Stage:    stage,
+				Deadline: deadline,
+			}))
+		}
+
+		r.RaiseEvent(study.EventTopicStudyRoundCreated,
+			fmt.Sprintf("스터디 라운드가 생성되었습니다.\n제목: %s\n참여자: %d명", params.Title, len(params.MemberIDs)))
+
+		if err := r.EventError(); err != nil {
+			return nil, err
 		}
 
 		// store new round
@@ -178,16 +429,26 @@ func (svc *studyService) NewRound(ctx context.Context, params *NewRoundParams) (
 			return nil, err
 		}
 
+		// store events of new round to outbox
+		if err := svc.storeEvents(sc, s, r.Events()); err != nil {
+			return nil, err
+		}
+
+		// store history of new round
+		if err := svc.storeHistory(sc, nr, r.History()); err != nil {
+			return nil, err
+		}
+
 		// update study
 		s.SetOngoingRoundID(nr.ID)
-		s.SetCurrentStage(study.StageRegistrationOpened)
+		s.SetCurrentStage(nr.Stage)
 
 		// update study
 		return svc.tx.UpdateStudy(sc, *s)
 	}
 
-	// execute transaction
-	s, err := svc.tx.ExecTx(ctx, txFn)
+	// execute transaction, retry on version conflict
+	s, err := svc.execTxWithRetry(ctx, txFn)
 	if err != nil {
 		return nil, err
 	}
@@ -198,9 +459,6 @@ func (svc *studyService) NewRound(ctx context.Context, params *NewRoundParams) (
 
 // create new study
 func (svc *studyService) NewStudy(ctx context.Context, params *NewStudyParams) (*study.Study, error) {
-	defer svc.mtx.Unlock()
-	svc.mtx.Lock()
-
 	if params == nil {
 		return nil, study.ErrNilParams
 	}
@@ -241,9 +499,6 @@ func (svc *studyService) NewStudy(ctx context.Context, params *NewStudyParams) (
 
 // update study and round
 func (svc *studyService) UpdateRound(ctx context.Context, params *UpdateParams, update UpdateFunc, validators ...UpdateValidator) (*study.Study, *study.Round, error) {
-	defer svc.mtx.Unlock()
-	svc.mtx.Lock()
-
 	if params == nil {
 		return nil, nil, study.ErrNilParams
 	}
@@ -282,26 +537,63 @@ func (svc *studyService) UpdateRound(ctx context.Context, params *UpdateParams,
 			}
 		}
 
+		// keep the loaded study to check if update changed it
+		loaded, err := json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+
 		// update study and round
 		update(s, r, params)
 
-		// update study
-		s, err = svc.tx.UpdateStudy(sc, *s)
-		if err != nil {
+		// events should be stored with the changes, otherwise they are lost
+		if err := r.EventError(); err != nil {
 			return nil, err
 		}
 
+		// update study only if it has been changed, so that updates of the round
+		// do not conflict with each other on the version of the study
+		if studyChanged(loaded, s) {
+			s, err = svc.tx.UpdateStudy(sc, *s)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		events := r.Events()
+		history := r.History()
+		feedbacks := r.Feedbacks()
+
 		// update round
 		r, err = svc.tx.UpdateRound(sc, *r)
 		if err != nil {
 			return nil, err
 		}
 
+		// store events raised by update to outbox in the same transaction
+		if err := svc.storeEvents(sc, s, events); err != nil {
+			return nil, err
+		}
+
+		// store changes of the round with its new version
+		if err := svc.storeHistory(sc, r, history); err != nil {
+			return nil, err
+		}
+
+		// store feedbacks written by update with the round
+		if err := svc.storeFeedbacks(sc, r, feedbacks); err != nil {
+			return nil, err
+		}
+
+		r.ClearEvents()
+		r.ClearHistory()
+		r.ClearFeedbacks()
+
 		return []any{s, r}, nil
 	}
 
-	// execute transaction
-	s, err := svc.tx.ExecTx(ctx, txFn)
+	// execute transaction, retry on version conflict
+	s, err := svc.execTxWithRetry(ctx, txFn)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -313,9 +605,6 @@ func (svc *studyService) UpdateRound(ctx context.Context, params *UpdateParams,
 
 // update study
 func (svc *studyService) UpdateStudy(ctx context.Context, params *UpdateParams, update UpdateFunc, validators ...UpdateValidator) (*study.Study, error) {
-	defer svc.mtx.Unlock()
-	svc.mtx.Lock()
-
 	if params == nil {
 		return nil, study.ErrNilParams
 	}
@@ -353,8 +642,8 @@ func (svc *studyService) UpdateStudy(ctx context.Context, params *UpdateParams,
 		return s, nil
 	}
 
-	// execute transaction
-	s, err := svc.tx.ExecTx(ctx, txFn)
+	// execute transaction, retry on version conflict
+	s, err := svc.execTxWithRetry(ctx, txFn)
 	if err != nil {
 		return nil, err
 	}
@@ -362,3 +651,85 @@ func (svc *studyService) UpdateStudy(ctx context.Context, params *UpdateParams,
 	// return updated study
 	return s.(*study.Study), nil
 }
+
+// store events to outbox with the time zone and the locale of the study, should be called in transaction
+func (svc *studyService) storeEvents(ctx context.Context, s *study.Study, events []study.Event) error {
+	for _, evt := range events {
+		evt.SetClock(s.TimeZone, s.Locale)
+
+		if _, err := svc.tx.CreateOutboxEvent(ctx, study.NewOutboxEvent(evt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// store history of the round, should be called in transaction
+func (svc *studyService) storeHistory(ctx context.Context, r *study.Round, history []study.RoundEvent) error {
+	for i, e := range history {
+		e.RoundID = r.ID
+		e.GuildID = r.GuildID
+		e.Version = r.Version
+		e.Index = i
+
+		if _, err := svc.tx.CreateRoundEvent(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// store feedbacks of the round, should be called in transaction
+func (svc *studyService) storeFeedbacks(ctx context.Context, r *study.Round, feedbacks []study.Feedback) error {
+	for _, f := range feedbacks {
+		f.RoundID = r.ID
+		f.GuildID = r.GuildID
+
+		if _, err := svc.tx.CreateFeedback(ctx, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stages of the deadlines in order of the stage
+func deadlineStages(deadlines map[study.Stage]time.Time) []study.Stage {
+	stages := make([]study.Stage, 0, len(deadlines))
+	for stage := range deadlines {
+		stages = append(stages, stage)
+	}
+
+	sort.Slice(stages, func(i, j int) bool { return stages[i] < stages[j] })
+
+	return stages
+}
+
+// check if the study differs from the encoded one
+func studyChanged(loaded []byte, s *study.Study) bool {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return true
+	}
+	return !bytes.Equal(loaded, b)
+}
+
+// execute transaction and retry the whole transaction function when the version of study or round has been changed
+func (svc *studyService) execTxWithRetry(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	var res interface{}
+	var err error
+
+	for i := 0; i <= svc.maxRetries; i++ {
+		res, err = svc.tx.ExecTx(ctx, fn)
+		if !errors.Is(err, study.ErrVersionConflict) {
+			return res, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Join(err, ctx.Err())
+		case <-time.After(svc.retryDelay * time.Duration(i+1)):
+		}
+	}
+
+	return nil, err
+}
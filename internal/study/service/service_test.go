@@ -0,0 +1,447 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/piatoss3612/my-study-bot/internal/study"
+	"github.com/piatoss3612/my-study-bot/internal/study/repository"
+	"github.com/piatoss3612/my-study-bot/internal/study/repository/memory"
+)
+
+const (
+	testGuildID   = "guild"
+	testManagerID = "manager"
+)
+
+var errTestStore = errors.New("store failed")
+
+// testTx wraps the memory repository to fail or conflict on the writes of rounds
+type testTx struct {
+	repository.Tx
+
+	conflicts   int    // number of round updates failing with version conflict
+	failOn      string // name of the write to fail
+	roundWrites int
+}
+
+func (tx *testTx) UpdateRound(ctx context.Context, r study.Round) (*study.Round, error) {
+	tx.roundWrites++
+	if tx.roundWrites <= tx.conflicts {
+		return nil, study.ErrVersionConflict
+	}
+	return tx.Tx.UpdateRound(ctx, r)
+}
+
+func (tx *testTx) CreateRoundEvent(ctx context.Context, e study.RoundEvent) (*study.RoundEvent, error) {
+	if tx.failOn == "history" {
+		return nil, errTestStore
+	}
+	return tx.Tx.CreateRoundEvent(ctx, e)
+}
+
+func (tx *testTx) CreateOutboxEvent(ctx context.Context, o study.OutboxEvent) (*study.OutboxEvent, error) {
+	if tx.failOn == "outbox" {
+		return nil, errTestStore
+	}
+	return tx.Tx.CreateOutboxEvent(ctx, o)
+}
+
+// service with a study having an ongoing round of the members
+func newTestService(t *testing.T, tx repository.Tx, opts ...ServiceOptsFunc) Service {
+	t.Helper()
+
+	ctx := context.Background()
+	svc := New(tx, append([]ServiceOptsFunc{WithRetryDelay(time.Millisecond)}, opts...)...)
+
+	if _, err := svc.NewStudy(ctx, &NewStudyParams{GuildID: testGuildID, ManagerID: testManagerID}); err != nil {
+		t.Fatalf("NewStudy error = %v", err)
+	}
+
+	if _, err := svc.NewRound(ctx, &NewRoundParams{
+		GuildID:   testGuildID,
+		ManagerID: testManagerID,
+		Title:     "round",
+		MemberIDs: []string{"a", "b", "c"},
+	}); err != nil {
+		t.Fatalf("NewRound error = %v", err)
+	}
+
+	return svc
+}
+
+// study, its ongoing round, history of the round and pending outbox events
+func loadState(t *testing.T, svc Service, tx repository.Tx) (*study.Study, *study.Round, int, int) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	s, err := svc.GetStudy(ctx, testGuildID)
+	if err != nil {
+		t.Fatalf("GetStudy error = %v", err)
+	}
+
+	r, err := svc.GetRound(ctx, s.OngoingRoundID)
+	if err != nil {
+		t.Fatalf("GetRound error = %v", err)
+	}
+
+	history, err := svc.GetRoundTimeline(ctx, r.ID)
+	if err != nil {
+		t.Fatalf("GetRoundTimeline error = %v", err)
+	}
+
+	events, err := tx.FindPendingOutboxEvents(ctx, 100)
+	if err != nil {
+		t.Fatalf("FindPendingOutboxEvents error = %v", err)
+	}
+
+	return s, r, len(history), len(events)
+}
+
+func TestUpdateRoundRollback(t *testing.T) {
+	tests := []struct {
+		name    string
+		failOn  string
+		update  UpdateFunc
+		wantErr error
+	}{
+		{
+			name:    "history can not be stored",
+			failOn:  "history",
+			update:  MoveStage,
+			wantErr: errTestStore,
+		},
+		{
+			name:    "events can not be stored",
+			failOn:  "outbox",
+			update:  MoveStage,
+			wantErr: errTestStore,
+		},
+		{
+			name:   "events can not be raised",
+			failOn: "",
+			update: func(s *study.Study, r *study.Round, params *UpdateParams) {
+				MoveStage(s, r, params)
+				r.AddEventError(errTestStore)
+			},
+			wantErr: errTestStore,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &testTx{Tx: memory.NewMemoryTx()}
+			svc := newTestService(t, tx)
+
+			s, r, history, events := loadState(t, svc, tx)
+
+			tx.failOn = tt.failOn
+
+			_, _, err := svc.UpdateRound(context.Background(), &UpdateParams{GuildID: testGuildID}, tt.update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateRound error = %v, want %v", err, tt.wantErr)
+			}
+
+			// every write of the update is rolled back
+			gotS, gotR, gotHistory, gotEvents := loadState(t, svc, tx)
+
+			if gotS.Version != s.Version || gotS.CurrentStage != s.CurrentStage {
+				t.Errorf("study = version %d, stage %v, want version %d, stage %v", gotS.Version, gotS.CurrentStage, s.Version, s.CurrentStage)
+			}
+			if gotR.Version != r.Version || gotR.Stage != r.Stage {
+				t.Errorf("round = version %d, stage %v, want version %d, stage %v", gotR.Version, gotR.Stage, r.Version, r.Stage)
+			}
+			if gotHistory != history {
+				t.Errorf("history = %d events, want %d", gotHistory, history)
+			}
+			if gotEvents != events {
+				t.Errorf("outbox = %d events, want %d", gotEvents, events)
+			}
+		})
+	}
+}
+
+func TestUpdateRoundRetryOnConflict(t *testing.T) {
+	const maxRetries = 2
+
+	tests := []struct {
+		name      string
+		conflicts int
+		wantErr   error
+	}{
+		{name: "no conflict", conflicts: 0},
+		{name: "conflict once", conflicts: 1},
+		{name: "conflicts up to max retries", conflicts: maxRetries},
+		{name: "conflicts more than max retries", conflicts: maxRetries + 1, wantErr: study.ErrVersionConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &testTx{Tx: memory.NewMemoryTx()}
+			svc := newTestService(t, tx, WithMaxRetries(maxRetries))
+
+			s, r, history, _ := loadState(t, svc, tx)
+
+			tx.conflicts = tx.roundWrites + tt.conflicts
+
+			calls := 0
+			update := func(s *study.Study, r *study.Round, params *UpdateParams) {
+				calls++
+				MoveStage(s, r, params)
+			}
+
+			_, _, err := svc.UpdateRound(context.Background(), &UpdateParams{GuildID: testGuildID}, update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateRound error = %v, want %v", err, tt.wantErr)
+			}
+
+			// the whole transaction is run again on each conflict
+			wantCalls := tt.conflicts + 1
+			if tt.wantErr != nil {
+				wantCalls = maxRetries + 1
+			}
+			if calls != wantCalls {
+				t.Errorf("update is called %d times, want %d", calls, wantCalls)
+			}
+
+			// the update is applied once if it succeeds, never if it fails
+			want := 1
+			if tt.wantErr != nil {
+				want = 0
+			}
+
+			gotS, gotR, gotHistory, _ := loadState(t, svc, tx)
+
+			if gotS.Version != s.Version+int64(want) {
+				t.Errorf("study version = %d, want %d", gotS.Version, s.Version+int64(want))
+			}
+			if gotR.Version != r.Version+int64(want) {
+				t.Errorf("round version = %d, want %d", gotR.Version, r.Version+int64(want))
+			}
+			if gotHistory != history+want {
+				t.Errorf("history = %d events, want %d", gotHistory, history+want)
+			}
+		})
+	}
+}
+
+func TestProjectRound(t *testing.T) {
+	type step struct {
+		update UpdateFunc
+		params UpdateParams
+	}
+
+	deadline := time.Date(2024, time.March, 2, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "created",
+		},
+		{
+			name: "registration with waitlist",
+			steps: []step{
+				{update: SetSpeakerCap, params: UpdateParams{SpeakerCap: 1}},
+				{update: RegisterMember, params: UpdateParams{MemberID: "a", MemberName: "A", Subject: "go"}},
+				{update: RegisterMember, params: UpdateParams{MemberID: "b", MemberName: "B", Subject: "rust"}},
+				{update: RegisterMember, params: UpdateParams{MemberID: "c", MemberName: "C", Subject: "zig"}},
+				{update: MoveWaitlistMember, params: UpdateParams{MemberID: "c", Position: 1}},
+				{update: WithdrawMember, params: UpdateParams{MemberID: "a"}},
+				{update: EditMember, params: UpdateParams{MemberID: "c", Subject: "c"}},
+			},
+		},
+		{
+			name: "stages and deadlines",
+			steps: []step{
+				{update: SetStageDeadline, params: UpdateParams{Stage: study.StageRegistrationOpened, Deadline: deadline}},
+				{update: SetStageDeadline, params: UpdateParams{Stage: study.StageSubmissionOpened, Deadline: deadline.Add(24 * time.Hour)}},
+				{update: SetStageDeadline, params: UpdateParams{Stage: study.StageRegistrationOpened}},
+				{update: MoveStage},
+				{update: MoveStage},
+				{update: RollbackStage},
+				{update: PauseRound},
+			},
+		},
+		{
+			name: "presentation and reviews",
+			steps: []step{
+				{update: RegisterMember, params: UpdateParams{MemberID: "a", MemberName: "A", Subject: "go"}},
+				{update: RegisterMember, params: UpdateParams{MemberID: "b", MemberName: "B", Subject: "rust"}},
+				{update: MoveStage},
+				{update: MoveStage},
+				{update: SubmitMemberContent, params: UpdateParams{MemberID: "a", ContentURL: "https://example.com/a"}},
+				{update: MoveStage},
+				{update: MoveStage},
+				{update: PrepareLineup},
+				{update: MoveToNextSpeaker},
+				{update: MoveToNextSpeaker},
+				{update: MoveToNextSpeaker},
+				{update: MoveStage},
+				{update: MoveStage},
+				{update: SendFeedback, params: UpdateParams{ReviewerID: "c", RevieweeID: "a", Content: "good"}},
+				{update: SetSentReflection, params: UpdateParams{MemberID: "a"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			tx := memory.NewMemoryTx()
+			svc := newTestService(t, tx)
+
+			var r *study.Round
+			var err error
+
+			_, r, _, _ = loadState(t, svc, tx)
+
+			for n, st := range tt.steps {
+				params := st.params
+				params.GuildID = testGuildID
+
+				_, r, err = svc.UpdateRound(ctx, &params, st.update)
+				if err != nil {
+					t.Fatalf("step %d: UpdateRound error = %v", n, err)
+				}
+			}
+
+			// read the stored round rather than the returned one, both are compared as stored
+			stored, err := svc.GetRound(ctx, r.ID)
+			if err != nil {
+				t.Fatalf("GetRound error = %v", err)
+			}
+
+			projected, err := svc.ProjectRound(ctx, r.ID)
+			if err != nil {
+				t.Fatalf("ProjectRound error = %v", err)
+			}
+
+			if got, want := encodeRound(t, projected), encodeRound(t, stored); got != want {
+				t.Errorf("projected round differs from the stored one\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestProjectRoundNotFound(t *testing.T) {
+	svc := New(memory.NewMemoryTx())
+
+	if _, err := svc.ProjectRound(context.Background(), "missing"); !errors.Is(err, study.ErrRoundNotFound) {
+		t.Errorf("ProjectRound error = %v, want %v", err, study.ErrRoundNotFound)
+	}
+}
+
+// round encoded without the times of creation and update, which are taken from the events by projection
+func encodeRound(t *testing.T, r *study.Round) string {
+	t.Helper()
+
+	rr := *r
+	rr.CreatedAt = time.Time{}
+	rr.UpdatedAt = time.Time{}
+
+	b, err := json.Marshal(rr)
+	if err != nil {
+		t.Fatalf("json.Marshal error = %v", err)
+	}
+
+	return string(b)
+}
+
+func TestNewRoundDeadlines(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+
+	tests := []struct {
+		name      string
+		deadlines map[study.Stage]time.Time
+		wantErr   error
+	}{
+		{
+			name: "no deadlines",
+		},
+		{
+			name: "deadlines of the pipeline",
+			deadlines: map[study.Stage]time.Time{
+				study.StageSubmissionOpened:   now.Add(48 * time.Hour),
+				study.StageRegistrationOpened: now.Add(24 * time.Hour),
+			},
+		},
+		{
+			name: "stage out of the pipeline",
+			deadlines: map[study.Stage]time.Time{
+				study.StageCancelled: now.Add(24 * time.Hour),
+			},
+			wantErr: study.ErrInvalidStage,
+		},
+		{
+			name: "passed deadline",
+			deadlines: map[study.Stage]time.Time{
+				study.StageRegistrationOpened: now.Add(24 * time.Hour),
+				study.StageSubmissionOpened:   now.Add(-time.Hour),
+			},
+			wantErr: study.ErrInvalidDeadline,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := New(memory.NewMemoryTx())
+
+			if _, err := svc.NewStudy(ctx, &NewStudyParams{GuildID: testGuildID, ManagerID: testManagerID}); err != nil {
+				t.Fatalf("NewStudy error = %v", err)
+			}
+
+			s, err := svc.NewRound(ctx, &NewRoundParams{
+				GuildID:   testGuildID,
+				ManagerID: testManagerID,
+				Title:     "round",
+				Deadlines: tt.deadlines,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewRound error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				// nothing is stored if any deadline is invalid
+				if _, err := svc.GetRounds(ctx, testGuildID); !errors.Is(err, study.ErrRoundNotFound) {
+					t.Errorf("GetRounds error = %v, want %v", err, study.ErrRoundNotFound)
+				}
+				return
+			}
+
+			r, err := svc.GetRound(ctx, s.OngoingRoundID)
+			if err != nil {
+				t.Fatalf("GetRound error = %v", err)
+			}
+
+			if len(r.Deadlines) != len(tt.deadlines) {
+				t.Errorf("deadlines = %v, want %v", r.Deadlines, tt.deadlines)
+			}
+			for stage, want := range tt.deadlines {
+				if got, ok := r.GetDeadline(stage); !ok || !got.Equal(want) {
+					t.Errorf("deadline of %v = %v, want %v", stage, got, want)
+				}
+			}
+
+			// deadlines are recorded with the creation of the round
+			history, err := svc.GetRoundTimeline(ctx, r.ID)
+			if err != nil {
+				t.Fatalf("GetRoundTimeline error = %v", err)
+			}
+			if len(history) != len(tt.deadlines)+1 {
+				t.Errorf("history = %d events, want %d", len(history), len(tt.deadlines)+1)
+			}
+			for _, e := range history {
+				if e.Version != r.Version {
+					t.Errorf("%s is recorded with version %d, want %d", e.Type, e.Version, r.Version)
+				}
+			}
+		})
+	}
+}
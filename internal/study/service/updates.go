@@ -1,27 +1,152 @@
 package service
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
 	"github.com/piatoss3612/my-study-bot/internal/study"
 )
 
-func MoveStage(s *study.Study, r *study.Round, _ *UpdateParams) {
-	next := s.CurrentStage.Next()
+func MoveStage(s *study.Study, r *study.Round, params *UpdateParams) {
+	prev := s.CurrentStage
+	next := r.GetPipeline().Next(s.CurrentStage)
 
 	if next == study.StageFinished {
 		s.SetCurrentStage(study.StageWait)
 		s.SetOngoingRoundID("")
-		r.SetStage(next)
+		r.Record(study.NewRoundEvent(study.RoundEventStageMoved, "", study.RoundEventData{Stage: next}))
+		if prev == study.StagePresentationStarted {
+			applyVoiceAttendance(s, r)
+		}
+		r.RaiseEvent(study.EventTopicStudyRoundProgress, fmt.Sprintf("%s: %s", r.Title, r.Stage.String()))
+
+		// finished round is recorded with its data
+		b, err := json.Marshal(r)
+		if err != nil {
+			r.AddEventError(err)
+			return
+		}
+		r.RaiseEvent(study.EventTopicStudyRoundFinished, "", b)
 		return
 	}
 
 	s.SetCurrentStage(next)
-	r.SetStage(next)
+	r.Record(study.NewRoundEvent(study.RoundEventStageMoved, "", study.RoundEventData{Stage: next}))
+	if prev == study.StagePresentationStarted {
+		applyVoiceAttendance(s, r)
+	}
+	// reviewers are assigned once when reviews open, managers can assign them again
+	if next == study.StageReviewOpened && s.AssignsReviewers() && !r.HasAssignments() {
+		params.Assigned = assignReviewers(s, r)
+	}
+	r.RaiseEvent(study.EventTopicStudyRoundProgress, fmt.Sprintf("%s: %s", r.Title, r.Stage.String()))
+}
+
+// move the round back to the previous stage of its pipeline
+func RollbackStage(s *study.Study, r *study.Round, _ *UpdateParams) {
+	current := s.CurrentStage
+	prev := r.GetPipeline().Prev(s.CurrentStage)
+
+	s.SetCurrentStage(prev)
+	r.Record(study.NewRoundEvent(study.RoundEventStageRolledBack, "", study.RoundEventData{Stage: prev}))
+	if current == study.StagePresentationStarted {
+		leaveVoiceAll(r)
+	}
+	r.RaiseEvent(study.EventTopicStudyRoundProgress, fmt.Sprintf("%s: %s (되돌림)", r.Title, r.Stage.String()))
+}
+
+func PauseRound(_ *study.Study, r *study.Round, _ *UpdateParams) {
+	r.Record(study.NewRoundEvent(study.RoundEventPaused, "", study.RoundEventData{}))
+	r.RaiseEvent(study.EventTopicStudyRoundProgress, fmt.Sprintf("%s: 일시 중지", r.Title))
+}
+
+func ResumeRound(_ *study.Study, r *study.Round, _ *UpdateParams) {
+	r.Record(study.NewRoundEvent(study.RoundEventResumed, "", study.RoundEventData{}))
+	r.RaiseEvent(study.EventTopicStudyRoundProgress, fmt.Sprintf("%s: 재개", r.Title))
+}
+
+// cancel the ongoing round, the study waits for the next round
+func CancelRound(s *study.Study, r *study.Round, params *UpdateParams) {
+	s.SetCurrentStage(study.StageWait)
+	s.SetOngoingRoundID("")
+	r.Record(study.NewRoundEvent(study.RoundEventCancelled, "", study.RoundEventData{Reason: params.Reason}))
+
+	description := fmt.Sprintf("%s: 라운드 취소", r.Title)
+	if params.Reason != "" {
+		description = fmt.Sprintf("%s (%s)", description, params.Reason)
+	}
+
+	// cancelled round is recorded with its data
+	b, err := json.Marshal(r)
+	if err != nil {
+		r.RaiseEvent(study.EventTopicStudyRoundCancelled, description)
+		return
+	}
+	r.RaiseEvent(study.EventTopicStudyRoundCancelled, description, b)
+}
+
+// set series of the study, its next run is scheduled in the time zone of the study
+func SetSeries(s *study.Study, _ *study.Round, params *UpdateParams) {
+	series := *params.Series
+	_ = series.Schedule(s.Clock().Now())
+	s.SetSeries(&series)
+}
+
+func RemoveSeries(s *study.Study, _ *study.Round, _ *UpdateParams) {
+	s.SetSeries(nil)
+}
+
+// claim the due run of the series and schedule the next run
+func AdvanceSeries(s *study.Study, _ *study.Round, _ *UpdateParams) {
+	s.Series.LastRunAt = s.Series.NextRunAt
+
+	// series without next run is not picked up again
+	if err := s.Series.Schedule(s.Clock().Now()); err != nil {
+		s.Series.NextRunAt = time.Time{}
+	}
+}
+
+// set time zone of the study, the next run of the series is scheduled again in the time zone
+func SetTimeZone(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.SetTimeZone(params.TimeZone)
+
+	if s.HasSeries() {
+		_ = s.Series.Schedule(s.Clock().Now())
+	}
+}
+
+func SetLocale(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.SetLocale(params.Locale)
+}
+
+func SetFeedbackMode(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.SetFeedbackMode(params.FeedbackMode)
 }
 
 func UpdateManagerID(s *study.Study, _ *study.Round, params *UpdateParams) {
 	s.SetManagerID(params.ManagerID)
 }
 
+func AddManager(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.AddManager(params.MemberID)
+}
+
+func RemoveManager(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.RemoveManager(params.MemberID)
+}
+
+// transfer ownership to the member, previous owner remains as a co-manager
+func TransferOwnership(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.AddManager(s.ManagerID)
+	s.RemoveManager(params.MemberID)
+	s.SetManagerID(params.MemberID)
+}
+
+func SetManagerRoleID(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.SetManagerRoleID(params.RoleID)
+}
+
 func UpdateNoticeChannelID(s *study.Study, _ *study.Round, params *UpdateParams) {
 	s.SetNoticeChannelID(params.ChannelID)
 }
@@ -30,49 +155,287 @@ func UpdateReflectionChannelID(s *study.Study, _ *study.Round, params *UpdatePar
 	s.SetReflectionChannelID(params.ChannelID)
 }
 
+// set voice channel whose presence is tracked during presentations, empty id stops tracking
+func UpdateVoiceChannelID(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.SetVoiceChannelID(params.ChannelID)
+}
+
+func SetAttendanceShare(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.SetAttendanceShare(params.Share)
+}
+
+// members given have joined the voice channel
+func JoinVoice(_ *study.Study, r *study.Round, params *UpdateParams) {
+	for _, id := range params.MemberIDs {
+		if m, ok := r.GetMember(id); ok && !m.IsInVoice() {
+			r.Record(study.NewRoundEvent(study.RoundEventVoiceJoined, id, study.RoundEventData{}))
+		}
+	}
+}
+
+// members given have left the voice channel
+func LeaveVoice(_ *study.Study, r *study.Round, params *UpdateParams) {
+	for _, id := range params.MemberIDs {
+		if m, ok := r.GetMember(id); ok && m.IsInVoice() {
+			r.Record(study.NewRoundEvent(study.RoundEventVoiceLeft, id, study.RoundEventData{}))
+		}
+	}
+}
+
+func leaveVoiceAll(r *study.Round) {
+	for _, id := range r.MembersInVoice() {
+		r.Record(study.NewRoundEvent(study.RoundEventVoiceLeft, id, study.RoundEventData{}))
+	}
+}
+
+// speakers who stayed in the voice channel for the share of the presentation session are marked as attended,
+// managers review the result afterwards
+func applyVoiceAttendance(s *study.Study, r *study.Round) {
+	leaveVoiceAll(r)
+
+	if !s.TracksVoice() || !r.HasPresentationSession() {
+		return
+	}
+
+	now := time.Now()
+
+	for _, id := range r.Speakers() {
+		if m, _ := r.GetMember(id); m.IsAttended() {
+			continue
+		}
+
+		if share := r.PresenceShare(id, now); share >= s.VoiceAttendanceShare() {
+			r.Record(study.NewRoundEvent(study.RoundEventMemberAttended, id, study.RoundEventData{Share: share}))
+		}
+	}
+}
+
+func SetReviewsPerMember(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.SetReviewsPerMember(params.Reviews)
+}
+
+func SetAssignedReviewsOnly(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.SetAssignedReviewsOnly(params.AssignedOnly)
+}
+
+// assign attended speakers to attended members again, assignments made before are replaced
+func AssignReviewers(s *study.Study, r *study.Round, params *UpdateParams) {
+	params.Assigned = assignReviewers(s, r)
+}
+
+func assignReviewers(s *study.Study, r *study.Round) bool {
+	assignments := r.ReviewAssignments(s.ReviewsPerMember)
+	if len(assignments) == 0 {
+		return false
+	}
+
+	r.Record(study.NewRoundEvent(study.RoundEventReviewersAssigned, "", study.RoundEventData{Assignments: assignments}))
+	return true
+}
+
+func AddReminder(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.AddReminder(params.Reminder)
+}
+
+func RemoveReminder(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.RemoveReminder(params.Position)
+}
+
+func SetReminderOptOut(s *study.Study, _ *study.Round, params *UpdateParams) {
+	s.SetReminderOptOut(params.MemberID, params.OptOut)
+}
+
+// mark the reminder as sent before sending it so that it is sent once, members to be reminded are set to params
+func SendReminder(s *study.Study, r *study.Round, params *UpdateParams) {
+	rr := params.Reminder
+	deadline, _ := r.GetDeadline(rr.Stage)
+	recipients := r.ReminderRecipients(rr.Target, s.ReminderOptOuts)
+
+	r.Record(study.NewRoundEvent(study.RoundEventReminderSent, "", study.RoundEventData{
+		Reminder:  &rr,
+		Deadline:  deadline,
+		MemberIDs: recipients,
+	}))
+	params.Reminded = recipients
+}
+
+// managers confirm or override attendance of speakers, the members given are attended and the others are not
+func ReviewAttendance(_ *study.Study, r *study.Round, params *UpdateParams) {
+	selected := map[string]bool{}
+	for _, id := range params.MemberIDs {
+		selected[id] = true
+	}
+
+	attended := []string{}
+
+	for _, id := range r.ReviewSpeakers() {
+		m, _ := r.GetMember(id)
+
+		switch {
+		case selected[id] && !m.IsAttended():
+			r.Record(study.NewRoundEvent(study.RoundEventMemberAttended, id, study.RoundEventData{}))
+		case !selected[id] && m.IsAttended():
+			r.Record(study.NewRoundEvent(study.RoundEventMemberReset, id, study.RoundEventData{Flag: study.MemberFlagAttendance}))
+		}
+
+		if selected[id] {
+			attended = append(attended, id)
+		}
+	}
+
+	r.Record(study.NewRoundEvent(study.RoundEventAttendanceReviewed, "", study.RoundEventData{MemberIDs: attended}))
+}
+
+// register the member as a speaker, the member waits for a seat if the round is full
 func RegisterMember(_ *study.Study, r *study.Round, params *UpdateParams) {
-	member, ok := r.GetMember(params.MemberID)
+	typ := study.RoundEventMemberRegistered
+
+	// registration of waiting member is changed without taking a seat
+	member, _ := r.GetMember(params.MemberID)
+	if !member.IsRegistered() && (r.IsWaitlisted(params.MemberID) || r.IsFull()) {
+		typ = study.RoundEventMemberWaitlisted
+	}
+
+	r.Record(study.NewRoundEvent(typ, params.MemberID, study.RoundEventData{
+		Name:    params.MemberName,
+		Subject: params.Subject,
+	}))
+}
+
+// withdraw registration of the member, the first waiting member takes the seat
+func WithdrawMember(_ *study.Study, r *study.Round, params *UpdateParams) {
+	r.Record(study.NewRoundEvent(study.RoundEventMemberWithdrawn, params.MemberID, study.RoundEventData{}))
+	params.Promoted = promoteWaitlist(r)
+}
+
+// register the member by a manager regardless of the stage and the speaker cap
+func ForceRegisterMember(_ *study.Study, r *study.Round, params *UpdateParams) {
+	r.Record(study.NewRoundEvent(study.RoundEventMemberRegistered, params.MemberID, study.RoundEventData{
+		Name:    params.MemberName,
+		Subject: params.Subject,
+	}))
+}
+
+// edit registration of the member, empty values are not changed
+func EditMember(_ *study.Study, r *study.Round, params *UpdateParams) {
+	r.Record(study.NewRoundEvent(study.RoundEventMemberEdited, params.MemberID, study.RoundEventData{
+		Name:       params.MemberName,
+		Subject:    params.Subject,
+		ContentURL: params.ContentURL,
+	}))
+}
+
+func ResetMember(_ *study.Study, r *study.Round, params *UpdateParams) {
+	r.Record(study.NewRoundEvent(study.RoundEventMemberReset, params.MemberID, study.RoundEventData{
+		Flag: params.Flag,
+	}))
+}
+
+// set presentation order of registered speakers
+func SetLineup(_ *study.Study, r *study.Round, params *UpdateParams) {
+	r.Record(study.NewRoundEvent(study.RoundEventLineupSet, "", study.RoundEventData{
+		MemberIDs: r.BuildLineup(params.Order, params.MemberIDs),
+		Order:     params.Order,
+	}))
+}
+
+// speakers present in order of registration if the lineup is not set
+func PrepareLineup(s *study.Study, r *study.Round, _ *UpdateParams) {
+	if r.HasLineup() {
+		return
+	}
+	SetLineup(s, r, &UpdateParams{Order: study.LineupOrderRegistration})
+}
+
+// end the talk of the current speaker and start the talk of the next speaker, who is marked as attended
+func MoveToNextSpeaker(s *study.Study, r *study.Round, params *UpdateParams) {
+	if r.CurrentSpeaker != "" {
+		r.Record(study.NewRoundEvent(study.RoundEventTalkEnded, r.CurrentSpeaker, study.RoundEventData{}))
+	}
+
+	next, ok := r.NextSpeaker()
 	if !ok {
-		member = study.NewMember()
+		return
 	}
 
-	member.SetName(params.MemberName)
-	member.SetSubject(params.Subject)
-	member.SetRegistered(true)
+	r.Record(study.NewRoundEvent(study.RoundEventTalkStarted, next, study.RoundEventData{}))
 
-	r.SetMember(params.MemberID, member)
+	if m, _ := r.GetMember(next); !m.IsAttended() {
+		CheckSpeakerAttendance(s, r, &UpdateParams{MemberID: next})
+	}
 }
 
-func SubmitMemberContent(_ *study.Study, r *study.Round, params *UpdateParams) {
-	member, _ := r.GetMember(params.MemberID)
-	member.SetContentURL(params.ContentURL)
+// set speaker cap of the round, waiting members take the seats opened by raising the cap
+func SetSpeakerCap(_ *study.Study, r *study.Round, params *UpdateParams) {
+	r.Record(study.NewRoundEvent(study.RoundEventSpeakerCapSet, "", study.RoundEventData{
+		SpeakerCap: params.SpeakerCap,
+	}))
+	params.Promoted = promoteWaitlist(r)
+}
 
-	r.SetMember(params.MemberID, member)
+// move the member to the position of the waitlist
+func MoveWaitlistMember(_ *study.Study, r *study.Round, params *UpdateParams) {
+	r.Record(study.NewRoundEvent(study.RoundEventWaitlistReordered, "", study.RoundEventData{
+		MemberIDs: r.MovedWaitlist(params.MemberID, params.Position),
+	}))
 }
 
-func CheckSpeakerAttendance(_ *study.Study, r *study.Round, params *UpdateParams) {
-	member, _ := r.GetMember(params.MemberID)
-	member.SetAttended(true)
+// promote waiting members in order until the round is full
+func promoteWaitlist(r *study.Round) []string {
+	promoted := []string{}
+
+	for len(r.Waitlist) > 0 && !r.IsFull() {
+		id := r.Waitlist[0]
+		r.Record(study.NewRoundEvent(study.RoundEventMemberPromoted, id, study.RoundEventData{}))
+		promoted = append(promoted, id)
+	}
 
-	r.SetMember(params.MemberID, member)
+	return promoted
+}
+
+func SubmitMemberContent(_ *study.Study, r *study.Round, params *UpdateParams) {
+	r.Record(study.NewRoundEvent(study.RoundEventMemberContentSubmitted, params.MemberID, study.RoundEventData{
+		ContentURL: params.ContentURL,
+	}))
+}
+
+func CheckSpeakerAttendance(_ *study.Study, r *study.Round, params *UpdateParams) {
+	r.Record(study.NewRoundEvent(study.RoundEventMemberAttended, params.MemberID, study.RoundEventData{}))
 }
 
 func SubmitRoundContent(_ *study.Study, r *study.Round, params *UpdateParams) {
-	r.SetContentURL(params.ContentURL)
+	r.Record(study.NewRoundEvent(study.RoundEventContentSubmitted, "", study.RoundEventData{
+		ContentURL: params.ContentURL,
+	}))
+	r.RaiseEvent(study.EventTopicStudyRoundProgress, fmt.Sprintf("%s: 발표 영상 등록", r.Title))
 }
 
 func SetReviewer(_ *study.Study, r *study.Round, params *UpdateParams) {
-	reviewee, _ := r.GetMember(params.RevieweeID)
-	reviewee.SetReviewer(params.ReviewerID)
+	r.Record(study.NewRoundEvent(study.RoundEventReviewerSet, params.RevieweeID, study.RoundEventData{
+		ReviewerID: params.ReviewerID,
+	}))
+}
 
-	r.SetMember(params.RevieweeID, reviewee)
+// set the reviewer of the speaker and keep the feedback with the round
+func SendFeedback(s *study.Study, r *study.Round, params *UpdateParams) {
+	SetReviewer(s, r, params)
+	r.AddFeedback(study.NewFeedback(params.ReviewerID, params.RevieweeID, params.Content, params.Rubric, s.GetFeedbackMode()))
 }
 
 func SetSentReflection(_ *study.Study, r *study.Round, params *UpdateParams) {
-	member, _ := r.GetMember(params.MemberID)
-	member.SetSentReflection(true)
+	r.Record(study.NewRoundEvent(study.RoundEventReflectionSent, params.MemberID, study.RoundEventData{}))
+}
+
+func SetStageDeadline(_ *study.Study, r *study.Round, params *UpdateParams) {
+	if params.Deadline.IsZero() {
+		r.Record(study.NewRoundEvent(study.RoundEventDeadlineRemoved, "", study.RoundEventData{Stage: params.Stage}))
+		return
+	}
 
-	r.SetMember(params.MemberID, member)
+	r.Record(study.NewRoundEvent(study.RoundEventDeadlineSet, "", study.RoundEventData{
+		Stage:    params.Stage,
+		Deadline: params.Deadline,
+	}))
 }
 
 func SetSpreadsheetURL(s *study.Study, _ *study.Round, params *UpdateParams) {
@@ -2,18 +2,113 @@ package service
 
 import (
 	"errors"
-	"fmt"
+	"strings"
+	"time"
 
 	"github.com/piatoss3612/my-study-bot/internal/study"
 )
 
 func ValidateToCheckManager(s *study.Study, _ *study.Round, params *UpdateParams) error {
-	if !s.IsManager(params.ManagerID) {
+	if !s.IsManager(params.ManagerID, params.ManagerRoleIDs...) {
 		return study.ErrNotManager
 	}
 	return nil
 }
 
+func ValidateToCheckOwner(s *study.Study, _ *study.Round, params *UpdateParams) error {
+	if !s.IsOwner(params.ManagerID) {
+		return study.ErrNotOwner
+	}
+	return nil
+}
+
+func ValidateToAddManager(s *study.Study, _ *study.Round, params *UpdateParams) error {
+	if params.MemberID == "" {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("매니저로 추가할 사용자 ID가 없습니다"))
+	}
+
+	if s.IsOwner(params.MemberID) || s.IsCoManager(params.MemberID) {
+		return study.ErrAlreadyManager
+	}
+
+	return nil
+}
+
+func ValidateToRemoveManager(s *study.Study, _ *study.Round, params *UpdateParams) error {
+	if params.MemberID == "" {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("매니저에서 제외할 사용자 ID가 없습니다"))
+	}
+
+	if s.IsOwner(params.MemberID) {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("스터디 소유자는 매니저에서 제외할 수 없습니다"))
+	}
+
+	if !s.IsCoManager(params.MemberID) {
+		return study.ErrManagerNotFound
+	}
+
+	return nil
+}
+
+func ValidateToTransferOwnership(s *study.Study, _ *study.Round, params *UpdateParams) error {
+	if params.MemberID == "" {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("소유권을 넘겨받을 사용자 ID가 없습니다"))
+	}
+
+	if s.IsOwner(params.MemberID) {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("이미 스터디 소유자입니다"))
+	}
+
+	return nil
+}
+
+func ValidateToSetSeries(s *study.Study, _ *study.Round, params *UpdateParams) error {
+	if params.Series == nil {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("정기 라운드 설정이 없습니다"))
+	}
+
+	if err := params.Series.Validate(); err != nil {
+		return err
+	}
+
+	// check if the series has the next run in the time zone of the study
+	series := *params.Series
+	return series.Schedule(s.Clock().Now())
+}
+
+func ValidateToSetTimeZone(_ *study.Study, _ *study.Round, params *UpdateParams) error {
+	return study.ValidateTimeZone(params.TimeZone)
+}
+
+func ValidateToSetLocale(_ *study.Study, _ *study.Round, params *UpdateParams) error {
+	_, err := study.ParseLocale(params.Locale.String())
+	return err
+}
+
+func ValidateToSetFeedbackMode(_ *study.Study, _ *study.Round, params *UpdateParams) error {
+	_, err := study.ParseFeedbackMode(params.FeedbackMode.String())
+	return err
+}
+
+func ValidateToCheckSeries(s *study.Study, _ *study.Round, _ *UpdateParams) error {
+	if !s.HasSeries() {
+		return study.ErrSeriesNotFound
+	}
+	return nil
+}
+
+func ValidateToRunSeries(s *study.Study, _ *study.Round, _ *UpdateParams) error {
+	if !s.HasSeries() {
+		return study.ErrSeriesNotFound
+	}
+
+	if !s.Series.IsDue(time.Now()) {
+		return study.ErrSeriesNotDue
+	}
+
+	return nil
+}
+
 func ValidateToCheckOngoingRound(s *study.Study, _ *study.Round, _ *UpdateParams) error {
 	if s.CurrentStage.IsNone() || s.CurrentStage.IsWait() {
 		return study.ErrRoundNotFound
@@ -21,13 +116,84 @@ func ValidateToCheckOngoingRound(s *study.Study, _ *study.Round, _ *UpdateParams
 	return nil
 }
 
+func ValidateToCheckNotPaused(_ *study.Study, r *study.Round, _ *UpdateParams) error {
+	if r.IsPaused() {
+		return study.ErrRoundPaused
+	}
+	return nil
+}
+
+func ValidateToRollbackStage(s *study.Study, r *study.Round, _ *UpdateParams) error {
+	if r.GetPipeline().Prev(s.CurrentStage).IsNone() {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("이전 단계로 되돌릴 수 없습니다"))
+	}
+	return nil
+}
+
+func ValidateToPauseRound(_ *study.Study, r *study.Round, _ *UpdateParams) error {
+	if r.IsPaused() {
+		return study.ErrRoundPaused
+	}
+	return nil
+}
+
+func ValidateToResumeRound(_ *study.Study, r *study.Round, _ *UpdateParams) error {
+	if !r.IsPaused() {
+		return study.ErrRoundNotPaused
+	}
+	return nil
+}
+
 func ValidateToRegister(s *study.Study, r *study.Round, params *UpdateParams) error {
 	if params.MemberID == "" {
-		return errors.Join(study.ErrInvalidUpdateParams, fmt.Errorf("등록할 사용자 ID가 없습니다"))
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("등록할 사용자 ID가 없습니다"))
+	}
+
+	if r.IsPaused() {
+		return study.ErrRoundPaused
+	}
+
+	if !r.GetPipeline().Allows(s.CurrentStage, study.ActionRegister) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("발표자 등록이 불가능한 단계입니다"))
+	}
+
+	member, ok := r.GetMember(params.MemberID)
+	if ok && member.IsRegistered() {
+		return study.ErrAlreadyRegistered
+	}
+
+	if r.IsWaitlisted(params.MemberID) {
+		return study.ErrAlreadyWaitlisted
+	}
+
+	return nil
+}
+
+// members can withdraw while they can register or submit their content
+func ValidateToWithdraw(s *study.Study, r *study.Round, params *UpdateParams) error {
+	if params.MemberID == "" {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("등록을 취소할 사용자 ID가 없습니다"))
+	}
+
+	if r.IsPaused() {
+		return study.ErrRoundPaused
 	}
 
-	if !s.CurrentStage.IsRegistrationOpened() {
-		return errors.Join(study.ErrInvalidStage, fmt.Errorf("발표자 등록이 불가능한 단계입니다"))
+	p := r.GetPipeline()
+	if !p.Allows(s.CurrentStage, study.ActionRegister) && !p.Allows(s.CurrentStage, study.ActionSubmitContent) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("발표자 등록 취소가 불가능한 단계입니다"))
+	}
+
+	return validateRegistered(r, params.MemberID)
+}
+
+func ValidateToForceRegister(_ *study.Study, r *study.Round, params *UpdateParams) error {
+	if params.MemberID == "" {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("등록할 사용자 ID가 없습니다"))
+	}
+
+	if params.MemberName == "" || params.Subject == "" {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("이름과 발표 주제는 필수 입력 사항입니다"))
 	}
 
 	member, ok := r.GetMember(params.MemberID)
@@ -38,13 +204,145 @@ func ValidateToRegister(s *study.Study, r *study.Round, params *UpdateParams) er
 	return nil
 }
 
+// managers can unregister members at any stage of the round
+func ValidateToUnregister(_ *study.Study, r *study.Round, params *UpdateParams) error {
+	if params.MemberID == "" {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("등록을 취소할 사용자 ID가 없습니다"))
+	}
+
+	return validateRegistered(r, params.MemberID)
+}
+
+func ValidateToEditMember(_ *study.Study, r *study.Round, params *UpdateParams) error {
+	if params.MemberID == "" {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("정보를 수정할 사용자 ID가 없습니다"))
+	}
+
+	if params.MemberName == "" && params.Subject == "" && params.ContentURL == "" {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("수정할 이름, 주제 또는 링크를 입력해주세요"))
+	}
+
+	if _, ok := r.GetMember(params.MemberID); !ok {
+		return study.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+func ValidateToResetMember(_ *study.Study, r *study.Round, params *UpdateParams) error {
+	if params.MemberID == "" {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("상태를 초기화할 사용자 ID가 없습니다"))
+	}
+
+	if !params.Flag.IsValid() {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("초기화할 수 없는 상태입니다: %s", params.Flag))
+	}
+
+	if _, ok := r.GetMember(params.MemberID); !ok {
+		return study.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// lineup can be set until the presentation stage
+func ValidateToSetLineup(s *study.Study, r *study.Round, params *UpdateParams) error {
+	if !params.Order.IsValid() {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("발표 순서를 정하는 방식을 선택해주세요"))
+	}
+
+	if s.CurrentStage > study.StagePresentationStarted {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("발표 순서를 정할 수 없는 단계입니다"))
+	}
+
+	if r.RegisteredCount() == 0 {
+		return study.ErrNoSpeakers
+	}
+
+	return nil
+}
+
+func ValidateToStartPresentation(s *study.Study, r *study.Round, _ *UpdateParams) error {
+	if r.IsPaused() {
+		return study.ErrRoundPaused
+	}
+
+	if !s.CurrentStage.IsPresentationStarted() {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("발표 단계에서만 발표를 진행할 수 있습니다"))
+	}
+
+	if r.RegisteredCount() == 0 {
+		return study.ErrNoSpeakers
+	}
+
+	return nil
+}
+
+func ValidateToMoveToNextSpeaker(s *study.Study, r *study.Round, params *UpdateParams) error {
+	if err := ValidateToStartPresentation(s, r, params); err != nil {
+		return err
+	}
+
+	if !r.HasLineup() {
+		return study.ErrLineupNotFound
+	}
+
+	if r.IsLineupFinished() {
+		return study.ErrLineupFinished
+	}
+
+	return nil
+}
+
+// check if the member is registered or waiting for a seat
+func validateRegistered(r *study.Round, memberID string) error {
+	member, ok := r.GetMember(memberID)
+	if !ok {
+		return study.ErrMemberNotFound
+	}
+
+	if !member.IsRegistered() && !r.IsWaitlisted(memberID) {
+		return study.ErrNotRegistered
+	}
+
+	return nil
+}
+
+// registered speakers are kept when the cap is lowered below their number
+func ValidateToSetSpeakerCap(_ *study.Study, _ *study.Round, params *UpdateParams) error {
+	if params.SpeakerCap < 0 {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("발표자 정원은 0 이상이어야 합니다"))
+	}
+	return nil
+}
+
+func ValidateToMoveWaitlistMember(_ *study.Study, r *study.Round, params *UpdateParams) error {
+	if params.MemberID == "" {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("순서를 변경할 사용자 ID가 없습니다"))
+	}
+
+	if !r.IsWaitlisted(params.MemberID) {
+		return study.ErrNotWaitlisted
+	}
+
+	if params.Position < 1 || params.Position > len(r.Waitlist) {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("대기 순번은 1부터 %d 사이여야 합니다", len(r.Waitlist)))
+	}
+
+	return nil
+}
+
 func ValidateToChangeRegistration(s *study.Study, r *study.Round, params *UpdateParams) error {
 	if params.MemberID == "" {
-		return errors.Join(study.ErrInvalidUpdateParams, fmt.Errorf("발표자 등록 정보를 변경할 사용자 ID가 없습니다"))
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("발표자 등록 정보를 변경할 사용자 ID가 없습니다"))
+	}
+
+	if r.IsPaused() {
+		return study.ErrRoundPaused
 	}
 
-	if !s.CurrentStage.IsRegistrationOpened() {
-		return errors.Join(study.ErrInvalidStage, fmt.Errorf("발표자 등록 정보 변경이 불가능한 단계입니다"))
+	if !r.GetPipeline().Allows(s.CurrentStage, study.ActionRegister) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("발표자 등록 정보 변경이 불가능한 단계입니다"))
 	}
 
 	member, ok := r.GetMember(params.MemberID)
@@ -52,7 +350,7 @@ func ValidateToChangeRegistration(s *study.Study, r *study.Round, params *Update
 		return study.ErrMemberNotFound
 	}
 
-	if !member.IsRegistered() {
+	if !member.IsRegistered() && !r.IsWaitlisted(params.MemberID) {
 		return study.ErrNotRegistered
 	}
 
@@ -61,11 +359,15 @@ func ValidateToChangeRegistration(s *study.Study, r *study.Round, params *Update
 
 func ValidateToSubmitMemberContent(s *study.Study, r *study.Round, params *UpdateParams) error {
 	if params.MemberID == "" {
-		return errors.Join(study.ErrInvalidUpdateParams, fmt.Errorf("발표자료를 제출할 사용자 ID가 없습니다"))
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("발표자료를 제출할 사용자 ID가 없습니다"))
+	}
+
+	if r.IsPaused() {
+		return study.ErrRoundPaused
 	}
 
-	if !s.CurrentStage.IsSubmissionOpened() {
-		return errors.Join(study.ErrInvalidStage, fmt.Errorf("발표자료 제출이 불가능한 단계입니다"))
+	if !r.GetPipeline().Allows(s.CurrentStage, study.ActionSubmitContent) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("발표자료 제출이 불가능한 단계입니다"))
 	}
 
 	member, ok := r.GetMember(params.MemberID)
@@ -80,13 +382,138 @@ func ValidateToSubmitMemberContent(s *study.Study, r *study.Round, params *Updat
 	return nil
 }
 
+func ValidateToSetAttendanceShare(_ *study.Study, _ *study.Round, params *UpdateParams) error {
+	if params.Share < 1 || params.Share > 100 {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("출석 인정 비율은 1부터 100 사이여야 합니다"))
+	}
+	return nil
+}
+
+// presence in the voice channel is recorded only during presentations
+func ValidateToTrackVoice(s *study.Study, _ *study.Round, params *UpdateParams) error {
+	if !s.TracksVoice() {
+		return study.ErrVoiceChannelNotSet
+	}
+
+	if s.CurrentStage != study.StagePresentationStarted {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("발표 중에만 음성 채널 참여를 기록합니다"))
+	}
+
+	if len(params.MemberIDs) == 0 {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("음성 채널에 참여한 사용자 ID가 없습니다"))
+	}
+
+	return nil
+}
+
+func ValidateToReviewAttendance(s *study.Study, r *study.Round, params *UpdateParams) error {
+	if r.IsPaused() {
+		return study.ErrRoundPaused
+	}
+
+	if !r.GetPipeline().Allows(s.CurrentStage, study.ActionCheckAttendance) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("발표자 출석체크가 불가능한 단계입니다"))
+	}
+
+	speakers := r.ReviewSpeakers()
+	if len(speakers) == 0 {
+		return study.ErrNoSpeakers
+	}
+
+	reviewable := map[string]bool{}
+	for _, id := range speakers {
+		reviewable[id] = true
+	}
+
+	for _, id := range params.MemberIDs {
+		if !reviewable[id] {
+			return errors.Join(study.ErrMemberNotRegistered, study.Errorf("출석을 검토할 수 없는 사용자입니다: <@%s>", id))
+		}
+	}
+
+	return nil
+}
+
+func ValidateToSetReviewsPerMember(_ *study.Study, _ *study.Round, params *UpdateParams) error {
+	if params.Reviews < 0 || params.Reviews > study.MaxReviewsPerMember {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("배정할 발표자 수는 0부터 %d 사이여야 합니다", study.MaxReviewsPerMember))
+	}
+	return nil
+}
+
+func ValidateToAssignReviewers(s *study.Study, r *study.Round, _ *UpdateParams) error {
+	if !s.AssignsReviewers() {
+		return errors.Join(study.ErrReviewersNotAssigned, study.Errorf("리뷰어마다 배정할 발표자 수를 먼저 설정해주세요"))
+	}
+
+	if r.IsPaused() {
+		return study.ErrRoundPaused
+	}
+
+	if !r.GetPipeline().Allows(s.CurrentStage, study.ActionReview) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("리뷰어 배정이 불가능한 단계입니다"))
+	}
+
+	if len(r.ReviewAssignments(s.ReviewsPerMember)) == 0 {
+		return errors.Join(study.ErrNoSpeakers, study.Errorf("출석한 발표자가 없어 리뷰어를 배정할 수 없습니다"))
+	}
+
+	return nil
+}
+
+func ValidateToAddReminder(s *study.Study, _ *study.Round, params *UpdateParams) error {
+	if len(s.Reminders) >= study.MaxReminders {
+		return errors.Join(study.ErrInvalidArgs, study.Errorf("리마인더는 최대 %d개까지 설정할 수 있습니다", study.MaxReminders))
+	}
+	return params.Reminder.Validate()
+}
+
+func ValidateToRemoveReminder(s *study.Study, _ *study.Round, params *UpdateParams) error {
+	if params.Position < 1 || params.Position > len(s.Reminders) {
+		return study.ErrReminderNotFound
+	}
+	return nil
+}
+
+func ValidateToSetReminderOptOut(_ *study.Study, _ *study.Round, params *UpdateParams) error {
+	if params.MemberID == "" {
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("리마인더 수신 여부를 설정할 사용자 ID가 없습니다"))
+	}
+	return nil
+}
+
+// reminder should be one of the rules of the study and due, reminders already sent are not due
+func ValidateToSendReminder(s *study.Study, r *study.Round, params *UpdateParams) error {
+	found := false
+	for _, rr := range s.Reminders {
+		if rr == params.Reminder {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return study.ErrReminderNotFound
+	}
+
+	if !r.IsReminderDue(params.Reminder, time.Now()) {
+		return study.ErrReminderNotDue
+	}
+
+	return nil
+}
+
 func ValidateToCheckAttendance(s *study.Study, r *study.Round, params *UpdateParams) error {
 	if params.MemberID == "" {
-		return errors.Join(study.ErrInvalidUpdateParams, fmt.Errorf("발표 참여 여부를 확인할 사용자 ID가 없습니다"))
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("발표 참여 여부를 확인할 사용자 ID가 없습니다"))
 	}
 
-	if s.CurrentStage < study.StagePresentationStarted {
-		return errors.Join(study.ErrInvalidStage, fmt.Errorf("발표자 출석체크가 불가능한 단계입니다"))
+	if r.IsPaused() {
+		return study.ErrRoundPaused
+	}
+
+	if !r.GetPipeline().Allows(s.CurrentStage, study.ActionCheckAttendance) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("발표자 출석체크가 불가능한 단계입니다"))
 	}
 
 	member, ok := r.GetMember(params.MemberID)
@@ -101,13 +528,17 @@ func ValidateToCheckAttendance(s *study.Study, r *study.Round, params *UpdatePar
 	return nil
 }
 
-func ValidateToSubmitRoundContent(s *study.Study, _ *study.Round, params *UpdateParams) error {
+func ValidateToSubmitRoundContent(s *study.Study, r *study.Round, params *UpdateParams) error {
 	if params.ContentURL == "" {
-		return errors.Join(study.ErrInvalidUpdateParams, fmt.Errorf("발표 녹화본 URL이 없습니다"))
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("발표 녹화본 URL이 없습니다"))
 	}
 
-	if s.CurrentStage < study.StagePresentationFinished {
-		return errors.Join(study.ErrInvalidStage, fmt.Errorf("발표 녹화본 제출이 불가능한 단계입니다"))
+	if r.IsPaused() {
+		return study.ErrRoundPaused
+	}
+
+	if !r.GetPipeline().Allows(s.CurrentStage, study.ActionSubmitRoundContent) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("발표 녹화본 제출이 불가능한 단계입니다"))
 	}
 
 	return nil
@@ -115,21 +546,25 @@ func ValidateToSubmitRoundContent(s *study.Study, _ *study.Round, params *Update
 
 func ValidateToSetReviewer(s *study.Study, r *study.Round, params *UpdateParams) error {
 	if params.ReviewerID == "" || params.RevieweeID == "" {
-		return errors.Join(study.ErrInvalidUpdateParams, fmt.Errorf("리뷰어 또는 리뷰 대상자 ID가 없습니다"))
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("리뷰어 또는 리뷰 대상자 ID가 없습니다"))
+	}
+
+	if r.IsPaused() {
+		return study.ErrRoundPaused
 	}
 
-	if !s.CurrentStage.IsReviewOpened() {
-		return errors.Join(study.ErrInvalidStage, fmt.Errorf("리뷰어 지정이 불가능한 단계입니다"))
+	if !r.GetPipeline().Allows(s.CurrentStage, study.ActionReview) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("리뷰어 지정이 불가능한 단계입니다"))
 	}
 
 	_, ok := r.GetMember(params.ReviewerID)
 	if !ok {
-		return errors.Join(study.ErrMemberNotFound, errors.New("스터디에 참여한 사용자만 리뷰 참여가 가능합니다"))
+		return errors.Join(study.ErrMemberNotFound, study.Errorf("스터디에 참여한 사용자만 리뷰 참여가 가능합니다"))
 	}
 
 	reviewee, ok := r.GetMember(params.RevieweeID)
 	if !ok {
-		return errors.Join(study.ErrMemberNotFound, errors.New("리뷰 대상자는 발표에 참여한 사용자여야 합니다"))
+		return errors.Join(study.ErrMemberNotFound, study.Errorf("리뷰 대상자는 발표에 참여한 사용자여야 합니다"))
 	}
 
 	if !reviewee.IsRegistered() {
@@ -144,16 +579,38 @@ func ValidateToSetReviewer(s *study.Study, r *study.Round, params *UpdateParams)
 		return study.ErrAlreadySentReview
 	}
 
+	// members review only the speakers assigned to them if the study restricts reviews
+	if s.AssignedReviewsOnly && r.HasAssignments() && !r.IsAssignedReviewee(params.ReviewerID, params.RevieweeID) {
+		return study.ErrNotAssignedReviewee
+	}
+
 	return nil
 }
 
+// feedback is validated as the review of the speaker with its content and rubric
+func ValidateToSendFeedback(s *study.Study, r *study.Round, params *UpdateParams) error {
+	if strings.TrimSpace(params.Content) == "" {
+		return errors.Join(study.ErrRequiredArgs, study.Errorf("피드백 내용을 입력해주세요"))
+	}
+
+	if err := params.Rubric.Validate(); err != nil {
+		return err
+	}
+
+	return ValidateToSetReviewer(s, r, params)
+}
+
 func ValidateToSetSendReflection(s *study.Study, r *study.Round, params *UpdateParams) error {
 	if params.MemberID == "" {
-		return errors.Join(study.ErrInvalidUpdateParams, fmt.Errorf("회고를 작성할 사용자 ID가 없습니다"))
+		return errors.Join(study.ErrInvalidUpdateParams, study.Errorf("회고를 작성할 사용자 ID가 없습니다"))
+	}
+
+	if r.IsPaused() {
+		return study.ErrRoundPaused
 	}
 
-	if s.CurrentStage < study.StagePresentationFinished {
-		return errors.Join(study.ErrInvalidStage, fmt.Errorf("회고 작성이 불가능한 단계입니다"))
+	if !r.GetPipeline().Allows(s.CurrentStage, study.ActionReflect) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("회고 작성이 불가능한 단계입니다"))
 	}
 
 	member, ok := r.GetMember(params.MemberID)
@@ -175,3 +632,33 @@ func ValidateToSetSendReflection(s *study.Study, r *study.Round, params *UpdateP
 
 	return nil
 }
+
+func ValidateToSetStageDeadline(_ *study.Study, r *study.Round, params *UpdateParams) error {
+	p := r.GetPipeline()
+
+	if !p.Contains(params.Stage) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("마감 시간을 설정할 수 없는 단계입니다"))
+	}
+
+	if p.Index(params.Stage) < p.Index(r.Stage) {
+		return errors.Join(study.ErrInvalidStage, study.Errorf("이미 지나간 단계입니다"))
+	}
+
+	// zero deadline removes the deadline of the stage
+	if params.Deadline.IsZero() {
+		return nil
+	}
+
+	if !params.Deadline.After(time.Now()) {
+		return errors.Join(study.ErrInvalidDeadline, study.Errorf("마감 시간은 현재 시간 이후여야 합니다"))
+	}
+
+	return nil
+}
+
+func ValidateToMoveStageOnDeadline(_ *study.Study, r *study.Round, _ *UpdateParams) error {
+	if !r.IsDeadlinePassed(time.Now()) {
+		return study.ErrDeadlineNotPassed
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ const (
 	StageReviewOpened         Stage = 8
 	StageReviewClosed         Stage = 9
 	StageFinished             Stage = 10
+	StageCancelled            Stage = 11
 )
 
 func (s Stage) String() string {
@@ -38,6 +39,8 @@ func (s Stage) String() string {
 		return "피드백 마감"
 	case StageFinished:
 		return "라운드 종료"
+	case StageCancelled:
+		return "라운드 취소"
 	default:
 		return "초기화"
 	}
@@ -87,27 +90,6 @@ func (s Stage) IsFinished() bool {
 	return s == StageFinished
 }
 
-func (s Stage) Next() Stage {
-	switch s {
-	case StageWait:
-		return StageRegistrationOpened
-	case StageRegistrationOpened:
-		return StageRegistrationClosed
-	case StageRegistrationClosed:
-		return StageSubmissionOpened
-	case StageSubmissionOpened:
-		return StageSubmissionClosed
-	case StageSubmissionClosed:
-		return StagePresentationStarted
-	case StagePresentationStarted:
-		return StagePresentationFinished
-	case StagePresentationFinished:
-		return StageReviewOpened
-	case StageReviewOpened:
-		return StageReviewClosed
-	case StageReviewClosed:
-		return StageFinished
-	default:
-		return StageNone
-	}
+func (s Stage) IsCancelled() bool {
+	return s == StageCancelled
 }
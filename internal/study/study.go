@@ -5,15 +5,28 @@ import (
 )
 
 type Study struct {
-	ID                  string `bson:"_id,omitempty"`
-	GuildID             string `bson:"guild_id"`
-	NoticeChannelID     string `bson:"notice_channel_id"`
-	ReflectionChannelID string `bson:"reflection_channel_id"`
-	ManagerID           string `bson:"manager_id"`
-	OngoingRoundID      string `bson:"ongoing_round_id"`
-	SpreadsheetURL      string `bson:"spreadsheet_url"`
-	CurrentStage        Stage  `bson:"current_stage"`
-	TotalRound          int8   `bson:"total_round"`
+	ID                  string          `bson:"_id,omitempty"`
+	GuildID             string          `bson:"guild_id"`
+	NoticeChannelID     string          `bson:"notice_channel_id"`
+	ReflectionChannelID string          `bson:"reflection_channel_id"`
+	ManagerID           string          `bson:"manager_id"`
+	Managers            map[string]bool `bson:"managers"`
+	ManagerRoleID       string          `bson:"manager_role_id"`
+	OngoingRoundID      string          `bson:"ongoing_round_id"`
+	SpreadsheetURL      string          `bson:"spreadsheet_url"`
+	CurrentStage        Stage           `bson:"current_stage"`
+	TotalRound          int8            `bson:"total_round"`
+	Series              *Series         `bson:"series,omitempty"`
+	TimeZone            string          `bson:"time_zone"`
+	Locale              Locale          `bson:"locale"`
+	VoiceChannelID      string          `bson:"voice_channel_id"`
+	AttendanceShare     int             `bson:"attendance_share"`
+	FeedbackMode        FeedbackMode    `bson:"feedback_mode"`
+	ReviewsPerMember    int             `bson:"reviews_per_member"`    // 0 means reviewers are not assigned
+	AssignedReviewsOnly bool            `bson:"assigned_reviews_only"` // members can review only assigned speakers
+	Reminders           []ReminderRule  `bson:"reminders"`
+	ReminderOptOuts     map[string]bool `bson:"reminder_opt_outs"` // ids of members who don't receive reminders
+	Version             int64           `bson:"version"`
 
 	CreatedAt time.Time `bson:"created_at"`
 	UpdatedAt time.Time `bson:"updated_at"`
@@ -25,6 +38,8 @@ func New() Study {
 		NoticeChannelID:     "",
 		ReflectionChannelID: "",
 		ManagerID:           "",
+		Managers:            map[string]bool{},
+		ManagerRoleID:       "",
 		OngoingRoundID:      "",
 		SpreadsheetURL:      "",
 		CurrentStage:        StageNone,
@@ -53,10 +68,57 @@ func (s *Study) SetManagerID(userID string) {
 	s.ManagerID = userID
 }
 
-func (s *Study) IsManager(userID string) bool {
+// check if the user is the owner of the study
+func (s *Study) IsOwner(userID string) bool {
 	return s.ManagerID == userID
 }
 
+// check if the user is the owner, a co-manager or has the manager role
+func (s *Study) IsManager(userID string, roleIDs ...string) bool {
+	if s.IsOwner(userID) || s.Managers[userID] {
+		return true
+	}
+
+	if s.ManagerRoleID == "" {
+		return false
+	}
+
+	for _, roleID := range roleIDs {
+		if roleID == s.ManagerRoleID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Study) AddManager(userID string) {
+	if s.Managers == nil {
+		s.Managers = map[string]bool{}
+	}
+	s.Managers[userID] = true
+}
+
+func (s *Study) RemoveManager(userID string) {
+	delete(s.Managers, userID)
+}
+
+func (s *Study) IsCoManager(userID string) bool {
+	return s.Managers[userID]
+}
+
+func (s *Study) GetManagerIDs() []string {
+	ids := []string{}
+	for id := range s.Managers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (s *Study) SetManagerRoleID(roleID string) {
+	s.ManagerRoleID = roleID
+}
+
 func (s *Study) SetOngoingRoundID(roundID string) {
 	s.OngoingRoundID = roundID
 }
@@ -69,10 +131,115 @@ func (s *Study) SetCurrentStage(state Stage) {
 	s.CurrentStage = state
 }
 
+func (s *Study) SetSeries(series *Series) {
+	s.Series = series
+}
+
+func (s *Study) HasSeries() bool {
+	return s.Series != nil
+}
+
+func (s *Study) SetTimeZone(timeZone string) {
+	s.TimeZone = timeZone
+}
+
+func (s *Study) SetLocale(locale Locale) {
+	s.Locale = locale
+}
+
+func (s *Study) SetVoiceChannelID(channelID string) {
+	s.VoiceChannelID = channelID
+}
+
+// check if presence in the voice channel is tracked for attendance
+func (s *Study) TracksVoice() bool {
+	return s.VoiceChannelID != ""
+}
+
+func (s *Study) SetAttendanceShare(share int) {
+	s.AttendanceShare = share
+}
+
+// share of the presentation session in percent that speakers should stay in the voice channel to be attended
+func (s *Study) VoiceAttendanceShare() int {
+	if s.AttendanceShare <= 0 {
+		return DefaultAttendanceShare
+	}
+	return s.AttendanceShare
+}
+
+func (s *Study) SetFeedbackMode(mode FeedbackMode) {
+	s.FeedbackMode = mode
+}
+
+// studies without feedback mode keep feedbacks anonymous
+func (s *Study) GetFeedbackMode() FeedbackMode {
+	if s.FeedbackMode == "" {
+		return DefaultFeedbackMode
+	}
+	return s.FeedbackMode
+}
+
+func (s *Study) SetReviewsPerMember(n int) {
+	s.ReviewsPerMember = n
+}
+
+// check if reviewers are assigned when reviews open
+func (s *Study) AssignsReviewers() bool {
+	return s.ReviewsPerMember > 0
+}
+
+func (s *Study) SetAssignedReviewsOnly(only bool) {
+	s.AssignedReviewsOnly = only
+}
+
+// add the reminder rule, the same rule is not added twice
+func (s *Study) AddReminder(rr ReminderRule) {
+	for _, r := range s.Reminders {
+		if r == rr {
+			return
+		}
+	}
+	s.Reminders = append(s.Reminders, rr)
+}
+
+// remove the reminder rule at the position starting from 1
+func (s *Study) RemoveReminder(position int) {
+	if position < 1 || position > len(s.Reminders) {
+		return
+	}
+	s.Reminders = append(s.Reminders[:position-1:position-1], s.Reminders[position:]...)
+}
+
+func (s *Study) SetReminderOptOut(memberID string, optOut bool) {
+	if !optOut {
+		delete(s.ReminderOptOuts, memberID)
+		return
+	}
+
+	if s.ReminderOptOuts == nil {
+		s.ReminderOptOuts = map[string]bool{}
+	}
+	s.ReminderOptOuts[memberID] = true
+}
+
+func (s *Study) IsReminderOptedOut(memberID string) bool {
+	return s.ReminderOptOuts[memberID]
+}
+
+// clock of the time zone and the locale of the study
+func (s *Study) Clock() Clock {
+	return NewClock(s.TimeZone, s.Locale)
+}
+
 func (s *Study) IncrementTotalRound() {
 	s.TotalRound++
 }
 
+func (s *Study) IncrementVersion() {
+	s.Version++
+}
+
 func (s *Study) SetUpdatedAt(t time.Time) {
 	s.UpdatedAt = t
 }
@@ -0,0 +1,118 @@
+package study
+
+import "time"
+
+// default share of the presentation session in percent to be attended by voice presence
+const DefaultAttendanceShare = 50
+
+// speakers shown in the attendance review, select menus of discord have at most 25 options
+const maxReviewSpeakers = 25
+
+// Presence is an interval the member stayed in the voice channel, LeftAt is zero while staying
+type Presence struct {
+	JoinedAt time.Time `bson:"joined_at" json:"joined_at"`
+	LeftAt   time.Time `bson:"left_at,omitempty" json:"left_at,omitempty"`
+}
+
+// check if the member is staying in the voice channel
+func (m Member) IsInVoice() bool {
+	n := len(m.Presences)
+	return n > 0 && m.Presences[n-1].LeftAt.IsZero()
+}
+
+func (m *Member) joinVoice(t time.Time) {
+	if m.IsInVoice() {
+		return
+	}
+	m.Presences = append(m.Presences, Presence{JoinedAt: t})
+}
+
+func (m *Member) leaveVoice(t time.Time) {
+	if !m.IsInVoice() {
+		return
+	}
+	m.Presences[len(m.Presences)-1].LeftAt = t
+}
+
+// time the member stayed in the voice channel between from and to, staying intervals end at to
+func (m Member) PresenceDuration(from, to time.Time) time.Duration {
+	var d time.Duration
+
+	for _, p := range m.Presences {
+		start, end := p.JoinedAt, p.LeftAt
+		if end.IsZero() || end.After(to) {
+			end = to
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(start) {
+			d += end.Sub(start)
+		}
+	}
+
+	return d
+}
+
+// track the presentation session by the stage the round moves to
+func (r *Round) movePresentationSession(from, to Stage, t time.Time) {
+	switch {
+	case from != StagePresentationStarted && to == StagePresentationStarted:
+		if r.PresentationStartedAt.IsZero() {
+			r.PresentationStartedAt = t
+		}
+		r.PresentationEndedAt = time.Time{}
+	case from == StagePresentationStarted && to != StagePresentationStarted:
+		r.PresentationEndedAt = t
+	}
+}
+
+func (r *Round) HasPresentationSession() bool {
+	return !r.PresentationStartedAt.IsZero()
+}
+
+// session is counted until now while the presentation is going on
+func (r *Round) presentationSession(now time.Time) (time.Time, time.Time) {
+	end := r.PresentationEndedAt
+	if end.IsZero() {
+		end = now
+	}
+	return r.PresentationStartedAt, end
+}
+
+// share of the presentation session in percent that the member stayed in the voice channel
+func (r *Round) PresenceShare(memberID string, now time.Time) int {
+	m, ok := r.GetMember(memberID)
+	if !ok || !r.HasPresentationSession() {
+		return 0
+	}
+
+	from, to := r.presentationSession(now)
+
+	total := to.Sub(from)
+	if total <= 0 {
+		return 0
+	}
+
+	return int(m.PresenceDuration(from, to) * 100 / total)
+}
+
+// ids of members staying in the voice channel
+func (r *Round) MembersInVoice() []string {
+	ids := []string{}
+	for id, m := range r.Members {
+		if m.IsInVoice() {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// speakers whose attendance is reviewed by managers in order of registration
+func (r *Round) ReviewSpeakers() []string {
+	speakers := r.Speakers()
+	if len(speakers) > maxReviewSpeakers {
+		speakers = speakers[:maxReviewSpeakers]
+	}
+	return speakers
+}
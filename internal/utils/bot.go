@@ -8,3 +8,10 @@ func GetGuildUserFromInteraction(i *discordgo.InteractionCreate) (user *discordg
 	}
 	return
 }
+
+func GetGuildMemberRolesFromInteraction(i *discordgo.InteractionCreate) (roles []string) {
+	if i.Member != nil {
+		roles = i.Member.Roles
+	}
+	return
+}